@@ -4,14 +4,42 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/caarlos0/env/v11"
 	"github.com/joho/godotenv"
 )
 
 type AppConfig struct {
-	Debug    bool   `env:"APP_DEBUG,required"`
-	RootPath string `env:"APP_ROOT_PATH,required"`
+	Debug                    bool   `env:"APP_DEBUG,required"`
+	RootPath                 string `env:"APP_ROOT_PATH,required"`
+	AccountDeletionGraceDays int    `env:"APP_ACCOUNT_DELETION_GRACE_DAYS" envDefault:"30"`
+	// language of emails for users without preference
+	DefaultLanguage string `env:"APP_DEFAULT_LANGUAGE" envDefault:"en"`
+	// port of the http server
+	Port string `env:"PORT" envDefault:"8080"`
+	// time given to in-flight requests, running cron tasks and pending emails on shutdown
+	ShutdownTimeoutSec int `env:"APP_SHUTDOWN_TIMEOUT_SEC" envDefault:"30"`
+	// timeout of every dependency check of the readiness probe
+	HealthTimeoutSec int `env:"APP_HEALTH_TIMEOUT_SEC" envDefault:"2"`
+	// result of the SMTP check is reused for this time, so probes don't open a connection on every call
+	HealthSMTPIntervalSec int `env:"APP_HEALTH_SMTP_INTERVAL_SEC" envDefault:"60"`
+	// addresses or CIDRs of proxies allowed to set X-Forwarded-For, without them the remote address is the client IP
+	TrustedProxies []string `env:"APP_TRUSTED_PROXIES" envSeparator:","`
+}
+
+type Log struct {
+	// output format: json or pretty, defaults to pretty in debug mode and json otherwise
+	Format string `env:"LOG_FORMAT"`
+	// minimal level: debug, info, warn or error, defaults to debug in debug mode and info otherwise
+	Level string `env:"LOG_LEVEL"`
+	// levels of components overriding the minimal one, e.g. http:warn,smtp:debug
+	ComponentLevels map[string]string `env:"LOG_COMPONENT_LEVELS"`
+	// number of identical debug and info records logged per second before sampling, zero disables sampling
+	SampleInitial int `env:"LOG_SAMPLE_INITIAL" envDefault:"0"`
+	// every n-th identical record is logged after the initial ones
+	SampleThereafter int `env:"LOG_SAMPLE_THEREAFTER" envDefault:"100"`
 }
 
 type PGConfig struct {
@@ -21,6 +49,50 @@ type PGConfig struct {
 	MigrationPath    string `env:"PG_MIGRATION_PATH"`
 }
 
+type Metrics struct {
+	Enabled bool `env:"METRICS_ENABLED" envDefault:"true"`
+	// endpoint is protected by basic auth when login is set
+	Login    string `env:"METRICS_LOGIN"`
+	Password string `env:"METRICS_PASSWORD"`
+}
+
+type CORS struct {
+	// origins allowed to call the API from browsers, defaults to FRONTEND_URL, "*" allows any origin
+	AllowedOrigins []string `env:"CORS_ALLOWED_ORIGINS"`
+	// cookies are sent with cross-origin requests, required by the cookie-based auth
+	AllowCredentials bool `env:"CORS_ALLOW_CREDENTIALS" envDefault:"true"`
+	// time browsers cache preflight responses
+	MaxAgeSec int `env:"CORS_MAX_AGE_SEC" envDefault:"600"`
+}
+
+type Security struct {
+	// zero disables HSTS
+	HSTSMaxAgeSec         int  `env:"SECURITY_HSTS_MAX_AGE_SEC" envDefault:"31536000"`
+	HSTSIncludeSubdomains bool `env:"SECURITY_HSTS_INCLUDE_SUBDOMAINS" envDefault:"false"`
+	// "none" disables the headers
+	FrameOptions   string `env:"SECURITY_FRAME_OPTIONS" envDefault:"DENY"`
+	ReferrerPolicy string `env:"SECURITY_REFERRER_POLICY" envDefault:"strict-origin-when-cross-origin"`
+}
+
+type RateLimit struct {
+	Enabled bool `env:"RATE_LIMIT_ENABLED" envDefault:"true"`
+	// store of buckets: memory for a single instance, postgres for multi-instance deployments
+	Backend string `env:"RATE_LIMIT_BACKEND" envDefault:"memory"`
+	// limits of route groups written as requests/period, groups without limit are not limited
+	Groups map[string]string `env:"RATE_LIMIT_GROUPS" envDefault:"api:600/1m,auth:20/1m,upload:10/1m,invite:30/1h"`
+}
+
+type Tracing struct {
+	// exporter of spans: otlp, stdout or none
+	Exporter    string `env:"TRACING_EXPORTER" envDefault:"none"`
+	ServiceName string `env:"TRACING_SERVICE_NAME" envDefault:"task-trail"`
+	// host:port of the OTLP/HTTP collector
+	Endpoint string `env:"TRACING_OTLP_ENDPOINT" envDefault:"localhost:4318"`
+	Insecure bool   `env:"TRACING_OTLP_INSECURE" envDefault:"false"`
+	// share of recorded traces started by the service, sampling decision of the caller is respected
+	SampleRatio float64 `env:"TRACING_SAMPLE_RATIO" envDefault:"1"`
+}
+
 type Docs struct {
 	Enabled  bool   `env:"SWAGGER_ENABLED" envDefault:"true"`
 	Login    string `env:"SWAGGER_LOGIN" envDefault:"root"`
@@ -35,14 +107,86 @@ type AuthConfig struct {
 	RTLifeMin   int    `env:"AUTH_REFRESH_TOKEN_LIFETIME_MIN,required"`
 	RTName      string `env:"AUTH_REFRESH_TOKEN_NAME" envDefault:"rt"`
 	TokenIssuer string `env:"AUTH_TOKEN_ISSUER,required"`
+	// readable cookie of the double-submit csrf token and the header the frontend sends it back in
+	CSRFName   string `env:"AUTH_CSRF_TOKEN_NAME" envDefault:"csrf"`
+	CSRFHeader string `env:"AUTH_CSRF_HEADER" envDefault:"X-CSRF-Token"`
+	// attributes of the auth cookies, secure is disabled for local HTTP development
+	CookieDomain   string `env:"AUTH_COOKIE_DOMAIN"`
+	CookieSecure   bool   `env:"AUTH_COOKIE_SECURE" envDefault:"true"`
+	CookieSameSite string `env:"AUTH_COOKIE_SAME_SITE" envDefault:"lax"`
+	// prefix names of the cookies with __Host- (__Secure- for the refresh token limited by path),
+	// browsers then accept them only from secure origin without domain
+	CookieHostPrefix bool `env:"AUTH_COOKIE_HOST_PREFIX" envDefault:"false"`
+}
+
+type Password struct {
+	MinLength        int    `env:"PASSWORD_MIN_LENGTH" envDefault:"8"`
+	MaxLength        int    `env:"PASSWORD_MAX_LENGTH" envDefault:"50"`
+	RequireUpper     bool   `env:"PASSWORD_REQUIRE_UPPER" envDefault:"true"`
+	RequireLower     bool   `env:"PASSWORD_REQUIRE_LOWER" envDefault:"true"`
+	RequireDigit     bool   `env:"PASSWORD_REQUIRE_DIGIT" envDefault:"true"`
+	RequireSpecial   bool   `env:"PASSWORD_REQUIRE_SPECIAL" envDefault:"false"`
+	DisallowEmail    bool   `env:"PASSWORD_DISALLOW_EMAIL" envDefault:"true"`
+	BreachedCheck    bool   `env:"PASSWORD_BREACHED_CHECK_ENABLED" envDefault:"true"`
+	BreachedListPath string `env:"PASSWORD_BREACHED_LIST_PATH"`
+	// hashing algorithm for new passwords, argon2id or bcrypt
+	HashAlgorithm     string `env:"PASSWORD_HASH_ALGORITHM" envDefault:"argon2id"`
+	Argon2Memory      uint32 `env:"PASSWORD_ARGON2_MEMORY_KIB" envDefault:"65536"`
+	Argon2Iterations  uint32 `env:"PASSWORD_ARGON2_ITERATIONS" envDefault:"3"`
+	Argon2Parallelism uint8  `env:"PASSWORD_ARGON2_PARALLELISM" envDefault:"2"`
 }
 
 type SMTP struct {
-	Host     string `env:"SMTP_HOST,required"`
-	Port     int    `env:"SMTP_PORT,required"`
-	User     string `env:"SMTP_USER,required"`
-	Password string `env:"SMTP_PASSWORD,required"`
+	// transport of emails: smtp, file or memory
+	Transport string `env:"MAIL_TRANSPORT" envDefault:"smtp"`
+	// directory of .eml files written by the file transport
+	FileDir  string `env:"MAIL_FILE_DIR" envDefault:"./mail"`
+	Host     string `env:"SMTP_HOST"`
+	Port     int    `env:"SMTP_PORT"`
+	User     string `env:"SMTP_USER"`
+	Password string `env:"SMTP_PASSWORD"`
 	Sender   string `env:"SMTP_SENDER"`
+	// number of workers sending emails, each of them keeps its own connection
+	Workers    int `env:"SMTP_WORKERS" envDefault:"4"`
+	MaxRetries int `env:"SMTP_MAX_RETRIES" envDefault:"3"`
+	// delay before the first retry, doubled on each next one
+	RetryDelaySec  int `env:"SMTP_RETRY_DELAY_SEC" envDefault:"1"`
+	IdleTimeoutSec int `env:"SMTP_IDLE_TIMEOUT_SEC" envDefault:"30"`
+}
+
+type Digest struct {
+	// cron spec of the daily digest job
+	DailySpec string `env:"DIGEST_DAILY_SPEC" envDefault:"0 8 * * *"`
+	// cron spec of the weekly digest job
+	WeeklySpec string `env:"DIGEST_WEEKLY_SPEC" envDefault:"0 8 * * 1"`
+}
+
+type Realtime struct {
+	// broker of real-time events: memory for a single instance, postgres for multi-instance deployments
+	Backend   string `env:"REALTIME_BACKEND" envDefault:"memory"`
+	PGChannel string `env:"REALTIME_PG_CHANNEL" envDefault:"tasktrail_events"`
+}
+
+type Webhook struct {
+	// cron spec of the delivery worker
+	Interval    string `env:"WEBHOOK_INTERVAL" envDefault:"@every 10s"`
+	MaxAttempts int    `env:"WEBHOOK_MAX_ATTEMPTS" envDefault:"8"`
+	BatchSize   int    `env:"WEBHOOK_BATCH_SIZE" envDefault:"50"`
+	// delay before the first retry, doubled on each next one
+	BackoffSec int `env:"WEBHOOK_BACKOFF_SEC" envDefault:"30"`
+	// timeout of the single delivery request
+	TimeoutSec int `env:"WEBHOOK_TIMEOUT_SEC" envDefault:"10"`
+	// let webhooks reach loopback and private addresses, e.g. local stub receivers
+	AllowPrivateNetworks bool `env:"WEBHOOK_ALLOW_PRIVATE_NETWORKS" envDefault:"false"`
+}
+
+type Outbox struct {
+	// cron spec of the delivery worker
+	Interval    string `env:"OUTBOX_INTERVAL" envDefault:"@every 10s"`
+	MaxAttempts int    `env:"OUTBOX_MAX_ATTEMPTS" envDefault:"5"`
+	BatchSize   int    `env:"OUTBOX_BATCH_SIZE" envDefault:"50"`
+	// delay before the first retry, doubled on each next one
+	BackoffSec int `env:"OUTBOX_BACKOFF_SEC" envDefault:"30"`
 }
 
 type Frontend struct {
@@ -50,6 +194,9 @@ type Frontend struct {
 	VerifyURL        string `env:"FRONTEND_VERIFY_URL,required"`
 	ResetPasswordURL string `env:"FRONTEND_RESET_PASSWORD_URL,required"`
 	ProjectURL       string `env:"FRONTEND_PROJECT_URL,required"`
+	EmailChangeURL   string `env:"FRONTEND_EMAIL_CHANGE_URL,required"`
+	EmailCancelURL   string `env:"FRONTEND_EMAIL_CHANGE_CANCEL_URL,required"`
+	ActivationURL    string `env:"FRONTEND_ACTIVATION_URL,required"`
 }
 
 type S3 struct {
@@ -61,13 +208,24 @@ type S3 struct {
 	Bucket    string `env:"S3_BUCKET"`
 }
 type Config struct {
-	App      AppConfig
-	PG       PGConfig
-	Auth     AuthConfig
-	Docs     Docs
-	SMTP     SMTP
-	Frontend Frontend
-	S3       S3
+	App       AppConfig
+	Log       Log
+	PG        PGConfig
+	Auth      AuthConfig
+	Docs      Docs
+	Metrics   Metrics
+	CORS      CORS
+	Security  Security
+	RateLimit RateLimit
+	Tracing   Tracing
+	Password  Password
+	SMTP      SMTP
+	Outbox    Outbox
+	Digest    Digest
+	Realtime  Realtime
+	Webhook   Webhook
+	Frontend  Frontend
+	S3        S3
 }
 
 func New() (*Config, error) {
@@ -79,12 +237,68 @@ func New() (*Config, error) {
 	if err := env.Parse(cfg); err != nil {
 		return nil, err
 	}
+	if cfg.Log.Format == "" {
+		cfg.Log.Format = "json"
+		if cfg.App.Debug {
+			cfg.Log.Format = "pretty"
+		}
+	}
+	if cfg.Log.Level == "" {
+		cfg.Log.Level = "info"
+		if cfg.App.Debug {
+			cfg.Log.Level = "debug"
+		}
+	}
 	if cfg.PG.MigrationEnabled {
 		if cfg.PG.MigrationPath == "" {
 			return nil, fmt.Errorf("PG_MIGRATION_PATH required if PG_MIGRATION_ENABLED")
 		}
 	}
 
+	if cfg.SMTP.Transport == "smtp" {
+		if cfg.SMTP.Host == "" ||
+			cfg.SMTP.Port == 0 ||
+			cfg.SMTP.User == "" ||
+			cfg.SMTP.Password == "" {
+			return nil, fmt.Errorf("all SMTP fields (SMTP_HOST, SMTP_PORT, SMTP_USER, SMTP_PASSWORD) must be set when MAIL_TRANSPORT is smtp")
+		}
+	}
+	if cfg.SMTP.Sender == "" {
+		cfg.SMTP.Sender = cfg.SMTP.User
+	}
+
+	switch strings.ToLower(cfg.Auth.CookieSameSite) {
+	case "lax", "strict":
+	case "none":
+		if !cfg.Auth.CookieSecure {
+			return nil, fmt.Errorf("AUTH_COOKIE_SAME_SITE none requires AUTH_COOKIE_SECURE")
+		}
+	default:
+		return nil, fmt.Errorf("unknown AUTH_COOKIE_SAME_SITE: %s", cfg.Auth.CookieSameSite)
+	}
+	if cfg.Auth.CookieHostPrefix {
+		if !cfg.Auth.CookieSecure || cfg.Auth.CookieDomain != "" {
+			return nil, fmt.Errorf("AUTH_COOKIE_HOST_PREFIX requires AUTH_COOKIE_SECURE and empty AUTH_COOKIE_DOMAIN")
+		}
+		cfg.Auth.ATName = "__Host-" + cfg.Auth.ATName
+		cfg.Auth.RTName = "__Secure-" + cfg.Auth.RTName
+		cfg.Auth.CSRFName = "__Host-" + cfg.Auth.CSRFName
+	}
+
+	if len(cfg.CORS.AllowedOrigins) == 0 {
+		cfg.CORS.AllowedOrigins = []string{cfg.Frontend.URL}
+	}
+	if cfg.CORS.AllowCredentials && slices.Contains(cfg.CORS.AllowedOrigins, "*") {
+		return nil, fmt.Errorf("CORS_ALLOWED_ORIGINS can't contain * when CORS_ALLOW_CREDENTIALS is enabled")
+	}
+
+	if cfg.Security.FrameOptions == "none" {
+		cfg.Security.FrameOptions = ""
+	}
+	if cfg.Security.ReferrerPolicy == "none" {
+		cfg.Security.ReferrerPolicy = ""
+	}
+
 	if cfg.S3.Enabled {
 		if cfg.S3.AccessKey == "" ||
 			cfg.S3.SecretKey == "" ||
@@ -23,6 +23,101 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/healthz": {
+            "get": {
+                "description": "Always ok while the process serves requests, dependencies are not checked",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "liveness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.statusRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "Checks Postgres, S3 and SMTP, each of them with its own timeout.\nReturns the result of every check, failed checks are logged as well.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "readiness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.readinessRes"
+                        }
+                    },
+                    "503": {
+                        "description": "some dependency is down",
+                        "schema": {
+                            "$ref": "#/definitions/http.readinessRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/activate": {
+            "post": {
+                "description": "set password and username of the user invited to a project, token comes from the invitation email",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "/v1/auth"
+                ],
+                "summary": "activate auto-registered user",
+                "parameters": [
+                    {
+                        "description": "token, password and username",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.activationReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
+                    },
+                    "429": {
+                        "description": "too many requests",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
+                    },
+                    "500": {
+                        "description": "internal error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
+                    }
+                }
+            }
+        },
         "/v1/auth/check": {
             "get": {
                 "security": [
@@ -30,6 +125,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
+                "description": "issues csrf cookie to the session without it",
                 "consumes": [
                     "application/json"
                 ],
@@ -53,6 +149,157 @@ const docTemplate = `{
                 }
             }
         },
+        "/v1/auth/email/cancel": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "/v1/auth"
+                ],
+                "summary": "cancel user email change",
+                "parameters": [
+                    {
+                        "description": "token",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.verifyReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "token is invalid",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
+                    },
+                    "429": {
+                        "description": "too many requests",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/email/change": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "sends confirmation link to the new email and notice with cancel link to the current one",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "/v1/auth"
+                ],
+                "summary": "request user email change",
+                "parameters": [
+                    {
+                        "description": "new email and current password",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.emailChangeReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
+                    },
+                    "401": {
+                        "description": "authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
+                    },
+                    "409": {
+                        "description": "email already taken",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
+                    },
+                    "429": {
+                        "description": "too many requests",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/email/confirm": {
+            "post": {
+                "description": "applies the new email and logs out the user from all sessions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "/v1/auth"
+                ],
+                "summary": "confirm user email change",
+                "parameters": [
+                    {
+                        "description": "token",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.verifyReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "token is invalid",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
+                    },
+                    "409": {
+                        "description": "email already taken",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
+                    },
+                    "429": {
+                        "description": "too many requests",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
+                    }
+                }
+            }
+        },
         "/v1/auth/login": {
             "post": {
                 "consumes": [
@@ -92,6 +339,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrAPI"
                         }
                     },
+                    "429": {
+                        "description": "too many requests",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
+                    },
                     "500": {
                         "description": "internal error",
                         "schema": {
@@ -163,6 +416,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/response.ErrAPI"
                         }
+                    },
+                    "429": {
+                        "description": "too many requests",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
                     }
                 }
             }
@@ -199,6 +458,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/response.ErrAPI"
                         }
+                    },
+                    "429": {
+                        "description": "too many requests",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
                     }
                 }
             }
@@ -235,6 +500,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/response.ErrAPI"
                         }
+                    },
+                    "429": {
+                        "description": "too many requests",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
                     }
                 }
             }
@@ -310,6 +581,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrAPI"
                         }
                     },
+                    "429": {
+                        "description": "too many requests",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
+                    },
                     "500": {
                         "description": "internal error",
                         "schema": {
@@ -351,6 +628,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/response.ErrAPI"
                         }
+                    },
+                    "429": {
+                        "description": "too many requests",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
                     }
                 }
             }
@@ -393,58 +676,79 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/response.ErrAPI"
                         }
+                    },
+                    "429": {
+                        "description": "too many requests",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
                     }
                 }
             }
         },
-        "/v1/projects": {
+        "/v1/events": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "List of projects where current user is a member or owner",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Server-Sent Events stream of project changes.\nEvent name is the event type, data is the JSON encoded event.\nWithout projectId the user receives events of all projects they are a member of.\nThe stream is closed when the access token expires, the client reconnects with a new one.",
                 "produces": [
-                    "application/json"
+                    "text/event-stream"
                 ],
                 "tags": [
-                    "/v1/project"
+                    "/v1/events"
+                ],
+                "summary": "stream project events",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "project id, can be repeated",
+                        "name": "projectId",
+                        "in": "query"
+                    }
                 ],
-                "summary": "get list of projects",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/response.projectRes"
-                            }
+                            "$ref": "#/definitions/pubsub.Event"
                         }
                     },
-                    "401": {
-                        "description": "authentication required",
+                    "400": {
+                        "description": "invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
+                    },
+                    "401": {
+                        "description": "authentication required",
                         "schema": {
                             "$ref": "#/definitions/response.ErrAPI"
                         }
                     },
                     "404": {
-                        "description": "user not found",
+                        "description": "project not found",
                         "schema": {
                             "$ref": "#/definitions/response.ErrAPI"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/v1/notifications": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "In-app notifications of the current user from newest to oldest, with total and unread counts",
                 "consumes": [
                     "application/json"
                 ],
@@ -452,29 +756,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "/v1/project"
+                    "/v1/notifications"
                 ],
-                "summary": "create new project",
+                "summary": "get list of notifications",
                 "parameters": [
                     {
-                        "description": "project data",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.projectCreateReq"
-                        }
+                        "type": "integer",
+                        "description": "page size, 20 by default, max 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of skipped notifications",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "return only unread notifications",
+                        "name": "unread",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.projectCreateRes"
+                            "$ref": "#/definitions/response.notificationListRes"
                         }
                     },
                     "400": {
-                        "description": "invalid request body",
+                        "description": "invalid query parameters",
                         "schema": {
                             "$ref": "#/definitions/response.ErrAPI"
                         }
@@ -484,9 +797,36 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/response.ErrAPI"
                         }
+                    }
+                }
+            }
+        },
+        "/v1/notifications/read-all": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "/v1/notifications"
+                ],
+                "summary": "mark all notifications as read",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.notificationsMarkedRes"
+                        }
                     },
-                    "404": {
-                        "description": "user not found",
+                    "401": {
+                        "description": "authentication required",
                         "schema": {
                             "$ref": "#/definitions/response.ErrAPI"
                         }
@@ -494,14 +834,13 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/projects/candidates": {
-            "get": {
+        "/v1/notifications/{id}/read": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Candidates are participatns in other projects owned by the current user",
                 "consumes": [
                     "application/json"
                 ],
@@ -509,26 +848,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "/v1/project"
+                    "/v1/notifications"
                 ],
-                "summary": "get list of candidates to add to the project",
+                "summary": "mark notification as read",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "project id",
+                        "description": "notification id",
                         "name": "id",
-                        "in": "query"
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/response.projectRes"
-                            }
-                        }
+                        "description": "OK"
                     },
                     "401": {
                         "description": "authentication required",
@@ -537,7 +871,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "project not found",
+                        "description": "notification not found",
                         "schema": {
                             "$ref": "#/definitions/response.ErrAPI"
                         }
@@ -545,14 +879,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/projects/{id}": {
+        "/v1/projects": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Project by id, where current user is a member",
+                "description": "List of projects where current user is a member or owner",
                 "consumes": [
                     "application/json"
                 ],
@@ -562,21 +896,15 @@ const docTemplate = `{
                 "tags": [
                     "/v1/project"
                 ],
-                "summary": "get project by id",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "project id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
+                "summary": "get list of projects",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.projectRes"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.projectRes"
+                            }
                         }
                     },
                     "401": {
@@ -586,22 +914,19 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "project not found",
+                        "description": "user not found",
                         "schema": {
                             "$ref": "#/definitions/response.ErrAPI"
                         }
                     }
                 }
-            }
-        },
-        "/v1/projects/{id}/members": {
+            },
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "validate list of candidates, create accounts if they do not exist yet, and add them to the project",
                 "consumes": [
                     "application/json"
                 ],
@@ -611,28 +936,30 @@ const docTemplate = `{
                 "tags": [
                     "/v1/project"
                 ],
-                "summary": "add new members to project",
+                "summary": "create new project",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "project id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "emails",
+                        "description": "project data",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.projectAddMembersReq"
+                            "$ref": "#/definitions/request.projectCreateReq"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.projectCreateRes"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
                     },
                     "401": {
                         "description": "authentication required",
@@ -649,14 +976,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/users/me": {
+        "/v1/projects/candidates": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "...",
+                "description": "Candidates are participatns in other projects owned by the current user",
                 "consumes": [
                     "application/json"
                 ],
@@ -664,25 +991,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "/v1/users"
+                    "/v1/project"
+                ],
+                "summary": "get list of candidates to add to the project",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "project id",
+                        "name": "id",
+                        "in": "query"
+                    }
                 ],
-                "summary": "return current user",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.currentRes"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.projectRes"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
+                    },
+                    "404": {
+                        "description": "project not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/v1/projects/{id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "...",
+                "description": "Project by id, where current user is a member",
                 "consumes": [
                     "application/json"
                 ],
@@ -690,31 +1042,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "/v1/users"
+                    "/v1/project"
                 ],
-                "summary": "update current user",
+                "summary": "get project by id",
                 "parameters": [
                     {
-                        "description": "user data",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.updateReq"
-                        }
+                        "type": "integer",
+                        "description": "project id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.currentRes"
-                        }
-                    },
-                    "400": {
-                        "description": "invalid request body",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrAPI"
+                            "$ref": "#/definitions/response.projectRes"
                         }
                     },
                     "401": {
@@ -724,7 +1068,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "user not found",
+                        "description": "project not found",
                         "schema": {
                             "$ref": "#/definitions/response.ErrAPI"
                         }
@@ -732,14 +1076,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/users/me/avatar": {
-            "patch": {
+        "/v1/projects/{id}/activity": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "...",
+                "description": "Audit log of the project from newest to oldest, available for project members",
                 "consumes": [
                     "application/json"
                 ],
@@ -747,23 +1091,64 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "/v1/users"
+                    "/v1/project"
                 ],
-                "summary": "upload new avatar",
+                "summary": "get project activity",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "new file",
-                        "name": "file",
-                        "in": "formData",
+                        "type": "integer",
+                        "description": "project id",
+                        "name": "id",
+                        "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size, 20 by default, max 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of skipped entries",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "return only changes made by the user",
+                        "name": "actorId",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "project",
+                            "member",
+                            "task"
+                        ],
+                        "type": "string",
+                        "description": "return only changes of the entity type",
+                        "name": "entityType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "return only changes of the entity",
+                        "name": "entityId",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.avatarRes"
+                            "$ref": "#/definitions/response.activityListRes"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
                         }
                     },
                     "401": {
@@ -771,18 +1156,24 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/response.ErrAPI"
                         }
+                    },
+                    "404": {
+                        "description": "project not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
                     }
                 }
             }
         },
-        "/v1/users/{id}": {
-            "get": {
+        "/v1/projects/{id}/members": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "...",
+                "description": "validate list of candidates, create accounts if they do not exist yet, and add them to the project",
                 "consumes": [
                     "application/json"
                 ],
@@ -790,17 +1181,719 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "/v1/users"
+                    "/v1/project"
                 ],
-                "summary": "return user by id",
+                "summary": "add new members to project",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "user id",
+                        "description": "project id",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    }
+                    },
+                    {
+                        "description": "emails",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.projectAddMembersReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "401": {
+                        "description": "authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
+                    },
+                    "404": {
+                        "description": "user not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
+                    },
+                    "429": {
+                        "description": "too many requests",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/projects/{id}/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "/v1/project"
+                ],
+                "summary": "get project webhooks",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "project id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.webhookRes"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
+                    },
+                    "404": {
+                        "description": "project not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Subscribes the URL to project events, available for the project owner.\nRequests are signed with HMAC-SHA256 of the body in the X-TaskTrail-Signature header: sha256=\u003chex\u003e.\nThe secret is generated if not given and returned only in this response.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "/v1/project"
+                ],
+                "summary": "create project webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "project id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "webhook",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.webhookCreateReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.webhookCreateRes"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
+                    },
+                    "401": {
+                        "description": "authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
+                    },
+                    "404": {
+                        "description": "project not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/projects/{id}/webhooks/{webhookId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "/v1/project"
+                ],
+                "summary": "delete project webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "project id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "webhook id",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "401": {
+                        "description": "authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
+                    },
+                    "404": {
+                        "description": "project or webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/projects/{id}/webhooks/{webhookId}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deliveries from newest to oldest with response codes and errors of the last attempt",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "/v1/project"
+                ],
+                "summary": "get webhook delivery log",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "project id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "webhook id",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size, 20 by default, max 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of skipped deliveries",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.webhookDeliveryRes"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
+                    },
+                    "401": {
+                        "description": "authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
+                    },
+                    "404": {
+                        "description": "project or webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/projects/{id}/webhooks/{webhookId}/test": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sends the webhook.test event immediately and returns the delivery result",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "/v1/project"
+                ],
+                "summary": "send test event",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "project id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "webhook id",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.webhookDeliveryRes"
+                        }
+                    },
+                    "401": {
+                        "description": "authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
+                    },
+                    "404": {
+                        "description": "project or webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "...",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "/v1/users"
+                ],
+                "summary": "return current user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.currentRes"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "...",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "/v1/users"
+                ],
+                "summary": "update current user",
+                "parameters": [
+                    {
+                        "description": "user data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.updateReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.currentRes"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
+                    },
+                    "401": {
+                        "description": "authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
+                    },
+                    "404": {
+                        "description": "user not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/me/avatar": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "...",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "/v1/users"
+                ],
+                "summary": "upload new avatar",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "new file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.avatarRes"
+                        }
+                    },
+                    "401": {
+                        "description": "authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
+                    },
+                    "429": {
+                        "description": "too many requests",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/me/deletion": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "account will be anonymized after the grace period, owned projects will be transferred to other members",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "/v1/users"
+                ],
+                "summary": "request current user account deletion",
+                "parameters": [
+                    {
+                        "description": "current password",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.accountDeletionReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.deletionRes"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
+                    },
+                    "401": {
+                        "description": "authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "/v1/users"
+                ],
+                "summary": "cancel current user account deletion",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "account deletion is not requested",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
+                    },
+                    "401": {
+                        "description": "authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/me/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ZIP archive with JSON files: profile, projects, authored tasks and files metadata",
+                "produces": [
+                    "application/zip"
+                ],
+                "tags": [
+                    "/v1/users"
+                ],
+                "summary": "export current user data",
+                "responses": {
+                    "200": {
+                        "description": "user data archive",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "401": {
+                        "description": "authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/me/notifications": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Every notification type and channel with enabled flag, and digest frequency of notification emails",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "/v1/users"
+                ],
+                "summary": "get notification preferences",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.notificationPreferencesRes"
+                        }
+                    },
+                    "401": {
+                        "description": "authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
+                    },
+                    "404": {
+                        "description": "user not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sets digest frequency and given preferences, omitted preferences are not changed.\nSecurity emails, like password reset, are always sent.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "/v1/users"
+                ],
+                "summary": "update notification preferences",
+                "parameters": [
+                    {
+                        "description": "notification preferences",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.notificationPreferencesReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.notificationPreferencesRes"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
+                    },
+                    "401": {
+                        "description": "authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
+                    },
+                    "404": {
+                        "description": "user not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrAPI"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "...",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "/v1/users"
+                ],
+                "summary": "return user by id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
                 "responses": {
                     "200": {
@@ -808,9 +1901,131 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
+        },
+        "/version": {
+            "get": {
+                "description": "Build commit and version of the applied database migrations",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "build info",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.versionRes"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "http.checkRes": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "description": "Error is the reason of the failed check",
+                    "type": "string"
+                },
+                "latencyMs": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.readinessRes": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.checkRes"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.statusRes": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.versionRes": {
+            "type": "object",
+            "properties": {
+                "commit": {
+                    "type": "string"
+                },
+                "goVersion": {
+                    "type": "string"
+                },
+                "migrationDirty": {
+                    "type": "boolean"
+                },
+                "migrationVersion": {
+                    "type": "integer"
+                }
+            }
+        },
+        "pubsub.Event": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "object"
+                },
+                "projectId": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.accountDeletionReq": {
+            "type": "object",
+            "required": [
+                "password"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.activationReq": {
+            "type": "object",
+            "required": [
+                "password",
+                "token",
+                "username"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
         "request.changePasswordReq": {
             "type": "object",
             "required": [
@@ -819,14 +2034,10 @@ const docTemplate = `{
             ],
             "properties": {
                 "newPassword": {
-                    "type": "string",
-                    "maxLength": 50,
-                    "minLength": 8
+                    "type": "string"
                 },
                 "oldPassword": {
-                    "type": "string",
-                    "maxLength": 50,
-                    "minLength": 8
+                    "type": "string"
                 }
             }
         },
@@ -842,8 +2053,22 @@ const docTemplate = `{
                 },
                 "password": {
                     "type": "string",
-                    "maxLength": 50,
-                    "minLength": 8
+                    "maxLength": 72
+                }
+            }
+        },
+        "request.emailChangeReq": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
                 }
             }
         },
@@ -858,6 +2083,55 @@ const docTemplate = `{
                 }
             }
         },
+        "request.notificationPreferenceReq": {
+            "type": "object",
+            "required": [
+                "channel",
+                "enabled",
+                "type"
+            ],
+            "properties": {
+                "channel": {
+                    "type": "string",
+                    "enum": [
+                        "email",
+                        "in_app"
+                    ]
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "project_invite"
+                    ]
+                }
+            }
+        },
+        "request.notificationPreferencesReq": {
+            "type": "object",
+            "required": [
+                "digest"
+            ],
+            "properties": {
+                "digest": {
+                    "type": "string",
+                    "enum": [
+                        "none",
+                        "daily",
+                        "weekly"
+                    ]
+                },
+                "preferences": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "$ref": "#/definitions/request.notificationPreferenceReq"
+                    }
+                }
+            }
+        },
         "request.projectAddMembersReq": {
             "type": "object",
             "required": [
@@ -895,9 +2169,7 @@ const docTemplate = `{
             ],
             "properties": {
                 "password": {
-                    "type": "string",
-                    "maxLength": 50,
-                    "minLength": 8
+                    "type": "string"
                 },
                 "token": {
                     "type": "string"
@@ -907,6 +2179,10 @@ const docTemplate = `{
         "request.updateReq": {
             "type": "object",
             "properties": {
+                "language": {
+                    "description": "Language is used for emails, one of templates.Locales",
+                    "type": "string"
+                },
                 "username": {
                     "type": "string",
                     "maxLength": 100
@@ -924,6 +2200,32 @@ const docTemplate = `{
                 }
             }
         },
+        "request.webhookCreateReq": {
+            "type": "object",
+            "required": [
+                "url"
+            ],
+            "properties": {
+                "events": {
+                    "description": "Events is the list of subscribed event types, empty list subscribes to all events",
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secret": {
+                    "description": "Secret is generated if empty",
+                    "type": "string",
+                    "maxLength": 128,
+                    "minLength": 16
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 2048
+                }
+            }
+        },
         "response.ErrAPI": {
             "type": "object",
             "properties": {
@@ -933,6 +2235,58 @@ const docTemplate = `{
                 },
                 "msg": {
                     "type": "string"
+                },
+                "requestId": {
+                    "description": "ids of the request and its trace, they are reported to support to find the failed request",
+                    "type": "string"
+                },
+                "traceId": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.activityListRes": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.activityRes"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.activityRes": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actorId": {
+                    "description": "ActorID is null when the actor account is removed",
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "diff": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "entityId": {
+                    "type": "integer"
+                },
+                "entityType": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "requestId": {
+                    "type": "string"
                 }
             }
         },
@@ -950,17 +2304,113 @@ const docTemplate = `{
                 "avatarUrl": {
                     "type": "string"
                 },
+                "deletionScheduledAt": {
+                    "type": "string"
+                },
                 "email": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
+                "language": {
+                    "type": "string"
+                },
                 "username": {
                     "type": "string"
                 }
             }
         },
+        "response.deletionRes": {
+            "type": "object",
+            "properties": {
+                "scheduledAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.notificationListRes": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.notificationRes"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "unread": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.notificationPreferenceRes": {
+            "type": "object",
+            "properties": {
+                "channel": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.notificationPreferencesRes": {
+            "type": "object",
+            "properties": {
+                "digest": {
+                    "type": "string"
+                },
+                "preferences": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.notificationPreferenceRes"
+                    }
+                }
+            }
+        },
+        "response.notificationRes": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "payload": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "projectId": {
+                    "type": "integer"
+                },
+                "readAt": {
+                    "type": "string"
+                },
+                "taskId": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.notificationsMarkedRes": {
+            "type": "object",
+            "properties": {
+                "marked": {
+                    "type": "integer"
+                }
+            }
+        },
         "response.projectCreateRes": {
             "type": "object",
             "properties": {
@@ -988,6 +2438,86 @@ const docTemplate = `{
                     "type": "integer"
                 }
             }
+        },
+        "response.webhookCreateRes": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "secret": {
+                    "description": "Secret is returned only once, on webhook creation",
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.webhookDeliveryRes": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deliveredAt": {
+                    "type": "string"
+                },
+                "eventId": {
+                    "type": "string"
+                },
+                "eventType": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lastError": {
+                    "type": "string"
+                },
+                "nextAttemptAt": {
+                    "description": "NextAttemptAt is the time of the next retry, set only for pending deliveries",
+                    "type": "string"
+                },
+                "responseCode": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.webhookRes": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
         }
     },
     "securityDefinitions": {
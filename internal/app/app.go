@@ -1,51 +1,142 @@
 package app
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	nethttp "net/http"
 	"os"
+	"os/signal"
+	"syscall"
 	"task-trail/config"
 	"task-trail/internal/controller/http"
 	"task-trail/internal/controller/http/middleware"
 	"task-trail/internal/customerrors"
 	"task-trail/internal/pkg/contextmanager"
+	"task-trail/internal/pkg/health"
+	"task-trail/internal/pkg/logger"
 	slogger "task-trail/internal/pkg/logger/slog"
+	"task-trail/internal/pkg/metrics"
+	"task-trail/internal/pkg/password"
+	"task-trail/internal/pkg/password/argon2id"
 	"task-trail/internal/pkg/password/bcrypt"
+	"task-trail/internal/pkg/password/policy"
 	"task-trail/internal/pkg/postgres"
+	"task-trail/internal/pkg/pubsub"
+	"task-trail/internal/pkg/pubsub/memory"
+	"task-trail/internal/pkg/pubsub/pgnotify"
+	"task-trail/internal/pkg/ratelimit"
+	ratememory "task-trail/internal/pkg/ratelimit/memory"
+	"task-trail/internal/pkg/ratelimit/pgstore"
+	"task-trail/internal/pkg/smtp"
+	"task-trail/internal/pkg/smtp/file"
 	"task-trail/internal/pkg/smtp/gomail"
+	mailmemory "task-trail/internal/pkg/smtp/memory"
+	"task-trail/internal/pkg/smtp/templates"
 	"task-trail/internal/pkg/storage/s3"
 	"task-trail/internal/pkg/token/jwt"
+	"task-trail/internal/pkg/tracing"
 	"task-trail/internal/pkg/uuid/guuid"
+	"task-trail/internal/pkg/webhook/httpsender"
 	"task-trail/internal/repo/api"
 	"task-trail/internal/repo/persistent"
 	"task-trail/internal/tasks"
+	activityuc "task-trail/internal/usecase/activity"
 	authuc "task-trail/internal/usecase/auth"
+	digestuc "task-trail/internal/usecase/digest"
 	fileuc "task-trail/internal/usecase/file"
+	notificationuc "task-trail/internal/usecase/notification"
+	outboxuc "task-trail/internal/usecase/outbox"
 	projectuc "task-trail/internal/usecase/project"
+	realtimeuc "task-trail/internal/usecase/realtime"
+	"task-trail/internal/usecase/traced"
 	useruc "task-trail/internal/usecase/user"
+	webhookuc "task-trail/internal/usecase/webhook"
+	"time"
 
+	"github.com/exaring/otelpgx"
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
+// shutdowner is implemented by services completing their pending work on shutdown.
+type shutdowner interface {
+	Shutdown(ctx context.Context) error
+}
+
 func Run(cfg *config.Config) {
-	logger := slogger.New(cfg.App.Debug, true)
-	logger1 := slogger.New(cfg.App.Debug, false)
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	logger, err := slogger.New(
+		slogger.Format(cfg.Log.Format),
+		slogger.Level(cfg.Log.Level),
+		slogger.ComponentLevels(cfg.Log.ComponentLevels),
+		slogger.Sampling(cfg.Log.SampleInitial, cfg.Log.SampleThereafter, time.Second),
+	)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "logger initialization error:", err)
+		os.Exit(1)
+	}
+	// middlewares log every request from the same line, so the caller is omitted
+	httpLogger := logger.Component("http").WithoutSource()
+	pgLogger := logger.Component("postgres")
+	taskLogger := logger.Component("tasks")
 	// migrate
 	if cfg.PG.MigrationEnabled {
-		if err := postgres.Migrate(cfg.PG.ConnString, cfg.PG.MigrationPath, logger); err != nil {
+		if err := postgres.Migrate(cfg.PG.ConnString, cfg.PG.MigrationPath, pgLogger); err != nil {
 			logger.Error("db migration error", "error", err.Error())
 			os.Exit(1)
 		}
 	}
+	tracer, err := tracing.New(
+		ctx,
+		cfg.Tracing.ServiceName,
+		cfg.Tracing.Exporter,
+		tracing.Endpoint(cfg.Tracing.Endpoint),
+		tracing.Insecure(cfg.Tracing.Insecure),
+		tracing.SampleRatio(cfg.Tracing.SampleRatio),
+		tracing.Version(health.Commit),
+	)
+	if err != nil {
+		logger.Error("tracing initialization error", "error", err.Error())
+		os.Exit(1)
+	}
 	// init db
-	opts := []postgres.Option{postgres.MaxPoolSize(cfg.PG.MaxPoolSize)}
-	pg, err := postgres.New(cfg.PG.ConnString, logger, opts...)
+	opts := []postgres.Option{
+		postgres.MaxPoolSize(cfg.PG.MaxPoolSize),
+		postgres.Tracer(otelpgx.NewTracer(otelpgx.WithTrimSQLInSpanName())),
+	}
+	pg, err := postgres.New(cfg.PG.ConnString, pgLogger, opts...)
 	if err != nil {
 		logger.Error("postgres connection error", "error", err.Error())
 		os.Exit(1)
 	}
-	defer pg.Close()
+
+	appMetrics := metrics.New()
+	appMetrics.RegisterPool(pg.Pool)
 
 	// init services
-	pwdService := bcrypt.New()
+	pwdService, err := newPasswordService(cfg.Password)
+	if err != nil {
+		logger.Error("password service initialization error", "error", err.Error())
+		os.Exit(1)
+	}
+	pwdPolicy, err := policy.New(
+		policy.MinLength(cfg.Password.MinLength),
+		policy.MaxLength(cfg.Password.MaxLength),
+		policy.RequireUpper(cfg.Password.RequireUpper),
+		policy.RequireLower(cfg.Password.RequireLower),
+		policy.RequireDigit(cfg.Password.RequireDigit),
+		policy.RequireSpecial(cfg.Password.RequireSpecial),
+		policy.DisallowEmail(cfg.Password.DisallowEmail),
+		policy.BreachedCheck(cfg.Password.BreachedCheck),
+		policy.BreachedListPath(cfg.Password.BreachedListPath),
+	)
+	if err != nil {
+		logger.Error("password policy initialization error", "error", err.Error())
+		os.Exit(1)
+	}
 	uuidGenerator := guuid.New()
 	tokenService := jwt.New(
 		cfg.Auth.ATSecret,
@@ -55,34 +146,89 @@ func Run(cfg *config.Config) {
 		cfg.Auth.TokenIssuer,
 		uuidGenerator)
 	errHandler := customerrors.NewErrHander()
-	contextm := contextmanager.NewGin(uuidGenerator)
-	smtp := gomail.New(logger, cfg.SMTP.Host, cfg.SMTP.Port, cfg.SMTP.User, cfg.SMTP.Password, cfg.SMTP.Sender)
+	contextm := contextmanager.NewGin(
+		uuidGenerator,
+		cfg.Auth.CSRFName,
+		contextmanager.CookieDomain(cfg.Auth.CookieDomain),
+		contextmanager.CookieSecure(cfg.Auth.CookieSecure),
+		contextmanager.CookieSameSite(cfg.Auth.CookieSameSite),
+	)
+	mailSender, err := newMailSender(cfg.SMTP, logger.Component("smtp"))
+	if err != nil {
+		logger.Error("mail transport initialization error", "error", err.Error())
+		os.Exit(1)
+	}
+	// memory transport keeps sent emails, they are exposed by the debug endpoint
+	mailbox, _ := mailSender.(smtp.Mailbox)
+	sender := appMetrics.Sender(mailSender)
+	mailRenderer, err := templates.New(cfg.App.DefaultLanguage)
+	if err != nil {
+		logger.Error("email templates initialization error", "error", err.Error())
+		os.Exit(1)
+	}
 	// TODO: storage selector! if s3 diabled then use local storage
 	storage, err := s3.New(cfg.S3.AccessKey, cfg.S3.SecretKey, cfg.S3.UploadURL, cfg.S3.PublicURL, cfg.S3.Bucket)
 	if err != nil {
 		logger.Error("s3 storage initialization error", "error", err.Error())
 		os.Exit(1)
 	}
+	broker, err := newBroker(ctx, cfg.Realtime, pg.Pool, logger.Component("realtime"))
+	if err != nil {
+		logger.Error("realtime broker initialization error", "error", err.Error())
+		os.Exit(1)
+	}
 	txManager := persistent.NewPgTxManager(pg.Pool)
 	userRepo := persistent.NewUserRepo(pg.Pool)
 	projectRepo := persistent.NewProjectRepo(pg.Pool)
 	tokenRepo := persistent.NewRefreshTokenRepo(pg.Pool)
-	notificationRepo := api.NewSmtpNotificationRepo(smtp, logger, uuidGenerator, cfg.Frontend.VerifyURL, cfg.Frontend.ResetPasswordURL, cfg.Frontend.ProjectURL)
+	outboxRepo := persistent.NewOutboxRepo(pg.Pool)
+	webhookRepo := persistent.NewWebhookRepo(pg.Pool)
+	activityRepo := persistent.NewActivityRepo(pg.Pool)
+	inAppNotificationRepo := persistent.NewNotificationRepo(pg.Pool)
+	notificationPreferenceRepo := persistent.NewNotificationPreferenceRepo(pg.Pool)
+	notificationDigestRepo := persistent.NewNotificationDigestRepo(pg.Pool)
+	emailNotificationRepo := api.NewSmtpNotificationRepo(
+		outboxRepo,
+		uuidGenerator,
+		mailRenderer,
+		userRepo,
+		cfg.Frontend.VerifyURL,
+		cfg.Frontend.ResetPasswordURL,
+		cfg.Frontend.ProjectURL,
+		cfg.Frontend.EmailChangeURL,
+		cfg.Frontend.EmailCancelURL,
+		cfg.Frontend.ActivationURL,
+	)
+	notificationRepo := api.NewFanoutNotificationRepo(
+		emailNotificationRepo,
+		inAppNotificationRepo,
+		notificationPreferenceRepo,
+		notificationDigestRepo,
+		userRepo,
+	)
 	emailTokenRepo := persistent.NewEmailTokenRepo(pg.Pool)
 	fileRepo := persistent.NewFileRepo(pg.Pool)
+	taskRepo := persistent.NewTaskRepo(pg.Pool)
 	// init uc
 	fileUC := fileuc.New(txManager, fileRepo, storage, errHandler, uuidGenerator)
 
-	userUC := useruc.New(
+	userUC := traced.NewUser(useruc.New(
 		txManager,
 		userRepo,
+		projectRepo,
+		taskRepo,
+		fileRepo,
+		tokenRepo,
+		emailTokenRepo,
 		fileUC,
 		storage,
 		pwdService,
 		errHandler,
 		uuidGenerator,
-	)
-	authUC := authuc.New(
+		logger.Component("user"),
+		cfg.App.AccountDeletionGraceDays,
+	))
+	authUC := traced.NewAuthentication(authuc.New(
 		errHandler,
 		txManager,
 		userRepo,
@@ -90,30 +236,264 @@ func Run(cfg *config.Config) {
 		emailTokenRepo,
 		notificationRepo,
 		pwdService,
+		pwdPolicy,
 		tokenService,
 		uuidGenerator,
-	)
+		logger.Component("auth"),
+	))
 
-	projectUC := projectuc.New(txManager, authUC, projectRepo, userRepo, notificationRepo, errHandler)
+	webhookUC := traced.NewWebhook(webhookuc.New(
+		webhookRepo,
+		projectRepo,
+		httpsender.New(
+			time.Duration(cfg.Webhook.TimeoutSec)*time.Second,
+			httpsender.AllowPrivateNetworks(cfg.Webhook.AllowPrivateNetworks),
+		),
+		uuidGenerator,
+		errHandler,
+		cfg.Webhook.MaxAttempts,
+		cfg.Webhook.BatchSize,
+		time.Duration(cfg.Webhook.BackoffSec)*time.Second,
+	))
+	projectUC := traced.NewProject(projectuc.New(
+		txManager,
+		authUC,
+		projectRepo,
+		userRepo,
+		notificationRepo,
+		activityRepo,
+		webhookUC,
+		broker,
+		errHandler,
+		logger.Component("project"),
+	))
+	activityUC := traced.NewActivity(activityuc.New(activityRepo, projectRepo, errHandler))
+	realtimeUC := traced.NewRealtime(realtimeuc.New(projectRepo, broker, errHandler, logger.Component("realtime")))
+	notificationUC := traced.NewNotification(notificationuc.New(txManager, inAppNotificationRepo, notificationPreferenceRepo, errHandler))
+	digestUC := traced.NewDigest(digestuc.New(notificationDigestRepo, sender, mailRenderer, uuidGenerator, errHandler, cfg.Frontend.ProjectURL))
+	outboxUC := traced.NewOutbox(outboxuc.New(
+		outboxRepo,
+		sender,
+		errHandler,
+		cfg.Outbox.MaxAttempts,
+		cfg.Outbox.BatchSize,
+		time.Duration(cfg.Outbox.BackoffSec)*time.Second,
+		// keep every worker of the smtp sender busy
+		cfg.SMTP.Workers,
+	))
+	healthService := health.New(time.Duration(cfg.App.HealthTimeoutSec)*time.Second, pg, logger.Component("health"))
+	healthService.Add("postgres", pg)
+	if cfg.S3.Enabled {
+		healthService.Add("s3", storage)
+	}
+	// only smtp transport depends on the external server
+	if c, ok := mailSender.(health.Checker); ok {
+		healthService.Add("smtp", health.Cache(c, time.Duration(cfg.App.HealthSMTPIntervalSec)*time.Second))
+	}
 	// init middlewares
 
-	recoveryMW := middleware.NewRecovery(logger1, contextm)
+	recoveryMW := middleware.NewRecovery(httpLogger, contextm)
 	requestMW := middleware.NewRequest(contextm)
-	logMW := middleware.NewLog(logger1, contextm)
+	logMW := middleware.NewLog(httpLogger)
+	metricsMW := middleware.NewMetrics(appMetrics)
 	authMW := middleware.NewAuth(tokenService, errHandler, contextm, cfg.Auth.ATName)
-	errorMW := middleware.NewError(logger1, contextm)
+	rateLimitStore, rateLimits, err := newRateLimit(cfg.RateLimit, pg.Pool)
+	if err != nil {
+		logger.Error("rate limit initialization error", "error", err.Error())
+		os.Exit(1)
+	}
+	rateLimitMW := middleware.NewRateLimit(rateLimitStore, rateLimits, tokenService, cfg.Auth.ATName, errHandler, contextm, httpLogger)
+	errorMW := middleware.NewError(httpLogger, contextm)
+	securityMW := middleware.NewSecurityHeaders(
+		time.Duration(cfg.Security.HSTSMaxAgeSec)*time.Second,
+		cfg.Security.HSTSIncludeSubdomains,
+		cfg.Security.FrameOptions,
+		cfg.Security.ReferrerPolicy,
+	)
+	corsMW := middleware.NewCORS(
+		cfg.CORS.AllowedOrigins,
+		cfg.CORS.AllowCredentials,
+		time.Duration(cfg.CORS.MaxAgeSec)*time.Second,
+		cfg.Auth.CSRFHeader,
+	)
+	csrfMW := middleware.NewCSRF(errHandler, cfg.Auth.CSRFName, cfg.Auth.CSRFHeader, cfg.Auth.ATName, cfg.Auth.RTName)
 	// init http server
 	httpServer := gin.New()
+	// gin context falls back to the request context, so spans started by otelgin reach use cases and queries
+	httpServer.ContextWithFallback = true
+	// client IP keys rate limits, so forwarded headers are accepted only from the configured proxies
+	if err := httpServer.SetTrustedProxies(cfg.App.TrustedProxies); err != nil {
+		logger.Error("trusted proxies initialization error", "error", err.Error())
+		os.Exit(1)
+	}
+	httpServer.Use(otelgin.Middleware(cfg.Tracing.ServiceName, otelgin.WithFilter(traceRequest)))
 	httpServer.Use(requestMW)
 	httpServer.Use(logMW)
+	httpServer.Use(metricsMW)
 	httpServer.Use(recoveryMW)
 	httpServer.Use(errorMW)
-	http.NewRouter(httpServer, errHandler, contextm, userUC, projectUC, authUC, storage, authMW, cfg)
-	tasks.CleanupRefreshTokens(tokenRepo, logger)
-	tasks.CleanupEmailTokens(emailTokenRepo, logger)
-	if err := httpServer.Run(); err != nil {
+	httpServer.Use(securityMW)
+	httpServer.Use(corsMW)
+	httpServer.Use(csrfMW)
+	http.NewRouter(httpServer, errHandler, contextm, userUC, projectUC, authUC, notificationUC, realtimeUC, webhookUC, activityUC, storage, mailbox, healthService, appMetrics, authMW, rateLimitMW, cfg)
+	scheduler := tasks.NewScheduler(taskLogger, appMetrics)
+	tasks.CleanupRefreshTokens(scheduler, tokenRepo, taskLogger)
+	tasks.CleanupEmailTokens(scheduler, emailTokenRepo, taskLogger)
+	tasks.DeleteScheduledUsers(scheduler, userUC, taskLogger)
+	tasks.CleanupOutbox(scheduler, outboxRepo, taskLogger)
+	tasks.DeliverOutbox(scheduler, outboxUC, cfg.Outbox.Interval, taskLogger)
+	tasks.DeliverWebhooks(scheduler, webhookUC, cfg.Webhook.Interval, taskLogger)
+	tasks.SendDigests(scheduler, digestUC, cfg.Digest.DailySpec, cfg.Digest.WeeklySpec, taskLogger)
+	if len(rateLimits) > 0 {
+		tasks.CleanupRateLimits(scheduler, rateLimitStore, ratelimit.LongestPeriod(rateLimits), taskLogger)
+	}
+	scheduler.Start()
+
+	srv := &nethttp.Server{Addr: ":" + cfg.App.Port, Handler: httpServer}
+	// real-time streams never complete by themselves, so they are closed as soon as shutdown starts
+	srv.RegisterOnShutdown(broker.Close)
+	serverErr := make(chan error, 1)
+	go func() {
+		logger.Info("http server started", "addr", srv.Addr)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, nethttp.ErrServerClosed) {
+			serverErr <- err
+		}
+	}()
+
+	exitCode := 0
+	select {
+	case <-ctx.Done():
+		logger.Info("shutdown signal received")
+	case err := <-serverErr:
 		logger.Error("http server start failed", "error", err.Error())
-		os.Exit(1)
+		exitCode = 1
+	}
+	stop()
+	shutdown(time.Duration(cfg.App.ShutdownTimeoutSec)*time.Second, logger, srv, scheduler, mailSender, tracer, pg)
+	if exitCode != 0 {
+		os.Exit(exitCode)
+	}
+}
+
+// shutdown drains in-flight requests, waits for running cron tasks, flushes pending emails and spans
+// and then closes the database pool, all steps share the timeout.
+func shutdown(
+	timeout time.Duration,
+	l logger.Logger,
+	srv *nethttp.Server,
+	scheduler *tasks.Scheduler,
+	mailSender smtp.Sender,
+	tracer *tracing.Provider,
+	pg *postgres.Postgres,
+) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		l.Error("http server shutdown failed, remaining connections are closed", "error", err.Error())
+		_ = srv.Close()
 	}
+	if err := scheduler.Stop(ctx); err != nil {
+		l.Error("cron tasks did not complete before shutdown timeout", "error", err.Error())
+	}
+	if s, ok := mailSender.(shutdowner); ok {
+		if err := s.Shutdown(ctx); err != nil {
+			l.Error("pending emails were not sent before shutdown timeout", "error", err.Error())
+		}
+	}
+	if err := tracer.Shutdown(ctx); err != nil {
+		l.Error("pending spans were not exported before shutdown timeout", "error", err.Error())
+	}
+	pg.Close()
+	l.Info("shutdown complete")
+}
+
+// traceRequest excludes probes and metrics scrapes from tracing, they are frequent and carry no useful spans.
+func traceRequest(r *nethttp.Request) bool {
+	switch r.URL.Path {
+	case "/healthz", "/readyz", "/metrics":
+		return false
+	}
+	return true
+}
+
+// newPasswordService creates password service for the configured algorithm,
+// argon2id service verifies old bcrypt hashes, so they are upgraded on login.
+func newPasswordService(cfg config.Password) (password.Service, error) {
+	switch cfg.HashAlgorithm {
+	case "argon2id":
+		return argon2id.New(
+			argon2id.Memory(cfg.Argon2Memory),
+			argon2id.Iterations(cfg.Argon2Iterations),
+			argon2id.Parallelism(cfg.Argon2Parallelism),
+			argon2id.Legacy(bcrypt.New()),
+		)
+	case "bcrypt":
+		return bcrypt.New(), nil
+	default:
+		return nil, fmt.Errorf("unknown password hash algorithm: %s", cfg.HashAlgorithm)
+	}
+}
 
+// newBroker creates broker of real-time events for the configured backend.
+func newBroker(ctx context.Context, cfg config.Realtime, pool *pgxpool.Pool, l logger.Logger) (pubsub.Broker, error) {
+	switch cfg.Backend {
+	case "memory":
+		return memory.New(), nil
+	case "postgres":
+		return pgnotify.New(ctx, pool, cfg.PGChannel, l), nil
+	default:
+		return nil, fmt.Errorf("unknown realtime backend: %s", cfg.Backend)
+	}
+}
+
+// newRateLimit creates store of rate limit buckets and parses limits of route groups,
+// limits are empty when rate limiting is disabled.
+func newRateLimit(cfg config.RateLimit, pool *pgxpool.Pool) (ratelimit.Store, map[string]ratelimit.Limit, error) {
+	var store ratelimit.Store
+	switch cfg.Backend {
+	case "memory":
+		store = ratememory.New()
+	case "postgres":
+		store = pgstore.New(pool)
+	default:
+		return nil, nil, fmt.Errorf("unknown rate limit backend: %s", cfg.Backend)
+	}
+	limits := make(map[string]ratelimit.Limit, len(cfg.Groups))
+	if !cfg.Enabled {
+		return store, limits, nil
+	}
+	for group, s := range cfg.Groups {
+		limit, err := ratelimit.ParseLimit(s)
+		if err != nil {
+			return nil, nil, fmt.Errorf("group %s: %w", group, err)
+		}
+		limits[group] = limit
+	}
+	return store, limits, nil
+}
+
+// newMailSender creates sender of emails for the configured transport.
+func newMailSender(cfg config.SMTP, l logger.Logger) (smtp.Sender, error) {
+	switch cfg.Transport {
+	case "smtp":
+		return gomail.New(
+			context.Background(),
+			l,
+			cfg.Host,
+			cfg.Port,
+			cfg.User,
+			cfg.Password,
+			cfg.Sender,
+			gomail.Workers(cfg.Workers),
+			gomail.MaxRetries(cfg.MaxRetries),
+			gomail.RetryDelay(time.Duration(cfg.RetryDelaySec)*time.Second),
+			gomail.IdleTimeout(time.Duration(cfg.IdleTimeoutSec)*time.Second),
+		)
+	case "file":
+		return file.New(l, cfg.FileDir, cfg.Sender)
+	case "memory":
+		return mailmemory.New(), nil
+	default:
+		return nil, fmt.Errorf("unknown mail transport: %s", cfg.Transport)
+	}
 }
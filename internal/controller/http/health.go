@@ -0,0 +1,97 @@
+package http
+
+import (
+	"net/http"
+	"task-trail/internal/pkg/health"
+
+	"github.com/gin-gonic/gin"
+)
+
+type healthRoutes struct {
+	health *health.Service
+}
+
+type statusRes struct {
+	Status string `json:"status"`
+}
+
+type checkRes struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	// Error is the reason of the failed check
+	Error     string `json:"error,omitempty"`
+	LatencyMs int64  `json:"latencyMs"`
+}
+
+type readinessRes struct {
+	Status string     `json:"status"`
+	Checks []checkRes `json:"checks"`
+}
+
+type versionRes struct {
+	Commit           string `json:"commit"`
+	GoVersion        string `json:"goVersion"`
+	MigrationVersion int    `json:"migrationVersion"`
+	MigrationDirty   bool   `json:"migrationDirty"`
+}
+
+func newHealthRouter(app *gin.Engine, h *health.Service) {
+	r := &healthRoutes{health: h}
+	app.GET("/healthz", r.liveness)
+	app.GET("/readyz", r.readiness)
+	app.GET("/version", r.version)
+	app.GET("/", r.version)
+}
+
+// @Summary 	liveness probe
+// @Description Always ok while the process serves requests, dependencies are not checked
+// @Tags 		health
+// @Produce 	json
+// @Success 	200 {object} http.statusRes
+// @Router 		/healthz [get]
+func (r *healthRoutes) liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, statusRes{Status: string(health.StatusUp)})
+}
+
+// @Summary 	readiness probe
+// @Description Checks Postgres, S3 and SMTP, each of them with its own timeout.
+// @Description Returns the result of every check, failed checks are logged as well.
+// @Tags 		health
+// @Produce 	json
+// @Success 	200 {object} http.readinessRes
+// @Failure 	503 {object} http.readinessRes "some dependency is down"
+// @Router 		/readyz [get]
+func (r *healthRoutes) readiness(c *gin.Context) {
+	report := r.health.Check(c)
+	res := readinessRes{Status: string(report.Status), Checks: make([]checkRes, 0, len(report.Checks))}
+	for _, v := range report.Checks {
+		res.Checks = append(res.Checks, checkRes{
+			Name:      v.Name,
+			Status:    string(v.Status),
+			Error:     v.Error,
+			LatencyMs: v.Duration.Milliseconds(),
+		})
+	}
+	code := http.StatusOK
+	if report.Status != health.StatusUp {
+		code = http.StatusServiceUnavailable
+	}
+	c.JSON(code, res)
+}
+
+// @Summary 	build info
+// @Description Build commit and version of the applied database migrations
+// @Tags 		health
+// @Produce 	json
+// @Success 	200 {object} http.versionRes
+// @Router 		/version [get]
+func (r *healthRoutes) version(c *gin.Context) {
+	// build info is returned even if migration version cannot be read
+	info, _ := r.health.BuildInfo(c)
+	c.JSON(http.StatusOK, versionRes{
+		Commit:           info.Commit,
+		GoVersion:        info.GoVersion,
+		MigrationVersion: info.MigrationVersion,
+		MigrationDirty:   info.MigrationDirty,
+	})
+}
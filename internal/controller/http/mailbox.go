@@ -0,0 +1,53 @@
+package http
+
+import (
+	"net/http"
+	"task-trail/internal/pkg/smtp"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type mailboxRoutes struct {
+	mailbox smtp.Mailbox
+}
+
+type mailboxMessageRes struct {
+	EventID    string    `json:"eventId"`
+	RequestID  string    `json:"requestId,omitempty"`
+	Subject    string    `json:"subject"`
+	Recipients []string  `json:"recipients"`
+	Text       string    `json:"text"`
+	HTML       string    `json:"html,omitempty"`
+	SentAt     time.Time `json:"sentAt"`
+}
+
+func newMailboxRouter(app *gin.Engine, mailbox smtp.Mailbox) {
+	r := &mailboxRoutes{mailbox: mailbox}
+	g := app.Group("/debug/mail")
+	g.GET("", r.getMessages)
+	g.DELETE("", r.clear)
+}
+
+// getMessages returns emails kept by the memory mail transport, the latest one goes first.
+func (r *mailboxRoutes) getMessages(c *gin.Context) {
+	messages := r.mailbox.Messages()
+	res := make([]mailboxMessageRes, 0, len(messages))
+	for _, m := range messages {
+		res = append(res, mailboxMessageRes{
+			EventID:    m.EventID,
+			RequestID:  m.RequestID,
+			Subject:    m.Subject,
+			Recipients: m.Recipients,
+			Text:       m.Text,
+			HTML:       m.HTML,
+			SentAt:     m.SentAt,
+		})
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (r *mailboxRoutes) clear(c *gin.Context) {
+	r.mailbox.Clear()
+	c.Status(http.StatusOK)
+}
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"strings"
 	"task-trail/internal/customerrors"
 	"task-trail/internal/pkg/contextmanager"
 	"task-trail/internal/pkg/token"
@@ -9,6 +10,7 @@ import (
 )
 
 // authenticate request, with validation access token
+// from the Authorization header or the cookie
 func NewAuth(
 	t token.Service,
 	errHandler customerrors.ErrorHandler,
@@ -16,14 +18,25 @@ func NewAuth(
 	atName string,
 ) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if at, ok := bearerToken(c); ok {
+			userID, exp, err := t.VerifyAccessToken(at)
+			if err != nil {
+				_ = c.Error(errHandler.Unauthorized(err, "invalid access token"))
+				c.Abort()
+				return
+			}
+			m.SetUserID(c, userID)
+			m.SetAccessTokenExp(c, exp)
+			return
+		}
 
-		at, err := c.Cookie("at")
+		at, err := c.Cookie(atName)
 		if err != nil {
 			_ = c.Error(errHandler.Unauthorized(err, "access token not found"))
 			c.Abort()
 			return
 		}
-		userID, err := t.VerifyAccessToken(at)
+		userID, exp, err := t.VerifyAccessToken(at)
 		if err != nil {
 			_ = c.Error(errHandler.Unauthorized(err, "invalid access token"))
 			m.DeleteAccessToken(c, atName)
@@ -31,5 +44,16 @@ func NewAuth(
 			return
 		}
 		m.SetUserID(c, userID)
+		m.SetAccessTokenExp(c, exp)
+	}
+}
+
+// bearerToken returns access token of the Authorization header, false if the request has no bearer token
+func bearerToken(c *gin.Context) (string, bool) {
+	scheme, token, ok := strings.Cut(c.GetHeader("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
 	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
 }
@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	corsAllowedMethods = []string{
+		http.MethodGet,
+		http.MethodPost,
+		http.MethodPut,
+		http.MethodPatch,
+		http.MethodDelete,
+		http.MethodOptions,
+	}
+	corsAllowedHeaders = []string{"Authorization", "Content-Type", "X-Request-ID"}
+	// headers readable by the frontend in addition to the safelisted ones
+	corsExposedHeaders = []string{
+		"Content-Disposition",
+		"X-Request-ID",
+		"RateLimit-Policy",
+		"RateLimit-Limit",
+		"RateLimit-Remaining",
+		"RateLimit-Reset",
+		"Retry-After",
+	}
+)
+
+// allow cross-origin requests of the configured origins, "*" allows any origin without credentials.
+// Credentials are required by the cookie-based auth, so the allowed origin is echoed instead of the wildcard.
+// Preflight requests are answered right away, preflight of not allowed origin is rejected.
+// Headers are allowed in addition to the default ones, e.g. the configured csrf header.
+func NewCORS(origins []string, credentials bool, maxAge time.Duration, headers ...string) gin.HandlerFunc {
+	allowAny := false
+	allowed := make(map[string]bool, len(origins))
+	for _, o := range origins {
+		if o == "*" {
+			allowAny = true
+			continue
+		}
+		allowed[normalizeOrigin(o)] = true
+	}
+	methods := strings.Join(corsAllowedMethods, ", ")
+	allowedHeaders := strings.Join(append(slices.Clone(corsAllowedHeaders), headers...), ", ")
+	exposed := strings.Join(corsExposedHeaders, ", ")
+	age := strconv.Itoa(int(maxAge.Seconds()))
+
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if origin == "" {
+			c.Next()
+			return
+		}
+		h := c.Writer.Header()
+		h.Add("Vary", "Origin")
+		preflight := c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != ""
+		if !allowAny && !allowed[normalizeOrigin(origin)] {
+			if preflight {
+				c.AbortWithStatus(http.StatusForbidden)
+				return
+			}
+			c.Next()
+			return
+		}
+
+		if allowAny {
+			h.Set("Access-Control-Allow-Origin", "*")
+		} else {
+			h.Set("Access-Control-Allow-Origin", origin)
+			if credentials {
+				h.Set("Access-Control-Allow-Credentials", "true")
+			}
+		}
+		if !preflight {
+			h.Set("Access-Control-Expose-Headers", exposed)
+			c.Next()
+			return
+		}
+		h.Add("Vary", "Access-Control-Request-Method")
+		h.Add("Vary", "Access-Control-Request-Headers")
+		h.Set("Access-Control-Allow-Methods", methods)
+		h.Set("Access-Control-Allow-Headers", allowedHeaders)
+		h.Set("Access-Control-Max-Age", age)
+		c.AbortWithStatus(http.StatusNoContent)
+	}
+}
+
+// normalizeOrigin leaves only lowercased scheme and host of the origin, so configured urls may contain a path.
+func normalizeOrigin(o string) string {
+	u, err := url.Parse(strings.TrimSpace(o))
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return strings.ToLower(strings.TrimSuffix(o, "/"))
+	}
+	return strings.ToLower(u.Scheme + "://" + u.Host)
+}
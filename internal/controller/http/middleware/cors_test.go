@@ -0,0 +1,158 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"task-trail/internal/controller/http/middleware"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	testOrigin     = "https://app.test"
+	testExposed    = "Content-Disposition, X-Request-ID, RateLimit-Policy, RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset, Retry-After"
+	testAllowed    = "Authorization, Content-Type, X-Request-ID, X-CSRF-Token"
+	testMethods    = "GET, POST, PUT, PATCH, DELETE, OPTIONS"
+	testCORSMaxAge = time.Minute * 10
+)
+
+func TestCORS(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	preflight := map[string]string{"Origin": testOrigin, "Access-Control-Request-Method": http.MethodPost}
+	tests := []struct {
+		name        string
+		origins     []string
+		credentials bool
+		method      string
+		headers     map[string]string
+		status      int
+		// expected response headers, empty value means the header is not set
+		want map[string]string
+	}{
+		{
+			name:    "request without origin",
+			origins: []string{testOrigin},
+			method:  http.MethodGet,
+			status:  http.StatusOK,
+			want:    map[string]string{"Access-Control-Allow-Origin": "", "Vary": ""},
+		},
+		{
+			name:        "allowed origin with credentials",
+			origins:     []string{testOrigin},
+			credentials: true,
+			method:      http.MethodGet,
+			headers:     map[string]string{"Origin": testOrigin},
+			status:      http.StatusOK,
+			want: map[string]string{
+				"Access-Control-Allow-Origin":      testOrigin,
+				"Access-Control-Allow-Credentials": "true",
+				"Access-Control-Expose-Headers":    testExposed,
+				"Access-Control-Allow-Methods":     "",
+				"Vary":                             "Origin",
+			},
+		},
+		{
+			name:    "allowed origin without credentials",
+			origins: []string{testOrigin},
+			method:  http.MethodGet,
+			headers: map[string]string{"Origin": testOrigin},
+			status:  http.StatusOK,
+			want: map[string]string{
+				"Access-Control-Allow-Origin":      testOrigin,
+				"Access-Control-Allow-Credentials": "",
+			},
+		},
+		{
+			name:    "configured origin is normalized",
+			origins: []string{"HTTPS://App.test/login/"},
+			method:  http.MethodGet,
+			headers: map[string]string{"Origin": testOrigin},
+			status:  http.StatusOK,
+			want:    map[string]string{"Access-Control-Allow-Origin": testOrigin},
+		},
+		{
+			name:    "not allowed origin",
+			origins: []string{testOrigin},
+			method:  http.MethodGet,
+			headers: map[string]string{"Origin": "https://evil.test"},
+			status:  http.StatusOK,
+			want: map[string]string{
+				"Access-Control-Allow-Origin":   "",
+				"Access-Control-Expose-Headers": "",
+				"Vary":                          "Origin",
+			},
+		},
+		{
+			name:        "any origin",
+			origins:     []string{"*"},
+			credentials: true,
+			method:      http.MethodGet,
+			headers:     map[string]string{"Origin": "https://other.test"},
+			status:      http.StatusOK,
+			want: map[string]string{
+				"Access-Control-Allow-Origin":      "*",
+				"Access-Control-Allow-Credentials": "",
+			},
+		},
+		{
+			name:        "preflight",
+			origins:     []string{testOrigin},
+			credentials: true,
+			method:      http.MethodOptions,
+			headers:     preflight,
+			status:      http.StatusNoContent,
+			want: map[string]string{
+				"Access-Control-Allow-Origin":      testOrigin,
+				"Access-Control-Allow-Credentials": "true",
+				"Access-Control-Allow-Methods":     testMethods,
+				"Access-Control-Allow-Headers":     testAllowed,
+				"Access-Control-Max-Age":           "600",
+				"Access-Control-Expose-Headers":    "",
+			},
+		},
+		{
+			name:    "preflight of not allowed origin is rejected",
+			origins: []string{testOrigin},
+			method:  http.MethodOptions,
+			headers: map[string]string{"Origin": "https://evil.test", "Access-Control-Request-Method": http.MethodPost},
+			status:  http.StatusForbidden,
+			want:    map[string]string{"Access-Control-Allow-Origin": "", "Access-Control-Allow-Methods": ""},
+		},
+		{
+			name:    "options without request method is not a preflight",
+			origins: []string{testOrigin},
+			method:  http.MethodOptions,
+			headers: map[string]string{"Origin": testOrigin},
+			status:  http.StatusOK,
+			want: map[string]string{
+				"Access-Control-Allow-Origin":   testOrigin,
+				"Access-Control-Allow-Methods":  "",
+				"Access-Control-Expose-Headers": testExposed,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.Use(middleware.NewCORS(tt.origins, tt.credentials, testCORSMaxAge, testCSRFHeader))
+			r.Handle(tt.method, "/", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			req := httptest.NewRequest(tt.method, "/", nil)
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			if w.Code != tt.status {
+				t.Errorf("status = %d, want %d", w.Code, tt.status)
+			}
+			for name, value := range tt.want {
+				if got := w.Header().Get(name); got != value {
+					t.Errorf("%s = %q, want %q", name, got, value)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"task-trail/internal/customerrors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// check double-submit csrf token of state-changing requests authenticated by cookies,
+// the header must match the csrf cookie issued with the tokens pair.
+// Requests with bearer token are exempt, browsers don't attach it to cross-site requests.
+// Refresh without csrf cookie is let through, so sessions started before csrf protection get the cookie,
+// the refresh token cookie is limited by path and is sent only with refresh requests.
+func NewCSRF(
+	errHandler customerrors.ErrorHandler,
+	csrfName string,
+	header string,
+	atName string,
+	rtName string,
+) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			return
+		}
+		if _, ok := bearerToken(c); ok {
+			return
+		}
+		if !hasCookie(c, atName) && !hasCookie(c, rtName) {
+			return
+		}
+
+		cookie, _ := c.Cookie(csrfName)
+		if cookie == "" && hasCookie(c, rtName) {
+			return
+		}
+		token := c.GetHeader(header)
+		if cookie == "" || subtle.ConstantTimeCompare([]byte(cookie), []byte(token)) != 1 {
+			_ = c.Error(errHandler.Forbidden(fmt.Errorf("csrf token mismatch"), "invalid csrf token", "header", token != "", "cookie", cookie != ""))
+			c.Abort()
+			return
+		}
+	}
+}
+
+func hasCookie(c *gin.Context, name string) bool {
+	v, err := c.Cookie(name)
+	return err == nil && v != ""
+}
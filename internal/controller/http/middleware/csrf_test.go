@@ -0,0 +1,127 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"task-trail/internal/controller/http/middleware"
+	"task-trail/internal/customerrors"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	testCSRFName   = "csrf"
+	testCSRFHeader = "X-CSRF-Token"
+	testATName     = "at"
+	testRTName     = "rt"
+	testCSRFToken  = "csrf-token"
+)
+
+func TestCSRF(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name    string
+		method  string
+		cookies map[string]string
+		headers map[string]string
+		status  int
+	}{
+		{
+			name:    "safe method is not checked",
+			method:  http.MethodGet,
+			cookies: map[string]string{testATName: "at"},
+			status:  http.StatusOK,
+		},
+		{
+			name:    "head is not checked",
+			method:  http.MethodHead,
+			cookies: map[string]string{testATName: "at"},
+			status:  http.StatusOK,
+		},
+		{
+			name:   "anonymous request is not checked",
+			method: http.MethodPost,
+			status: http.StatusOK,
+		},
+		{
+			name:    "bearer token request is exempt",
+			method:  http.MethodPost,
+			cookies: map[string]string{testATName: "at", testCSRFName: testCSRFToken},
+			headers: map[string]string{"Authorization": "Bearer at"},
+			status:  http.StatusOK,
+		},
+		{
+			name:    "matching token",
+			method:  http.MethodDelete,
+			cookies: map[string]string{testATName: "at", testCSRFName: testCSRFToken},
+			headers: map[string]string{testCSRFHeader: testCSRFToken},
+			status:  http.StatusOK,
+		},
+		{
+			name:    "mismatching token",
+			method:  http.MethodPost,
+			cookies: map[string]string{testATName: "at", testCSRFName: testCSRFToken},
+			headers: map[string]string{testCSRFHeader: "other"},
+			status:  http.StatusForbidden,
+		},
+		{
+			name:    "missing header",
+			method:  http.MethodPatch,
+			cookies: map[string]string{testATName: "at", testCSRFName: testCSRFToken},
+			status:  http.StatusForbidden,
+		},
+		{
+			name:    "empty bearer token is not exempt",
+			method:  http.MethodPost,
+			cookies: map[string]string{testATName: "at", testCSRFName: testCSRFToken},
+			headers: map[string]string{"Authorization": "Bearer "},
+			status:  http.StatusForbidden,
+		},
+		{
+			name:    "missing cookie",
+			method:  http.MethodPost,
+			cookies: map[string]string{testATName: "at"},
+			headers: map[string]string{testCSRFHeader: testCSRFToken},
+			status:  http.StatusForbidden,
+		},
+		{
+			name:    "refresh without cookie is let through",
+			method:  http.MethodPost,
+			cookies: map[string]string{testRTName: "rt"},
+			status:  http.StatusOK,
+		},
+		{
+			name:    "refresh with cookie is checked",
+			method:  http.MethodPost,
+			cookies: map[string]string{testRTName: "rt", testCSRFName: testCSRFToken},
+			status:  http.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.Use(func(c *gin.Context) {
+				c.Next()
+				if len(c.Errors) > 0 {
+					c.AbortWithStatus(http.StatusForbidden)
+				}
+			})
+			r.Use(middleware.NewCSRF(customerrors.NewErrHander(), testCSRFName, testCSRFHeader, testATName, testRTName))
+			r.Handle(tt.method, "/", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			req := httptest.NewRequest(tt.method, "/", nil)
+			for name, value := range tt.cookies {
+				req.AddCookie(&http.Cookie{Name: name, Value: value})
+			}
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			if w.Code != tt.status {
+				t.Errorf("status = %d, want %d", w.Code, tt.status)
+			}
+		})
+	}
+}
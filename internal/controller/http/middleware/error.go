@@ -1,27 +1,34 @@
 package middleware
 
 import (
+	"context"
+	"net/http"
 	"task-trail/internal/controller/http/v1/response"
 	"task-trail/internal/customerrors"
 	"task-trail/internal/pkg/contextmanager"
 	"task-trail/internal/pkg/logger"
+	"task-trail/internal/pkg/tracing"
 
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // log err and return prepared response
 func NewError(l logger.Logger, m contextmanager.Gin) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Next()
+		span := trace.SpanFromContext(c.Request.Context())
 		for _, err := range c.Errors {
+			span.RecordError(err.Err)
 			switch e := err.Err.(type) {
 			case *customerrors.Err:
-				logError(e, l, m.GetRequestID(c))
+				logError(c.Request.Context(), e, l)
 				a := response.NewFromErrBase(e)
+				withIDs(c, m, a)
 				c.AbortWithStatusJSON(a.Status, a)
 			default:
-				l.Error("unexpected error", "error", err)
-				c.AbortWithStatusJSON(500, "unexpected error")
+				l.ErrorContext(c.Request.Context(), "unexpected error", "error", err)
+				abortInternal(c, m)
 			}
 		}
 
@@ -30,20 +37,36 @@ func NewError(l logger.Logger, m contextmanager.Gin) gin.HandlerFunc {
 	}
 }
 
-func logError(e *customerrors.Err, l logger.Logger, reqID any) {
-	args := append(e.Data, "source", e.Source, "requestID", reqID, "error", e.Unwrap())
+func logError(ctx context.Context, e *customerrors.Err, l logger.Logger) {
+	args := append(e.Data, "source", e.Source, "error", e.Unwrap())
 	switch e.Type {
 	case customerrors.InvalidCredentialsErr:
-		l.Warn(e.Msg, args...)
+		l.WarnContext(ctx, e.Msg, args...)
 	case customerrors.UnauthorizedErr:
-		l.Warn(e.Msg, args...)
+		l.WarnContext(ctx, e.Msg, args...)
+	case customerrors.ForbiddenErr:
+		l.WarnContext(ctx, e.Msg, args...)
+	case customerrors.TooManyRequestsErr:
+		l.WarnContext(ctx, e.Msg, args...)
 	case customerrors.ValidationErr:
-		l.Warn(e.Msg, args...)
+		l.WarnContext(ctx, e.Msg, args...)
 	case customerrors.ConflictErr:
-		l.Warn(e.Msg, args...)
+		l.WarnContext(ctx, e.Msg, args...)
 	case customerrors.InternalErr:
-		l.Error(e.Msg, args...)
+		l.ErrorContext(ctx, e.Msg, args...)
 	default:
-		l.Error(e.Msg, args...)
+		l.ErrorContext(ctx, e.Msg, args...)
 	}
 }
+
+// abortInternal responds with internal error, ids let the client report the failed request.
+func abortInternal(c *gin.Context, m contextmanager.Gin) {
+	a := response.New(http.StatusInternalServerError, "internal error", nil)
+	withIDs(c, m, a)
+	c.AbortWithStatusJSON(a.Status, a)
+}
+
+func withIDs(c *gin.Context, m contextmanager.Gin, a *response.ErrAPI) {
+	a.RequestID = m.GetRequestID(c)
+	a.TraceID = tracing.TraceID(c.Request.Context())
+}
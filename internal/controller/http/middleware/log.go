@@ -1,30 +1,22 @@
 package middleware
 
 import (
-	"task-trail/internal/pkg/contextmanager"
 	"task-trail/internal/pkg/logger"
 	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
-// log each http event
-func NewLog(l logger.Logger, m contextmanager.Gin) gin.HandlerFunc {
+// log each http event, request id, user id and trace id are added by the logger from the request context
+func NewLog(l logger.Logger) gin.HandlerFunc {
 
 	return func(c *gin.Context) {
 		start := time.Now()
 		c.Next()
-		var userID *int = nil
-		v, err := m.GetUserID(c)
-		if err == nil {
-			userID = &v
-		}
 		latency := time.Since(start)
 		status := c.Writer.Status()
 		args := []any{
 			"status", status,
-			"userID", userID,
-			"reqID", m.GetRequestID(c),
 			"client_ip", c.ClientIP(),
 			"method", c.Request.Method,
 			"path", c.Request.URL.Path,
@@ -32,6 +24,6 @@ func NewLog(l logger.Logger, m contextmanager.Gin) gin.HandlerFunc {
 			"latency", latency.String(),
 		}
 
-		l.Info("http request", args...)
+		l.InfoContext(c.Request.Context(), "http request", args...)
 	}
 }
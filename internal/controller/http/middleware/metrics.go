@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"task-trail/internal/pkg/metrics"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// count each http request by route template, so path parameters do not blow up label cardinality
+func NewMetrics(m *metrics.Metrics) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		m.ObserveRequest(c.Request.Method, route, c.Writer.Status(), time.Since(start))
+	}
+}
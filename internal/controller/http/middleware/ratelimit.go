@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"task-trail/internal/customerrors"
+	"task-trail/internal/pkg/contextmanager"
+	"task-trail/internal/pkg/logger"
+	"task-trail/internal/pkg/ratelimit"
+	"task-trail/internal/pkg/token"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// limit requests of the route group with token bucket, returns middleware of the group.
+// Requests of authenticated users are counted per user, the user is taken from the access token
+// when the middleware goes before auth, anonymous requests are counted per client IP.
+// Groups without limit are not limited, the request is let through if the store fails.
+func NewRateLimit(
+	store ratelimit.Store,
+	limits map[string]ratelimit.Limit,
+	t token.Service,
+	atName string,
+	errHandler customerrors.ErrorHandler,
+	m contextmanager.Gin,
+	l logger.Logger,
+) func(group string) gin.HandlerFunc {
+	return func(group string) gin.HandlerFunc {
+		limit, ok := limits[group]
+		if !ok {
+			return func(c *gin.Context) {}
+		}
+		policy := fmt.Sprintf("%d;w=%d", limit.Requests, int(limit.Period.Seconds()))
+
+		return func(c *gin.Context) {
+			key := group + ":ip:" + c.ClientIP()
+			if userID, ok := requestUserID(c, m, t, atName); ok {
+				key = group + ":user:" + strconv.Itoa(userID)
+			}
+			res, err := store.Take(c, key, limit)
+			if err != nil {
+				l.WarnContext(c, "rate limit store failed", "error", err, "group", group)
+				return
+			}
+			h := c.Writer.Header()
+			h.Set("RateLimit-Policy", policy)
+			h.Set("RateLimit-Limit", strconv.Itoa(res.Limit))
+			h.Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+			h.Set("RateLimit-Reset", ceilSeconds(res.Reset))
+			if !res.Allowed {
+				h.Set("Retry-After", ceilSeconds(res.RetryAfter))
+				_ = c.Error(errHandler.TooManyRequests(fmt.Errorf("rate limit of %s exceeded", group), "too many requests", "group", group, "key", key))
+				c.Abort()
+			}
+		}
+	}
+}
+
+// requestUserID returns user authenticated by auth middleware or by the valid access token of the request.
+func requestUserID(c *gin.Context, m contextmanager.Gin, t token.Service, atName string) (int, bool) {
+	if userID, err := m.GetUserID(c); err == nil {
+		return userID, true
+	}
+	at, ok := bearerToken(c)
+	if !ok {
+		var err error
+		if at, err = c.Cookie(atName); err != nil || at == "" {
+			return 0, false
+		}
+	}
+	userID, _, err := t.VerifyAccessToken(at)
+	return userID, err == nil
+}
+
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,206 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"task-trail/internal/controller/http/middleware"
+	"task-trail/internal/customerrors"
+	"task-trail/internal/pkg/contextmanager"
+	"task-trail/internal/pkg/ratelimit"
+	"task-trail/internal/pkg/ratelimit/memory"
+	"task-trail/test/mocks"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	testGroup     = "auth"
+	testUserToken = "user-token"
+)
+
+type rateLimitReq struct {
+	ip string
+	// bearer is the access token of the Authorization header
+	bearer string
+	// cookie is the access token of the cookie
+	cookie string
+	// authUserID is set by auth middleware going before the limit
+	authUserID int
+	status     int
+}
+
+// failingStore fails every request, e.g. the database is unavailable
+type failingStore struct{}
+
+func (failingStore) Take(context.Context, string, ratelimit.Limit) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("store unavailable")
+}
+
+func (failingStore) Cleanup(context.Context, time.Duration) (int64, error) {
+	return 0, nil
+}
+
+func newRateLimitRouter(t *testing.T, store ratelimit.Store, l *mocks.MockLogger, group string) *gin.Engine {
+	ctrl := gomock.NewController(t)
+	tokens := mocks.NewMockTokenService(ctrl)
+	tokens.EXPECT().VerifyAccessToken(testUserToken).Return(1, time.Now().Add(time.Hour), nil).AnyTimes()
+	tokens.EXPECT().VerifyAccessToken(gomock.Not(testUserToken)).Return(0, time.Time{}, errors.New("invalid token")).AnyTimes()
+	if l == nil {
+		l = mocks.NewMockLogger(ctrl)
+	}
+	cm := contextmanager.NewGin(nil, testCSRFName)
+	limits := map[string]ratelimit.Limit{testGroup: {Requests: 1, Period: time.Hour}}
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Next()
+		if len(c.Errors) > 0 {
+			c.AbortWithStatus(http.StatusTooManyRequests)
+		}
+	})
+	r.Use(func(c *gin.Context) {
+		if userID, err := strconv.Atoi(c.Query("authUserID")); err == nil {
+			cm.SetUserID(c, userID)
+		}
+	})
+	r.Use(middleware.NewRateLimit(store, limits, tokens, testATName, customerrors.NewErrHander(), cm, l)(group))
+	r.GET("/", func(c *gin.Context) { c.Status(http.StatusOK) })
+	return r
+}
+
+func serveRateLimit(r *gin.Engine, req rateLimitReq) *httptest.ResponseRecorder {
+	target := "/"
+	if req.authUserID != 0 {
+		target += "?authUserID=" + strconv.Itoa(req.authUserID)
+	}
+	httpReq := httptest.NewRequest(http.MethodGet, target, nil)
+	httpReq.RemoteAddr = req.ip + ":1234"
+	if req.bearer != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+req.bearer)
+	}
+	if req.cookie != "" {
+		httpReq.AddCookie(&http.Cookie{Name: testATName, Value: req.cookie})
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httpReq)
+	return w
+}
+
+func TestRateLimitKeys(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name     string
+		requests []rateLimitReq
+	}{
+		{
+			name: "anonymous requests are counted per IP",
+			requests: []rateLimitReq{
+				{ip: "192.0.2.1", status: http.StatusOK},
+				{ip: "192.0.2.1", status: http.StatusTooManyRequests},
+				{ip: "192.0.2.2", status: http.StatusOK},
+			},
+		},
+		{
+			name: "user requests are counted per user",
+			requests: []rateLimitReq{
+				{ip: "192.0.2.1", bearer: testUserToken, status: http.StatusOK},
+				{ip: "192.0.2.2", bearer: testUserToken, status: http.StatusTooManyRequests},
+				{ip: "192.0.2.1", status: http.StatusOK},
+			},
+		},
+		{
+			name: "user is taken from the access token cookie",
+			requests: []rateLimitReq{
+				{ip: "192.0.2.1", cookie: testUserToken, status: http.StatusOK},
+				{ip: "192.0.2.2", bearer: testUserToken, status: http.StatusTooManyRequests},
+			},
+		},
+		{
+			name: "request with invalid token is counted per IP",
+			requests: []rateLimitReq{
+				{ip: "192.0.2.1", bearer: "invalid", status: http.StatusOK},
+				{ip: "192.0.2.1", status: http.StatusTooManyRequests},
+				{ip: "192.0.2.2", bearer: testUserToken, status: http.StatusOK},
+			},
+		},
+		{
+			name: "user authenticated before the limit",
+			requests: []rateLimitReq{
+				{ip: "192.0.2.1", authUserID: 2, status: http.StatusOK},
+				{ip: "192.0.2.2", authUserID: 2, status: http.StatusTooManyRequests},
+				{ip: "192.0.2.1", bearer: testUserToken, status: http.StatusOK},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newRateLimitRouter(t, memory.New(), nil, testGroup)
+			for i, req := range tt.requests {
+				if w := serveRateLimit(r, req); w.Code != req.status {
+					t.Errorf("request %d: status = %d, want %d", i+1, w.Code, req.status)
+				}
+			}
+		})
+	}
+}
+
+func TestRateLimitHeaders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := newRateLimitRouter(t, memory.New(), nil, testGroup)
+	req := rateLimitReq{ip: "192.0.2.1"}
+
+	w := serveRateLimit(r, req)
+	want := map[string]string{
+		"RateLimit-Policy":    "1;w=3600",
+		"RateLimit-Limit":     "1",
+		"RateLimit-Remaining": "0",
+		"RateLimit-Reset":     "3600",
+		"Retry-After":         "",
+	}
+	for name, value := range want {
+		if got := w.Header().Get(name); got != value {
+			t.Errorf("allowed request: %s = %q, want %q", name, got, value)
+		}
+	}
+
+	w = serveRateLimit(r, req)
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusTooManyRequests)
+	}
+	want["Retry-After"] = "3600"
+	for name, value := range want {
+		if got := w.Header().Get(name); got != value {
+			t.Errorf("limited request: %s = %q, want %q", name, got, value)
+		}
+	}
+}
+
+func TestRateLimitNotLimited(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Run("group without limit", func(t *testing.T) {
+		r := newRateLimitRouter(t, memory.New(), nil, "other")
+		for range 3 {
+			w := serveRateLimit(r, rateLimitReq{ip: "192.0.2.1"})
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+			}
+			if got := w.Header().Get("RateLimit-Limit"); got != "" {
+				t.Errorf("RateLimit-Limit = %q, want no header", got)
+			}
+		}
+	})
+	t.Run("store failure lets the request through", func(t *testing.T) {
+		l := mocks.NewMockLogger(gomock.NewController(t))
+		l.EXPECT().WarnContext(gomock.Any(), "rate limit store failed", "error", gomock.Any(), "group", testGroup)
+		r := newRateLimitRouter(t, failingStore{}, l, testGroup)
+		if w := serveRateLimit(r, rateLimitReq{ip: "192.0.2.1"}); w.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+	})
+}
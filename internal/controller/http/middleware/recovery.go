@@ -23,22 +23,16 @@ func NewRecovery(l logger.Logger, m contextmanager.Gin) gin.HandlerFunc {
 					"function": fn.Name(),
 					"line":     line,
 				}
-				var userID *int = nil
-				v, err := m.GetUserID(c)
-				if err == nil {
-					userID = &v
-				}
-				l.Error(
+				l.ErrorContext(
+					c.Request.Context(),
 					"panic recovered",
 					"error", r,
 					"path", c.Request.URL.Path,
 					"method", c.Request.Method,
 					"client_ip", c.ClientIP(),
 					"source", path,
-					"userID", userID,
-					"reqID", m.GetRequestID(c),
 				)
-				c.AbortWithStatus(500)
+				abortInternal(c, m)
 			}
 		}()
 		c.Next()
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// add standard security headers to every response, empty values and zero HSTS max age disable the header.
+// Browsers ignore HSTS received over plain http, so it is safe to send it behind a TLS terminating proxy.
+func NewSecurityHeaders(hstsMaxAge time.Duration, hstsSubdomains bool, frameOptions string, referrerPolicy string) gin.HandlerFunc {
+	hsts := ""
+	if hstsMaxAge > 0 {
+		hsts = "max-age=" + strconv.Itoa(int(hstsMaxAge.Seconds()))
+		if hstsSubdomains {
+			hsts += "; includeSubDomains"
+		}
+	}
+	return func(c *gin.Context) {
+		h := c.Writer.Header()
+		h.Set("X-Content-Type-Options", "nosniff")
+		if hsts != "" {
+			h.Set("Strict-Transport-Security", hsts)
+		}
+		if frameOptions != "" {
+			h.Set("X-Frame-Options", frameOptions)
+		}
+		if referrerPolicy != "" {
+			h.Set("Referrer-Policy", referrerPolicy)
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,71 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"task-trail/internal/controller/http/middleware"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestSecurityHeaders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name           string
+		hstsMaxAge     time.Duration
+		hstsSubdomains bool
+		frameOptions   string
+		referrerPolicy string
+		// expected response headers, empty value means the header is not set
+		want map[string]string
+	}{
+		{
+			name:           "all headers",
+			hstsMaxAge:     time.Hour * 24 * 365,
+			hstsSubdomains: true,
+			frameOptions:   "DENY",
+			referrerPolicy: "strict-origin-when-cross-origin",
+			want: map[string]string{
+				"X-Content-Type-Options":    "nosniff",
+				"Strict-Transport-Security": "max-age=31536000; includeSubDomains",
+				"X-Frame-Options":           "DENY",
+				"Referrer-Policy":           "strict-origin-when-cross-origin",
+			},
+		},
+		{
+			name:       "hsts without subdomains",
+			hstsMaxAge: time.Hour,
+			want:       map[string]string{"Strict-Transport-Security": "max-age=3600"},
+		},
+		{
+			name:           "disabled headers",
+			hstsSubdomains: true,
+			want: map[string]string{
+				"X-Content-Type-Options":    "nosniff",
+				"Strict-Transport-Security": "",
+				"X-Frame-Options":           "",
+				"Referrer-Policy":           "",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.Use(middleware.NewSecurityHeaders(tt.hstsMaxAge, tt.hstsSubdomains, tt.frameOptions, tt.referrerPolicy))
+			r.GET("/", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+			if w.Code != http.StatusOK {
+				t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+			}
+			for name, value := range tt.want {
+				if got := w.Header().Get(name); got != value {
+					t.Errorf("%s = %q, want %q", name, got, value)
+				}
+			}
+		})
+	}
+}
@@ -1,12 +1,14 @@
 package http
 
 import (
-	"net/http"
 	"task-trail/config"
 	"task-trail/internal/customerrors"
 
 	v1 "task-trail/internal/controller/http/v1"
 	"task-trail/internal/pkg/contextmanager"
+	"task-trail/internal/pkg/health"
+	"task-trail/internal/pkg/metrics"
+	"task-trail/internal/pkg/smtp"
 	"task-trail/internal/pkg/storage"
 	"task-trail/internal/usecase"
 
@@ -38,8 +40,16 @@ func NewRouter(
 	userUC usecase.User,
 	projectUC usecase.Project,
 	authUC usecase.Authentication,
+	notificationUC usecase.Notification,
+	realtimeUC usecase.Realtime,
+	webhookUC usecase.Webhook,
+	activityUC usecase.Activity,
 	storage storage.Service,
+	mailbox smtp.Mailbox,
+	healthService *health.Service,
+	appMetrics *metrics.Metrics,
 	authMW gin.HandlerFunc,
+	rateLimitMW func(group string) gin.HandlerFunc,
 	cfg *config.Config,
 ) {
 	v1.NewRouter(
@@ -48,16 +58,29 @@ func NewRouter(
 		userUC,
 		projectUC,
 		authUC,
+		notificationUC,
+		realtimeUC,
+		webhookUC,
+		activityUC,
 		contextmanager,
 		errHandler,
 		storage,
 		authMW,
+		rateLimitMW,
 	)
 
-	app.GET("/", func(c *gin.Context) {
-		// TODO: add api info
-		c.JSON(http.StatusOK, gin.H{"message": "kek", "status": http.StatusOK})
-	})
+	newHealthRouter(app, healthService)
+	// emails kept by the memory transport are readable only in debug mode
+	if cfg.App.Debug && mailbox != nil {
+		newMailboxRouter(app, mailbox)
+	}
+	if cfg.Metrics.Enabled {
+		handlers := []gin.HandlerFunc{gin.WrapH(appMetrics.Handler())}
+		if cfg.Metrics.Login != "" {
+			handlers = append([]gin.HandlerFunc{gin.BasicAuth(gin.Accounts{cfg.Metrics.Login: cfg.Metrics.Password})}, handlers...)
+		}
+		app.GET("/metrics", handlers...)
+	}
 	if cfg.Docs.Enabled {
 		authMiddleware := gin.BasicAuth(gin.Accounts{
 			cfg.Docs.Login: cfg.Docs.Password, // логин и пароль
@@ -0,0 +1,65 @@
+package v1
+
+import (
+	"net/http"
+	"strconv"
+	"task-trail/internal/controller/http/v1/request"
+	"task-trail/internal/controller/http/v1/response"
+	"task-trail/internal/customerrors"
+	"task-trail/internal/pkg/contextmanager"
+	"task-trail/internal/usecase"
+	"task-trail/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type activityRoutes struct {
+	contextmanager contextmanager.Gin
+	errHandler     customerrors.ErrorHandler
+	u              usecase.Activity
+}
+
+// @Summary 	get project activity
+// @Description Audit log of the project from newest to oldest, available for project members
+// @Security BearerAuth
+// @Tags 		/v1/project
+// @Accept 		json
+// @Produce 	json
+// @Param 		id path int true "project id"
+// @Param 		limit query int false "page size, 20 by default, max 100"
+// @Param 		offset query int false "number of skipped entries"
+// @Param 		actorId query int false "return only changes made by the user"
+// @Param 		entityType query string false "return only changes of the entity type" Enums(project, member, task)
+// @Param 		entityId query int false "return only changes of the entity"
+// @Success 	200 {object} response.activityListRes
+// @Failure		400 {object} response.ErrAPI "invalid query parameters"
+// @Failure		401 {object} response.ErrAPI "authentication required"
+// @Failure		404 {object} response.ErrAPI "project not found"
+// @Router 		/v1/projects/{id}/activity [get]
+func (r *activityRoutes) getList(c *gin.Context) {
+	userID := utils.Must(r.contextmanager.GetUserID(c))
+	projectID := utils.Must(strconv.Atoi(c.Param("id")))
+	data, err := request.BindActivityListDTO(c, userID, projectID)
+	if err != nil {
+		_ = c.Error(r.errHandler.Validation(err))
+		return
+	}
+	res, err := r.u.GetList(c, data)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, response.NewActivityListResFromDTO(res))
+}
+
+func NewActivityRouter(
+	router *gin.RouterGroup,
+	u usecase.Activity,
+	authMW gin.HandlerFunc,
+	errHandler customerrors.ErrorHandler,
+	contextmanager contextmanager.Gin,
+) {
+	r := &activityRoutes{u: u, contextmanager: contextmanager, errHandler: errHandler}
+	g := router.Group("/projects")
+	g.GET(":id/activity", authMW, r.getList)
+}
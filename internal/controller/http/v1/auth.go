@@ -54,6 +54,7 @@ func new(
 // @Failure		400 {object} response.ErrAPI "invalid request body"
 // @Failure		409 {object} response.ErrAPI "user already exists"
 // @Failure		500 {object} response.ErrAPI "internal error"
+// @Failure		429 {object} response.ErrAPI "too many requests"
 // @Router 		/v1/auth/register [post]
 func (r *authRoutes) register(c *gin.Context) {
 	data, err := request.BindCredentialsDTO(c)
@@ -77,6 +78,7 @@ func (r *authRoutes) register(c *gin.Context) {
 // @Failure		400 {object} response.ErrAPI "invalid request body"
 // @Failure		401 {object} response.ErrAPI "invalid credentials"
 // @Failure		500 {object} response.ErrAPI "internal error"
+// @Failure		429 {object} response.ErrAPI "too many requests"
 // @Router 		/v1/auth/login [post]
 func (r *authRoutes) login(c *gin.Context) {
 	data, err := request.BindCredentialsDTO(c)
@@ -142,6 +144,7 @@ func (r *authRoutes) logout(c *gin.Context) {
 // @Success 	200
 // @Failure		400 {object} response.ErrAPI "token is invalid"
 // @Failure		404 {object} response.ErrAPI "token or user not found"
+// @Failure		429 {object} response.ErrAPI "too many requests"
 // @Router 		/v1/auth/verify [post]
 func (r *authRoutes) verify(c *gin.Context) {
 	token, err := request.BindVerifyToken(c)
@@ -163,6 +166,7 @@ func (r *authRoutes) verify(c *gin.Context) {
 // @Param 		body body request.emailReq true "user email"
 // @Success 	200
 // @Failure		400 {object} response.ErrAPI "invalid request body"
+// @Failure		429 {object} response.ErrAPI "too many requests"
 // @Router 		/v1/auth/resend-verification [post]
 func (r *authRoutes) resend(c *gin.Context) {
 	email, err := request.BindEmail(c)
@@ -184,6 +188,7 @@ func (r *authRoutes) resend(c *gin.Context) {
 // @Param 		body body request.emailReq true "user email"
 // @Success 	200
 // @Failure		400 {object} response.ErrAPI "invalid request body"
+// @Failure		429 {object} response.ErrAPI "too many requests"
 // @Router 		/v1/auth/password/forgot [post]
 func (r *authRoutes) forgotPWD(c *gin.Context) {
 	email, err := request.BindEmail(c)
@@ -205,6 +210,7 @@ func (r *authRoutes) forgotPWD(c *gin.Context) {
 // @Param 		body body request.resetPasswordReq true "token and new password"
 // @Success 	200
 // @Failure		400 {object} response.ErrAPI "invalid request body"
+// @Failure		429 {object} response.ErrAPI "too many requests"
 // @Router 		/v1/auth/password/reset [post]
 func (r *authRoutes) resetPWD(c *gin.Context) {
 	data, err := request.BindResetPasswordDTO(c)
@@ -219,6 +225,30 @@ func (r *authRoutes) resetPWD(c *gin.Context) {
 	c.JSON(http.StatusOK, nil)
 }
 
+// @Summary 	activate auto-registered user
+// @Description set password and username of the user invited to a project, token comes from the invitation email
+// @Tags 		/v1/auth
+// @Accept 		json
+// @Produce 	json
+// @Param 		body body request.activationReq true "token, password and username"
+// @Success 	200
+// @Failure		400 {object} response.ErrAPI "invalid request body"
+// @Failure		500 {object} response.ErrAPI "internal error"
+// @Failure		429 {object} response.ErrAPI "too many requests"
+// @Router 		/v1/auth/activate [post]
+func (r *authRoutes) activate(c *gin.Context) {
+	data, err := request.BindActivationDTO(c)
+	if err != nil {
+		_ = c.Error(r.errHandler.Validation(err))
+		return
+	}
+	if err := r.u.Activate(c, data); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, nil)
+}
+
 // @Summary 	change user password
 // @Tags 		/v1/auth
 // @Accept 		json
@@ -226,6 +256,7 @@ func (r *authRoutes) resetPWD(c *gin.Context) {
 // @Param 		body body request.changePasswordReq true "old and new password"
 // @Success 	200
 // @Failure		400 {object} response.ErrAPI "invalid request body"
+// @Failure		429 {object} response.ErrAPI "too many requests"
 // @Router 		/v1/auth/password/change [post]
 func (r *authRoutes) changePWD(c *gin.Context) {
 	userID := utils.Must(r.contextmanager.GetUserID(c))
@@ -241,7 +272,82 @@ func (r *authRoutes) changePWD(c *gin.Context) {
 	c.JSON(http.StatusOK, nil)
 }
 
+// @Summary 	request user email change
+// @Description sends confirmation link to the new email and notice with cancel link to the current one
+// @Security BearerAuth
+// @Tags 		/v1/auth
+// @Accept 		json
+// @Produce 	json
+// @Param 		body body request.emailChangeReq true "new email and current password"
+// @Success 	200
+// @Failure		400 {object} response.ErrAPI "invalid request body"
+// @Failure		401 {object} response.ErrAPI "authentication required"
+// @Failure		409 {object} response.ErrAPI "email already taken"
+// @Failure		429 {object} response.ErrAPI "too many requests"
+// @Router 		/v1/auth/email/change [post]
+func (r *authRoutes) changeEmail(c *gin.Context) {
+	userID := utils.Must(r.contextmanager.GetUserID(c))
+	data, err := request.BindEmailChangeDTO(c, userID)
+	if err != nil {
+		_ = c.Error(r.errHandler.Validation(err))
+		return
+	}
+	if err := r.u.RequestEmailChange(c, data); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, nil)
+}
+
+// @Summary 	confirm user email change
+// @Description applies the new email and logs out the user from all sessions
+// @Tags 		/v1/auth
+// @Accept 		json
+// @Produce 	json
+// @Param 		body body request.verifyReq true "token"
+// @Success 	200
+// @Failure		400 {object} response.ErrAPI "token is invalid"
+// @Failure		409 {object} response.ErrAPI "email already taken"
+// @Failure		429 {object} response.ErrAPI "too many requests"
+// @Router 		/v1/auth/email/confirm [post]
+func (r *authRoutes) confirmEmail(c *gin.Context) {
+	token, err := request.BindVerifyToken(c)
+	if err != nil {
+		_ = c.Error(r.errHandler.Validation(err))
+		return
+	}
+	if err := r.u.ConfirmEmailChange(c, token); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	r.contextmanager.DeleteTokens(c, r.atName, r.rtName, r.rtPath)
+	c.JSON(http.StatusOK, nil)
+}
+
+// @Summary 	cancel user email change
+// @Tags 		/v1/auth
+// @Accept 		json
+// @Produce 	json
+// @Param 		body body request.verifyReq true "token"
+// @Success 	200
+// @Failure		400 {object} response.ErrAPI "token is invalid"
+// @Failure		429 {object} response.ErrAPI "too many requests"
+// @Router 		/v1/auth/email/cancel [post]
+func (r *authRoutes) cancelEmail(c *gin.Context) {
+	token, err := request.BindVerifyToken(c)
+	if err != nil {
+		_ = c.Error(r.errHandler.Validation(err))
+		return
+	}
+	if err := r.u.CancelEmailChange(c, token); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, nil)
+}
+
 // @Summary 	check user authentication
+// @Description issues csrf cookie to the session without it
 // @Security BearerAuth
 // @Tags 		/v1/auth
 // @Accept 		json
@@ -250,6 +356,7 @@ func (r *authRoutes) changePWD(c *gin.Context) {
 // @Failure		401 {object} response.ErrAPI "authentication required"
 // @Router 		/v1/auth/check [get]
 func (r *authRoutes) check(c *gin.Context) {
+	r.contextmanager.EnsureCSRFToken(c)
 	c.JSON(http.StatusOK, nil)
 }
 
@@ -257,20 +364,27 @@ func NewAuthRouter(
 	router *gin.RouterGroup,
 	u usecase.Authentication,
 	authMW gin.HandlerFunc,
+	rateLimitMW func(group string) gin.HandlerFunc,
 	errHandler customerrors.ErrorHandler,
 	contextmanager contextmanager.Gin,
 	cfg *config.Config,
 ) {
 	r := new(contextmanager, errHandler, u, cfg)
+	// credentials and email tokens guessing and sending of emails are limited
+	limitMW := rateLimitMW("auth")
 	g := router.Group("/auth")
-	g.POST("/login", r.login)
+	g.POST("/login", limitMW, r.login)
 	g.POST("/logout", authMW, r.logout)
-	g.POST("/register", r.register)
+	g.POST("/register", limitMW, r.register)
 	g.POST("/refresh", r.refresh)
-	g.POST("/resend-verification", r.resend)
-	g.POST("/password/forgot", r.forgotPWD)
-	g.POST("/password/reset", r.resetPWD)
-	g.POST("/password/change", authMW, r.changePWD)
-	g.POST("/verify", r.verify)
+	g.POST("/resend-verification", limitMW, r.resend)
+	g.POST("/password/forgot", limitMW, r.forgotPWD)
+	g.POST("/password/reset", limitMW, r.resetPWD)
+	g.POST("/password/change", authMW, limitMW, r.changePWD)
+	g.POST("/activate", limitMW, r.activate)
+	g.POST("/verify", limitMW, r.verify)
+	g.POST("/email/change", authMW, limitMW, r.changeEmail)
+	g.POST("/email/confirm", limitMW, r.confirmEmail)
+	g.POST("/email/cancel", limitMW, r.cancelEmail)
 	g.GET("/check", authMW, r.check)
 }
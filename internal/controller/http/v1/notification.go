@@ -0,0 +1,151 @@
+package v1
+
+import (
+	"net/http"
+	"strconv"
+	"task-trail/internal/controller/http/v1/request"
+	"task-trail/internal/controller/http/v1/response"
+	"task-trail/internal/customerrors"
+	"task-trail/internal/pkg/contextmanager"
+	"task-trail/internal/usecase"
+	"task-trail/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type notificationRoutes struct {
+	contextmanager contextmanager.Gin
+	errHandler     customerrors.ErrorHandler
+	u              usecase.Notification
+}
+
+// @Summary 	get list of notifications
+// @Description In-app notifications of the current user from newest to oldest, with total and unread counts
+// @Security BearerAuth
+// @Tags 		/v1/notifications
+// @Accept 		json
+// @Produce 	json
+// @Param 		limit query int false "page size, 20 by default, max 100"
+// @Param 		offset query int false "number of skipped notifications"
+// @Param 		unread query bool false "return only unread notifications"
+// @Success 	200 {object} response.notificationListRes
+// @Failure		400 {object} response.ErrAPI "invalid query parameters"
+// @Failure		401 {object} response.ErrAPI "authentication required"
+// @Router 		/v1/notifications [get]
+func (r *notificationRoutes) getList(c *gin.Context) {
+	userID := utils.Must(r.contextmanager.GetUserID(c))
+	data, err := request.BindNotificationListDTO(c, userID)
+	if err != nil {
+		_ = c.Error(r.errHandler.Validation(err))
+		return
+	}
+	res, err := r.u.GetList(c, data)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, response.NewNotificationListResFromDTO(res))
+}
+
+// @Summary 	mark notification as read
+// @Security BearerAuth
+// @Tags 		/v1/notifications
+// @Accept 		json
+// @Produce 	json
+// @Param 		id path int true "notification id"
+// @Success 	200
+// @Failure		401 {object} response.ErrAPI "authentication required"
+// @Failure		404 {object} response.ErrAPI "notification not found"
+// @Router 		/v1/notifications/{id}/read [post]
+func (r *notificationRoutes) markRead(c *gin.Context) {
+	userID := utils.Must(r.contextmanager.GetUserID(c))
+	notificationID := utils.Must(strconv.Atoi(c.Param("id")))
+	if err := r.u.MarkRead(c, userID, notificationID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, nil)
+}
+
+// @Summary 	mark all notifications as read
+// @Security BearerAuth
+// @Tags 		/v1/notifications
+// @Accept 		json
+// @Produce 	json
+// @Success 	200 {object} response.notificationsMarkedRes
+// @Failure		401 {object} response.ErrAPI "authentication required"
+// @Router 		/v1/notifications/read-all [post]
+func (r *notificationRoutes) markAllRead(c *gin.Context) {
+	userID := utils.Must(r.contextmanager.GetUserID(c))
+	marked, err := r.u.MarkAllRead(c, userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, response.NewNotificationsMarkedRes(marked))
+}
+
+// @Summary 	get notification preferences
+// @Description Every notification type and channel with enabled flag, and digest frequency of notification emails
+// @Security BearerAuth
+// @Tags 		/v1/users
+// @Accept 		json
+// @Produce 	json
+// @Success 	200 {object} response.notificationPreferencesRes
+// @Failure		401 {object} response.ErrAPI "authentication required"
+// @Failure		404 {object} response.ErrAPI "user not found"
+// @Router 		/v1/users/me/notifications [get]
+func (r *notificationRoutes) getPreferences(c *gin.Context) {
+	userID := utils.Must(r.contextmanager.GetUserID(c))
+	res, err := r.u.GetPreferences(c, userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, response.NewNotificationPreferencesResFromDTO(res))
+}
+
+// @Summary 	update notification preferences
+// @Description Sets digest frequency and given preferences, omitted preferences are not changed.
+// @Description Security emails, like password reset, are always sent.
+// @Security BearerAuth
+// @Tags 		/v1/users
+// @Accept 		json
+// @Produce 	json
+// @Param 		body body request.notificationPreferencesReq true "notification preferences"
+// @Success 	200 {object} response.notificationPreferencesRes
+// @Failure		400 {object} response.ErrAPI "invalid request body"
+// @Failure		401 {object} response.ErrAPI "authentication required"
+// @Failure		404 {object} response.ErrAPI "user not found"
+// @Router 		/v1/users/me/notifications [put]
+func (r *notificationRoutes) updatePreferences(c *gin.Context) {
+	userID := utils.Must(r.contextmanager.GetUserID(c))
+	data, err := request.BindNotificationPreferencesDTO(c, userID)
+	if err != nil {
+		_ = c.Error(r.errHandler.Validation(err))
+		return
+	}
+	res, err := r.u.UpdatePreferences(c, data)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, response.NewNotificationPreferencesResFromDTO(res))
+}
+
+func NewNotificationRouter(
+	router *gin.RouterGroup,
+	u usecase.Notification,
+	authMW gin.HandlerFunc,
+	errHandler customerrors.ErrorHandler,
+	contextmanager contextmanager.Gin,
+) {
+	r := &notificationRoutes{u: u, contextmanager: contextmanager, errHandler: errHandler}
+	g := router.Group("/notifications")
+	g.GET("", authMW, r.getList)
+	g.POST("read-all", authMW, r.markAllRead)
+	g.POST(":id/read", authMW, r.markRead)
+	p := router.Group("/users/me/notifications")
+	p.GET("", authMW, r.getPreferences)
+	p.PUT("", authMW, r.updatePreferences)
+}
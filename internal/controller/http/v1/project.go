@@ -103,6 +103,7 @@ func (r *projectRoutes) getByID(c *gin.Context) {
 // @Success 	200
 // @Failure		404 {object} response.ErrAPI "user not found"
 // @Failure		401 {object} response.ErrAPI "authentication required"
+// @Failure		429 {object} response.ErrAPI "too many requests"
 // @Router 		/v1/projects/{id}/members [post]
 func (r *projectRoutes) addMembers(c *gin.Context) {
 	userID := utils.Must(r.contextmanager.GetUserID(c))
@@ -149,12 +150,14 @@ func NewProjectRouter(
 	router *gin.RouterGroup,
 	u usecase.Project,
 	authMW gin.HandlerFunc,
+	rateLimitMW func(group string) gin.HandlerFunc,
 	errHandler customerrors.ErrorHandler,
 	contextmanager contextmanager.Gin,
 ) {
 	r := &projectRoutes{u: u, contextmanager: contextmanager, errHandler: errHandler}
 	g := router.Group("/projects")
-	g.POST(":id/members", authMW, r.addMembers)
+	// invited members get emails
+	g.POST(":id/members", authMW, rateLimitMW("invite"), r.addMembers)
 	g.GET("candidates", authMW, r.getCandidates)
 	g.GET(":id", authMW, r.getByID)
 	g.POST("", authMW, r.create)
@@ -0,0 +1,83 @@
+package v1
+
+import (
+	"context"
+	"io"
+	"task-trail/internal/controller/http/v1/request"
+	"task-trail/internal/customerrors"
+	"task-trail/internal/pkg/contextmanager"
+	"task-trail/internal/usecase"
+	"task-trail/internal/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// interval of comments keeping idle connection open through proxies
+const heartbeatInterval = time.Second * 30
+
+type realtimeRoutes struct {
+	contextmanager contextmanager.Gin
+	errHandler     customerrors.ErrorHandler
+	u              usecase.Realtime
+}
+
+// @Summary 	stream project events
+// @Description Server-Sent Events stream of project changes.
+// @Description Event name is the event type, data is the JSON encoded event.
+// @Description Without projectId the user receives events of all projects they are a member of.
+// @Description The stream is closed when the access token expires, the client reconnects with a new one.
+// @Security BearerAuth
+// @Tags 		/v1/events
+// @Produce 	text/event-stream
+// @Param 		projectId query []int false "project id, can be repeated" collectionFormat(multi)
+// @Success 	200 {object} pubsub.Event
+// @Failure		400 {object} response.ErrAPI "invalid query parameters"
+// @Failure		401 {object} response.ErrAPI "authentication required"
+// @Failure		404 {object} response.ErrAPI "project not found"
+// @Router 		/v1/events [get]
+func (r *realtimeRoutes) stream(c *gin.Context) {
+	userID := utils.Must(r.contextmanager.GetUserID(c))
+	exp := utils.Must(r.contextmanager.GetAccessTokenExp(c))
+	projectIDs, err := request.BindSubscriptionProjectIDs(c)
+	if err != nil {
+		_ = c.Error(r.errHandler.Validation(err))
+		return
+	}
+	ctx, cancel := context.WithDeadline(c.Request.Context(), exp)
+	defer cancel()
+	events, err := r.u.Subscribe(ctx, userID, projectIDs)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				return false
+			}
+			c.SSEvent(e.Type, e)
+		case <-heartbeat.C:
+			_, err := io.WriteString(w, ": ping\n\n")
+			return err == nil
+		}
+		return true
+	})
+}
+
+func NewRealtimeRouter(
+	router *gin.RouterGroup,
+	u usecase.Realtime,
+	authMW gin.HandlerFunc,
+	errHandler customerrors.ErrorHandler,
+	contextmanager contextmanager.Gin,
+) {
+	r := &realtimeRoutes{u: u, contextmanager: contextmanager, errHandler: errHandler}
+	g := router.Group("/events")
+	g.GET("", authMW, r.stream)
+}
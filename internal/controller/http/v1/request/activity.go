@@ -0,0 +1,38 @@
+package request
+
+import (
+	"task-trail/internal/usecase/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+const defaultActivityLimit = 20
+
+type activityListReq struct {
+	Limit      int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset     int    `form:"offset" binding:"omitempty,min=0"`
+	ActorID    int    `form:"actorId" binding:"omitempty,min=1"`
+	EntityType string `form:"entityType" binding:"omitempty,oneof=project member task"`
+	EntityID   int    `form:"entityId" binding:"omitempty,min=1"`
+}
+
+// BindActivityListDTO binds and validates the query parameters from the Gin context.
+// Returns ActivityList DTO if ok, or an error if the query is invalid or binding fails.
+func BindActivityListDTO(c *gin.Context, userID int, projectID int) (*dto.ActivityList, error) {
+	var q activityListReq
+	if err := c.ShouldBindQuery(&q); err != nil {
+		return nil, err
+	}
+	if q.Limit == 0 {
+		q.Limit = defaultActivityLimit
+	}
+	return &dto.ActivityList{
+		ProjectID:  projectID,
+		UserID:     userID,
+		ActorID:    q.ActorID,
+		EntityType: q.EntityType,
+		EntityID:   q.EntityID,
+		Limit:      q.Limit,
+		Offset:     q.Offset,
+	}, nil
+}
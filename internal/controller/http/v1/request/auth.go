@@ -6,19 +6,26 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// max limits the password hashed on login, the policy doesn't allow longer ones
 type credentials struct {
 	Email    string `json:"email" binding:"required,email"`
-	Password string `json:"password" binding:"required,min=8,max=50"`
+	Password string `json:"password" binding:"required,max=72"`
 }
 
 type changePasswordReq struct {
-	OldPassword string `json:"oldPassword" binding:"required,min=8,max=50"`
-	NewPassword string `json:"newPassword" binding:"required,min=8,max=50"`
+	OldPassword string `json:"oldPassword" binding:"required"`
+	NewPassword string `json:"newPassword" binding:"required"`
 }
 
 type resetPasswordReq struct {
 	Token    string `json:"token" binding:"required,uuid"`
-	Password string `json:"password" binding:"required,min=8,max=50"`
+	Password string `json:"password" binding:"required"`
+}
+
+type activationReq struct {
+	Token    string `json:"token" binding:"required,uuid"`
+	Password string `json:"password" binding:"required"`
+	Username string `json:"username" binding:"required,max=100"`
 }
 
 type emailReq struct {
@@ -29,6 +36,22 @@ type verifyReq struct {
 	Token string `json:"token" binding:"required,uuid"`
 }
 
+type emailChangeReq struct {
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required"`
+}
+
+// BindEmailChangeDTO binds and validates the payload from the Gin context.
+// UserID required for build DTO
+// Returns EmailChange DTO if ok, or an error if the request payload is invalid or binding fails.
+func BindEmailChangeDTO(c *gin.Context, userID int) (*dto.EmailChange, error) {
+	body, err := validate[emailChangeReq](c)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.EmailChange{UserID: userID, NewEmail: body.Email, Password: body.Password}, nil
+}
+
 // BindChangePasswordDTO binds and validates the payload from the Gin context.
 // UserID required for build DTO
 // Returns PasswordChange DTO if ok, or an error if the request payload is invalid or binding fails.
@@ -50,6 +73,16 @@ func BindResetPasswordDTO(c *gin.Context) (*dto.PasswordReset, error) {
 	return &dto.PasswordReset{TokenID: body.Token, NewPassword: body.Password}, nil
 }
 
+// BindActivationDTO binds and validates the payload from the Gin context.
+// Returns Activation DTO if ok, or an error if the request payload is invalid or binding fails.
+func BindActivationDTO(c *gin.Context) (*dto.Activation, error) {
+	body, err := validate[activationReq](c)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.Activation{TokenID: body.Token, Password: body.Password, Username: body.Username}, nil
+}
+
 // BindEmail binds and validates the payload from the Gin context.
 // Returns the email as a string if ok, or an error if the request payload is invalid or binding fails.
 func BindEmail(c *gin.Context) (string, error) {
@@ -0,0 +1,59 @@
+package request
+
+import (
+	"task-trail/internal/usecase/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+const defaultNotificationLimit = 20
+
+type notificationListReq struct {
+	Limit  int  `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int  `form:"offset" binding:"omitempty,min=0"`
+	Unread bool `form:"unread"`
+}
+
+// BindNotificationListDTO binds and validates the query parameters from the Gin context.
+// UserID required for build DTO
+// Returns NotificationList DTO if ok, or an error if the query is invalid or binding fails.
+func BindNotificationListDTO(c *gin.Context, userID int) (*dto.NotificationList, error) {
+	var q notificationListReq
+	if err := c.ShouldBindQuery(&q); err != nil {
+		return nil, err
+	}
+	if q.Limit == 0 {
+		q.Limit = defaultNotificationLimit
+	}
+	return &dto.NotificationList{UserID: userID, OnlyUnread: q.Unread, Limit: q.Limit, Offset: q.Offset}, nil
+}
+
+type notificationPreferenceReq struct {
+	Type    string `json:"type" binding:"required,oneof=project_invite"`
+	Channel string `json:"channel" binding:"required,oneof=email in_app"`
+	Enabled *bool  `json:"enabled" binding:"required"`
+}
+
+type notificationPreferencesReq struct {
+	Digest      string                      `json:"digest" binding:"required,oneof=none daily weekly"`
+	Preferences []notificationPreferenceReq `json:"preferences" binding:"max=50,dive"`
+}
+
+// BindNotificationPreferencesDTO binds and validates the payload from the Gin context.
+// UserID required for build DTO
+// Returns NotificationPreferences DTO if ok, or an error if the request payload is invalid or binding fails.
+func BindNotificationPreferencesDTO(c *gin.Context, userID int) (*dto.NotificationPreferences, error) {
+	body, err := validate[notificationPreferencesReq](c)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]*dto.NotificationPreference, 0, len(body.Preferences))
+	for _, p := range body.Preferences {
+		items = append(items, &dto.NotificationPreference{
+			Type:    dto.NotificationType(p.Type),
+			Channel: dto.NotificationChannel(p.Channel),
+			Enabled: *p.Enabled,
+		})
+	}
+	return &dto.NotificationPreferences{UserID: userID, Digest: dto.DigestFrequency(body.Digest), Items: items}, nil
+}
@@ -0,0 +1,19 @@
+package request
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type subscriptionReq struct {
+	ProjectIDs []int `form:"projectId" binding:"max=100,dive,min=1"`
+}
+
+// BindSubscriptionProjectIDs binds and validates the query parameters from the Gin context.
+// Returns IDs of projects to subscribe, or an error if the query is invalid or binding fails.
+func BindSubscriptionProjectIDs(c *gin.Context) ([]int, error) {
+	var q subscriptionReq
+	if err := c.ShouldBindQuery(&q); err != nil {
+		return nil, err
+	}
+	return q.ProjectIDs, nil
+}
@@ -1,6 +1,9 @@
 package request
 
 import (
+	"fmt"
+	"slices"
+	"task-trail/internal/pkg/smtp/templates"
 	"task-trail/internal/usecase/dto"
 
 	"github.com/gin-gonic/gin"
@@ -9,6 +12,8 @@ import (
 // updateReq represents the request payload for updating a user's information.
 type updateReq struct {
 	Username string `json:"username" binding:"max=100"`
+	// Language is used for emails, one of templates.Locales
+	Language string `json:"language"`
 }
 
 func BindUserUpdateDTO(c *gin.Context, userID int) (*dto.UserUpdate, error) {
@@ -16,5 +21,22 @@ func BindUserUpdateDTO(c *gin.Context, userID int) (*dto.UserUpdate, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &dto.UserUpdate{ID: userID, Username: body.Username}, nil
+	if body.Language != "" && !slices.Contains(templates.Locales, body.Language) {
+		return nil, fmt.Errorf("unsupported language: %s", body.Language)
+	}
+	return &dto.UserUpdate{ID: userID, Username: body.Username, Language: body.Language}, nil
+}
+
+type accountDeletionReq struct {
+	Password string `json:"password" binding:"required"`
+}
+
+// BindAccountDeletionDTO binds and validates the payload from the Gin context.
+// Returns AccountDeletion DTO if ok, or an error if the request payload is invalid or binding fails.
+func BindAccountDeletionDTO(c *gin.Context, userID int) (*dto.AccountDeletion, error) {
+	body, err := validate[accountDeletionReq](c)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.AccountDeletion{UserID: userID, Password: body.Password}, nil
 }
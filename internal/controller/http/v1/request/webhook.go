@@ -0,0 +1,57 @@
+package request
+
+import (
+	"task-trail/internal/usecase/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+const defaultWebhookDeliveryLimit = 20
+
+type webhookCreateReq struct {
+	URL string `json:"url" binding:"required,http_url,max=2048"`
+	// Secret is generated if empty
+	Secret string `json:"secret" binding:"omitempty,min=16,max=128"`
+	// Events is the list of subscribed event types, empty list subscribes to all events
+	Events []string `json:"events" binding:"max=20,dive,oneof=project.members_added"`
+}
+
+type webhookDeliveryListReq struct {
+	Limit  int `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int `form:"offset" binding:"omitempty,min=0"`
+}
+
+// BindWebhookCreateDTO binds and validates the payload from the Gin context.
+// Returns WebhookCreate DTO if ok, or an error if the request payload is invalid or binding fails.
+func BindWebhookCreateDTO(c *gin.Context, userID int, projectID int) (*dto.WebhookCreate, error) {
+	body, err := validate[webhookCreateReq](c)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.WebhookCreate{
+		ProjectID: projectID,
+		OwnerID:   userID,
+		URL:       body.URL,
+		Secret:    body.Secret,
+		Events:    body.Events,
+	}, nil
+}
+
+// BindWebhookDeliveryListDTO binds and validates the query parameters from the Gin context.
+// Returns WebhookDeliveryList DTO if ok, or an error if the query is invalid or binding fails.
+func BindWebhookDeliveryListDTO(c *gin.Context, userID int, projectID int, webhookID int) (*dto.WebhookDeliveryList, error) {
+	var q webhookDeliveryListReq
+	if err := c.ShouldBindQuery(&q); err != nil {
+		return nil, err
+	}
+	if q.Limit == 0 {
+		q.Limit = defaultWebhookDeliveryLimit
+	}
+	return &dto.WebhookDeliveryList{
+		ProjectID: projectID,
+		OwnerID:   userID,
+		WebhookID: webhookID,
+		Limit:     q.Limit,
+		Offset:    q.Offset,
+	}, nil
+}
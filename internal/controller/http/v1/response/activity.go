@@ -0,0 +1,44 @@
+package response
+
+import (
+	"task-trail/internal/usecase/dto"
+	"time"
+)
+
+type activityRes struct {
+	ID int `json:"id"`
+	// ActorID is null when the actor account is removed
+	ActorID    *int           `json:"actorId"`
+	Action     string         `json:"action"`
+	EntityType string         `json:"entityType"`
+	EntityID   int            `json:"entityId"`
+	Diff       map[string]any `json:"diff"`
+	RequestID  *string        `json:"requestId"`
+	CreatedAt  time.Time      `json:"createdAt"`
+}
+
+type activityListRes struct {
+	Items []*activityRes `json:"items"`
+	Total int            `json:"total"`
+}
+
+func NewActivityResFromDTO(data *dto.Activity) *activityRes {
+	return &activityRes{
+		ID:         data.ID,
+		ActorID:    data.ActorID,
+		Action:     data.Action,
+		EntityType: data.EntityType,
+		EntityID:   data.EntityID,
+		Diff:       data.Diff,
+		RequestID:  data.RequestID,
+		CreatedAt:  data.CreatedAt,
+	}
+}
+
+func NewActivityListResFromDTO(data *dto.ActivityPage) *activityListRes {
+	items := make([]*activityRes, 0, len(data.Items))
+	for _, v := range data.Items {
+		items = append(items, NewActivityResFromDTO(v))
+	}
+	return &activityListRes{Items: items, Total: data.Total}
+}
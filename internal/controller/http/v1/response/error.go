@@ -13,6 +13,9 @@ type ErrAPI struct {
 	Status   int            `json:"-"`
 	Msg      string         `json:"msg,omitempty"`
 	Metadata map[string]any `json:"metadata,omitempty"`
+	// ids of the request and its trace, they are reported to support to find the failed request
+	RequestID string `json:"requestId,omitempty"`
+	TraceID   string `json:"traceId,omitempty"`
 }
 
 func New(status int, msg string, metadata map[string]any) *ErrAPI {
@@ -25,6 +28,10 @@ func NewFromErrBase(err *customerrors.Err) *ErrAPI {
 		return New(http.StatusUnauthorized, "authentication required", err.ResponseData)
 	case customerrors.InvalidCredentialsErr:
 		return New(http.StatusUnauthorized, "invalid credentials", err.ResponseData)
+	case customerrors.ForbiddenErr:
+		return New(http.StatusForbidden, err.Msg, err.ResponseData)
+	case customerrors.TooManyRequestsErr:
+		return New(http.StatusTooManyRequests, "too many requests", err.ResponseData)
 	case customerrors.ValidationErr:
 		return New(http.StatusBadRequest, err.Msg, prepareValidationErrMetadata(err))
 	case customerrors.ConflictErr:
@@ -39,6 +46,9 @@ func NewFromErrBase(err *customerrors.Err) *ErrAPI {
 }
 
 func prepareValidationErrMetadata(err *customerrors.Err) map[string]any {
+	if err.ResponseData != nil {
+		return err.ResponseData
+	}
 	metadata := make(map[string]any)
 	sourceErr := err.Unwrap()
 	if sourceErr == nil {
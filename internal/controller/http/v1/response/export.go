@@ -0,0 +1,111 @@
+package response
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"task-trail/internal/usecase/dto"
+	"time"
+)
+
+type exportProfileRes struct {
+	ID         int        `json:"id"`
+	Email      string     `json:"email"`
+	Username   *string    `json:"username"`
+	AvatarUrl  *string    `json:"avatarUrl"`
+	VerifiedAt *time.Time `json:"verifiedAt"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+type exportTaskRes struct {
+	ID          int       `json:"id"`
+	ProjectID   int       `json:"projectId"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+type exportFileRes struct {
+	ID           string     `json:"id"`
+	OriginalName string     `json:"originalName"`
+	MimeType     string     `json:"mimeType"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	DeletedAt    *time.Time `json:"deletedAt"`
+}
+
+type exportInfoRes struct {
+	CreatedAt time.Time `json:"createdAt"`
+	Files     []string  `json:"files"`
+}
+
+// WriteUserExportArchive writes ZIP archive with user data to w.
+// Each data section is stored in a separate JSON file.
+func WriteUserExportArchive(w io.Writer, data *dto.UserExport) error {
+	tasks := make([]*exportTaskRes, 0, len(data.Tasks))
+	for _, v := range data.Tasks {
+		tasks = append(tasks, &exportTaskRes{
+			ID:          v.ID,
+			ProjectID:   v.ProjectID,
+			Name:        v.Name,
+			Description: v.Description,
+			CreatedAt:   v.CreatedAt,
+		})
+	}
+	files := make([]*exportFileRes, 0, len(data.Files))
+	for _, v := range data.Files {
+		deletedAt := v.DeletedAt
+		if deletedAt == nil {
+			deletedAt = v.SoftDeletedAt
+		}
+		files = append(files, &exportFileRes{
+			ID:           v.ID,
+			OriginalName: v.OriginalName,
+			MimeType:     v.MimeType,
+			CreatedAt:    v.CreatedAt,
+			DeletedAt:    deletedAt,
+		})
+	}
+	sections := []struct {
+		name string
+		data any
+	}{
+		{"profile.json", &exportProfileRes{
+			ID:         data.Profile.ID,
+			Email:      data.Profile.Email,
+			Username:   data.Profile.Username,
+			AvatarUrl:  data.Profile.AvatarURL,
+			VerifiedAt: data.Profile.VerifiedAt,
+			CreatedAt:  data.Profile.CreatedAt,
+		}},
+		{"projects.json", NewProjectResFromDTOBatch(data.Projects)},
+		{"tasks.json", tasks},
+		{"files.json", files},
+	}
+
+	archive := zip.NewWriter(w)
+	info := &exportInfoRes{CreatedAt: data.CreatedAt}
+	for _, s := range sections {
+		if err := writeJSONToArchive(archive, s.name, s.data); err != nil {
+			return err
+		}
+		info.Files = append(info.Files, s.name)
+	}
+	if err := writeJSONToArchive(archive, "export.json", info); err != nil {
+		return err
+	}
+	return archive.Close()
+}
+
+func writeJSONToArchive(archive *zip.Writer, name string, data any) error {
+	f, err := archive.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to create %s in archive: %w", name, err)
+	}
+	e := json.NewEncoder(f)
+	e.SetIndent("", "  ")
+	if err := e.Encode(data); err != nil {
+		return fmt.Errorf("failed to write %s in archive: %w", name, err)
+	}
+	return nil
+}
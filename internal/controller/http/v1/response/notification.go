@@ -0,0 +1,69 @@
+package response
+
+import (
+	"task-trail/internal/usecase/dto"
+	"time"
+)
+
+type notificationRes struct {
+	ID        int               `json:"id"`
+	Type      string            `json:"type"`
+	ProjectID *int              `json:"projectId"`
+	TaskID    *int              `json:"taskId"`
+	Payload   map[string]string `json:"payload"`
+	ReadAt    *time.Time        `json:"readAt"`
+	CreatedAt time.Time         `json:"createdAt"`
+}
+
+type notificationListRes struct {
+	Items  []*notificationRes `json:"items"`
+	Total  int                `json:"total"`
+	Unread int                `json:"unread"`
+}
+
+type notificationsMarkedRes struct {
+	Marked int `json:"marked"`
+}
+
+func NewNotificationResFromDTO(data *dto.Notification) *notificationRes {
+	return &notificationRes{
+		ID:        data.ID,
+		Type:      string(data.Type),
+		ProjectID: data.ProjectID,
+		TaskID:    data.TaskID,
+		Payload:   data.Payload,
+		ReadAt:    data.ReadAt,
+		CreatedAt: data.CreatedAt,
+	}
+}
+
+func NewNotificationListResFromDTO(data *dto.NotificationPage) *notificationListRes {
+	items := make([]*notificationRes, 0, len(data.Items))
+	for _, v := range data.Items {
+		items = append(items, NewNotificationResFromDTO(v))
+	}
+	return &notificationListRes{Items: items, Total: data.Total, Unread: data.Unread}
+}
+
+func NewNotificationsMarkedRes(marked int) *notificationsMarkedRes {
+	return &notificationsMarkedRes{Marked: marked}
+}
+
+type notificationPreferenceRes struct {
+	Type    string `json:"type"`
+	Channel string `json:"channel"`
+	Enabled bool   `json:"enabled"`
+}
+
+type notificationPreferencesRes struct {
+	Digest      string                       `json:"digest"`
+	Preferences []*notificationPreferenceRes `json:"preferences"`
+}
+
+func NewNotificationPreferencesResFromDTO(data *dto.NotificationPreferences) *notificationPreferencesRes {
+	items := make([]*notificationPreferenceRes, 0, len(data.Items))
+	for _, v := range data.Items {
+		items = append(items, &notificationPreferenceRes{Type: string(v.Type), Channel: string(v.Channel), Enabled: v.Enabled})
+	}
+	return &notificationPreferencesRes{Digest: string(data.Digest), Preferences: items}
+}
@@ -2,6 +2,7 @@ package response
 
 import (
 	"task-trail/internal/usecase/dto"
+	"time"
 )
 
 type avatarRes struct {
@@ -13,21 +14,33 @@ func NewAvatarResFromDTO(data *dto.UserAvatar) *avatarRes {
 }
 
 type currentRes struct {
-	ID        int     `json:"id"`
-	Email     string  `json:"email"`
-	Username  *string `json:"username"`
-	AvatarUrl *string `json:"avatarUrl"`
+	ID                  int        `json:"id"`
+	Email               string     `json:"email"`
+	Username            *string    `json:"username"`
+	AvatarUrl           *string    `json:"avatarUrl"`
+	Language            *string    `json:"language"`
+	DeletionScheduledAt *time.Time `json:"deletionScheduledAt"`
 }
 
 func NewCurrentResFromDTO(data *dto.CurrentUser) *currentRes {
 	return &currentRes{
-		ID:        data.ID,
-		Username:  data.Username,
-		Email:     data.Email,
-		AvatarUrl: data.AvatarURL,
+		ID:                  data.ID,
+		Username:            data.Username,
+		Email:               data.Email,
+		AvatarUrl:           data.AvatarURL,
+		Language:            data.Language,
+		DeletionScheduledAt: data.DeletionScheduledAt,
 	}
 }
 
+type deletionRes struct {
+	ScheduledAt time.Time `json:"scheduledAt"`
+}
+
+func NewDeletionRes(scheduledAt *time.Time) *deletionRes {
+	return &deletionRes{ScheduledAt: *scheduledAt}
+}
+
 type userSimpleRes struct {
 	ID       int     `json:"id"`
 	Email    string  `json:"email"`
@@ -0,0 +1,80 @@
+package response
+
+import (
+	"task-trail/internal/usecase/dto"
+	"time"
+)
+
+type webhookRes struct {
+	ID        int       `json:"id"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type webhookCreateRes struct {
+	webhookRes
+	// Secret is returned only once, on webhook creation
+	Secret string `json:"secret"`
+}
+
+type webhookDeliveryRes struct {
+	ID           int        `json:"id"`
+	EventID      string     `json:"eventId"`
+	EventType    string     `json:"eventType"`
+	Status       string     `json:"status"`
+	Attempts     int        `json:"attempts"`
+	ResponseCode *int       `json:"responseCode"`
+	LastError    *string    `json:"lastError"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	DeliveredAt  *time.Time `json:"deliveredAt"`
+	// NextAttemptAt is the time of the next retry, set only for pending deliveries
+	NextAttemptAt *time.Time `json:"nextAttemptAt"`
+}
+
+func NewWebhookResFromDTO(data *dto.Webhook) *webhookRes {
+	events := data.Events
+	if events == nil {
+		events = []string{}
+	}
+	return &webhookRes{ID: data.ID, URL: data.URL, Events: events, CreatedAt: data.CreatedAt}
+}
+
+func NewWebhookResFromDTOBatch(data []*dto.Webhook) []*webhookRes {
+	retVal := make([]*webhookRes, 0, len(data))
+	for _, v := range data {
+		retVal = append(retVal, NewWebhookResFromDTO(v))
+	}
+	return retVal
+}
+
+func NewWebhookCreateResFromDTO(data *dto.Webhook) *webhookCreateRes {
+	return &webhookCreateRes{webhookRes: *NewWebhookResFromDTO(data), Secret: data.Secret}
+}
+
+func NewWebhookDeliveryResFromDTO(data *dto.WebhookDelivery) *webhookDeliveryRes {
+	retVal := &webhookDeliveryRes{
+		ID:           data.ID,
+		EventID:      data.EventID,
+		EventType:    data.EventType,
+		Status:       string(data.Status),
+		Attempts:     data.Attempts,
+		ResponseCode: data.ResponseCode,
+		LastError:    data.LastError,
+		CreatedAt:    data.CreatedAt,
+		DeliveredAt:  data.DeliveredAt,
+	}
+	if data.Status == dto.WebhookDeliveryPending {
+		next := data.NextAttemptAt
+		retVal.NextAttemptAt = &next
+	}
+	return retVal
+}
+
+func NewWebhookDeliveryResFromDTOBatch(data []*dto.WebhookDelivery) []*webhookDeliveryRes {
+	retVal := make([]*webhookDeliveryRes, 0, len(data))
+	for _, v := range data {
+		retVal = append(retVal, NewWebhookDeliveryResFromDTO(v))
+	}
+	return retVal
+}
@@ -17,14 +17,23 @@ func NewRouter(
 	userUC usecase.User,
 	projectUC usecase.Project,
 	authUC usecase.Authentication,
+	notificationUC usecase.Notification,
+	realtimeUC usecase.Realtime,
+	webhookUC usecase.Webhook,
+	activityUC usecase.Activity,
 	contextmanager contextmanager.Gin,
 	errHandler customerrors.ErrorHandler,
 	storage storage.Service,
 	authMW gin.HandlerFunc,
+	rateLimitMW func(group string) gin.HandlerFunc,
 ) {
 
-	g := router.Group("/v1")
-	NewUserRouter(g, userUC, authMW, errHandler, contextmanager, storage)
-	NewProjectRouter(g, projectUC, authMW, errHandler, contextmanager)
-	NewAuthRouter(g, authUC, authMW, errHandler, contextmanager, cfg)
+	g := router.Group("/v1", rateLimitMW("api"))
+	NewUserRouter(g, userUC, authMW, rateLimitMW, errHandler, contextmanager, storage)
+	NewProjectRouter(g, projectUC, authMW, rateLimitMW, errHandler, contextmanager)
+	NewAuthRouter(g, authUC, authMW, rateLimitMW, errHandler, contextmanager, cfg)
+	NewNotificationRouter(g, notificationUC, authMW, errHandler, contextmanager)
+	NewRealtimeRouter(g, realtimeUC, authMW, errHandler, contextmanager)
+	NewWebhookRouter(g, webhookUC, authMW, errHandler, contextmanager)
+	NewActivityRouter(g, activityUC, authMW, errHandler, contextmanager)
 }
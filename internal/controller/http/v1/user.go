@@ -1,6 +1,8 @@
 package v1
 
 import (
+	"bytes"
+	"fmt"
 	"net/http"
 	"task-trail/internal/controller/http/v1/request"
 	"task-trail/internal/controller/http/v1/response"
@@ -62,6 +64,7 @@ func (r *usersRoutes) getMe(c *gin.Context) {
 // @Param 		file formData file true "new file"
 // @Success 	200 {object} response.avatarRes
 // @Failure		401 {object} response.ErrAPI "authentication required"
+// @Failure		429 {object} response.ErrAPI "too many requests"
 // @Router 		/v1/users/me/avatar [patch]
 func (r *usersRoutes) updateAvatar(c *gin.Context) {
 	userID := utils.Must(r.contextmanager.GetUserID(c))
@@ -96,7 +99,7 @@ func (r *usersRoutes) updateMe(c *gin.Context) {
 	userID := utils.Must(r.contextmanager.GetUserID(c))
 	data, err := request.BindUserUpdateDTO(c, userID)
 	if err != nil {
-		_ = c.Error(err)
+		_ = c.Error(r.errHandler.Validation(err))
 		return
 	}
 	res, err := r.u.UpdateByID(c, data)
@@ -106,18 +109,92 @@ func (r *usersRoutes) updateMe(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, response.NewCurrentResFromDTO(res))
 }
+
+// @Summary 	export current user data
+// @Description ZIP archive with JSON files: profile, projects, authored tasks and files metadata
+// @Security BearerAuth
+// @Tags 		/v1/users
+// @Produce 	application/zip
+// @Success 	200 {file} file "user data archive"
+// @Failure		401 {object} response.ErrAPI "authentication required"
+// @Router 		/v1/users/me/export [get]
+func (r *usersRoutes) exportMe(c *gin.Context) {
+	userID := utils.Must(r.contextmanager.GetUserID(c))
+	res, err := r.u.Export(c, userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	buf := bytes.NewBuffer(nil)
+	if err := response.WriteUserExportArchive(buf, res); err != nil {
+		_ = c.Error(r.errHandler.InternalTrouble(err, "failed to build user data archive", "userID", userID))
+		return
+	}
+	filename := fmt.Sprintf("tasktrail-export-%d-%s.zip", userID, res.CreatedAt.Format("20060102"))
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Data(http.StatusOK, "application/zip", buf.Bytes())
+}
+
+// @Summary 	request current user account deletion
+// @Description account will be anonymized after the grace period, owned projects will be transferred to other members
+// @Security BearerAuth
+// @Tags 		/v1/users
+// @Accept 		json
+// @Produce 	json
+// @Param 		body body request.accountDeletionReq true "current password"
+// @Success 	200 {object} response.deletionRes
+// @Failure		400 {object} response.ErrAPI "invalid request body"
+// @Failure		401 {object} response.ErrAPI "authentication required"
+// @Router 		/v1/users/me/deletion [post]
+func (r *usersRoutes) requestDeletion(c *gin.Context) {
+	userID := utils.Must(r.contextmanager.GetUserID(c))
+	data, err := request.BindAccountDeletionDTO(c, userID)
+	if err != nil {
+		_ = c.Error(r.errHandler.Validation(err))
+		return
+	}
+	res, err := r.u.RequestDeletion(c, data)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, response.NewDeletionRes(res))
+}
+
+// @Summary 	cancel current user account deletion
+// @Security BearerAuth
+// @Tags 		/v1/users
+// @Accept 		json
+// @Produce 	json
+// @Success 	200
+// @Failure		400 {object} response.ErrAPI "account deletion is not requested"
+// @Failure		401 {object} response.ErrAPI "authentication required"
+// @Router 		/v1/users/me/deletion [delete]
+func (r *usersRoutes) cancelDeletion(c *gin.Context) {
+	userID := utils.Must(r.contextmanager.GetUserID(c))
+	if err := r.u.CancelDeletion(c, userID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, nil)
+}
+
 func NewUserRouter(
 	router *gin.RouterGroup,
 	u usecase.User,
 	authMW gin.HandlerFunc,
+	rateLimitMW func(group string) gin.HandlerFunc,
 	errHandler customerrors.ErrorHandler,
 	contextmanager contextmanager.Gin,
 	storage storage.Service,
 ) {
 	r := &usersRoutes{u: u, contextmanager: contextmanager, errHandler: errHandler, storage: storage}
 	g := router.Group("/users")
-	g.PATCH("me/avatar", authMW, r.updateAvatar)
+	g.PATCH("me/avatar", authMW, rateLimitMW("upload"), r.updateAvatar)
 	g.GET(":id", authMW, r.getUser)
 	g.GET("me", authMW, r.getMe)
 	g.PATCH("me", authMW, r.updateMe)
+	g.GET("me/export", authMW, r.exportMe)
+	g.POST("me/deletion", authMW, r.requestDeletion)
+	g.DELETE("me/deletion", authMW, r.cancelDeletion)
 }
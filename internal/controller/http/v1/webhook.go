@@ -0,0 +1,166 @@
+package v1
+
+import (
+	"net/http"
+	"strconv"
+	"task-trail/internal/controller/http/v1/request"
+	"task-trail/internal/controller/http/v1/response"
+	"task-trail/internal/customerrors"
+	"task-trail/internal/pkg/contextmanager"
+	"task-trail/internal/usecase"
+	"task-trail/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type webhookRoutes struct {
+	contextmanager contextmanager.Gin
+	errHandler     customerrors.ErrorHandler
+	u              usecase.Webhook
+}
+
+// @Summary 	create project webhook
+// @Description Subscribes the URL to project events, available for the project owner.
+// @Description Requests are signed with HMAC-SHA256 of the body in the X-TaskTrail-Signature header: sha256=<hex>.
+// @Description The secret is generated if not given and returned only in this response.
+// @Security BearerAuth
+// @Tags 		/v1/project
+// @Accept 		json
+// @Produce 	json
+// @Param 		id path int true "project id"
+// @Param 		body body request.webhookCreateReq true "webhook"
+// @Success 	200 {object} response.webhookCreateRes
+// @Failure		400 {object} response.ErrAPI "invalid request body"
+// @Failure		401 {object} response.ErrAPI "authentication required"
+// @Failure		404 {object} response.ErrAPI "project not found"
+// @Router 		/v1/projects/{id}/webhooks [post]
+func (r *webhookRoutes) create(c *gin.Context) {
+	userID := utils.Must(r.contextmanager.GetUserID(c))
+	projectID := utils.Must(strconv.Atoi(c.Param("id")))
+	data, err := request.BindWebhookCreateDTO(c, userID, projectID)
+	if err != nil {
+		_ = c.Error(r.errHandler.Validation(err))
+		return
+	}
+	res, err := r.u.Create(c, data)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, response.NewWebhookCreateResFromDTO(res))
+}
+
+// @Summary 	get project webhooks
+// @Security BearerAuth
+// @Tags 		/v1/project
+// @Accept 		json
+// @Produce 	json
+// @Param 		id path int true "project id"
+// @Success 	200 {array} response.webhookRes
+// @Failure		401 {object} response.ErrAPI "authentication required"
+// @Failure		404 {object} response.ErrAPI "project not found"
+// @Router 		/v1/projects/{id}/webhooks [get]
+func (r *webhookRoutes) getList(c *gin.Context) {
+	userID := utils.Must(r.contextmanager.GetUserID(c))
+	projectID := utils.Must(strconv.Atoi(c.Param("id")))
+	res, err := r.u.GetList(c, projectID, userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, response.NewWebhookResFromDTOBatch(res))
+}
+
+// @Summary 	delete project webhook
+// @Security BearerAuth
+// @Tags 		/v1/project
+// @Accept 		json
+// @Produce 	json
+// @Param 		id path int true "project id"
+// @Param 		webhookId path int true "webhook id"
+// @Success 	200
+// @Failure		401 {object} response.ErrAPI "authentication required"
+// @Failure		404 {object} response.ErrAPI "project or webhook not found"
+// @Router 		/v1/projects/{id}/webhooks/{webhookId} [delete]
+func (r *webhookRoutes) delete(c *gin.Context) {
+	userID := utils.Must(r.contextmanager.GetUserID(c))
+	projectID := utils.Must(strconv.Atoi(c.Param("id")))
+	webhookID := utils.Must(strconv.Atoi(c.Param("webhookId")))
+	if err := r.u.Delete(c, projectID, userID, webhookID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, nil)
+}
+
+// @Summary 	get webhook delivery log
+// @Description Deliveries from newest to oldest with response codes and errors of the last attempt
+// @Security BearerAuth
+// @Tags 		/v1/project
+// @Accept 		json
+// @Produce 	json
+// @Param 		id path int true "project id"
+// @Param 		webhookId path int true "webhook id"
+// @Param 		limit query int false "page size, 20 by default, max 100"
+// @Param 		offset query int false "number of skipped deliveries"
+// @Success 	200 {array} response.webhookDeliveryRes
+// @Failure		400 {object} response.ErrAPI "invalid query parameters"
+// @Failure		401 {object} response.ErrAPI "authentication required"
+// @Failure		404 {object} response.ErrAPI "project or webhook not found"
+// @Router 		/v1/projects/{id}/webhooks/{webhookId}/deliveries [get]
+func (r *webhookRoutes) getDeliveries(c *gin.Context) {
+	userID := utils.Must(r.contextmanager.GetUserID(c))
+	projectID := utils.Must(strconv.Atoi(c.Param("id")))
+	webhookID := utils.Must(strconv.Atoi(c.Param("webhookId")))
+	data, err := request.BindWebhookDeliveryListDTO(c, userID, projectID, webhookID)
+	if err != nil {
+		_ = c.Error(r.errHandler.Validation(err))
+		return
+	}
+	res, err := r.u.GetDeliveries(c, data)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, response.NewWebhookDeliveryResFromDTOBatch(res))
+}
+
+// @Summary 	send test event
+// @Description Sends the webhook.test event immediately and returns the delivery result
+// @Security BearerAuth
+// @Tags 		/v1/project
+// @Accept 		json
+// @Produce 	json
+// @Param 		id path int true "project id"
+// @Param 		webhookId path int true "webhook id"
+// @Success 	200 {object} response.webhookDeliveryRes
+// @Failure		401 {object} response.ErrAPI "authentication required"
+// @Failure		404 {object} response.ErrAPI "project or webhook not found"
+// @Router 		/v1/projects/{id}/webhooks/{webhookId}/test [post]
+func (r *webhookRoutes) sendTest(c *gin.Context) {
+	userID := utils.Must(r.contextmanager.GetUserID(c))
+	projectID := utils.Must(strconv.Atoi(c.Param("id")))
+	webhookID := utils.Must(strconv.Atoi(c.Param("webhookId")))
+	res, err := r.u.SendTest(c, projectID, userID, webhookID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, response.NewWebhookDeliveryResFromDTO(res))
+}
+
+func NewWebhookRouter(
+	router *gin.RouterGroup,
+	u usecase.Webhook,
+	authMW gin.HandlerFunc,
+	errHandler customerrors.ErrorHandler,
+	contextmanager contextmanager.Gin,
+) {
+	r := &webhookRoutes{u: u, contextmanager: contextmanager, errHandler: errHandler}
+	g := router.Group("/projects")
+	g.POST(":id/webhooks", authMW, r.create)
+	g.GET(":id/webhooks", authMW, r.getList)
+	g.DELETE(":id/webhooks/:webhookId", authMW, r.delete)
+	g.GET(":id/webhooks/:webhookId/deliveries", authMW, r.getDeliveries)
+	g.POST(":id/webhooks/:webhookId/test", authMW, r.sendTest)
+}
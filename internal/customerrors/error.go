@@ -16,6 +16,8 @@ const (
 	ConflictErr
 	NotFoundErr
 	Ok
+	ForbiddenErr
+	TooManyRequestsErr
 )
 
 const sourceCodeOffset = 2
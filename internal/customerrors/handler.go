@@ -6,8 +6,12 @@ type ErrorHandler interface {
 	InvalidCredentials(err error, msg string, args ...any) error
 	InternalTrouble(err error, msg string, args ...any) error
 	Unauthorized(err error, msg string, args ...any) error
+	Forbidden(err error, msg string, args ...any) error
+	TooManyRequests(err error, msg string, args ...any) error
 	Validation(err error) error
 	BadRequest(err error, msg string, args ...any) error
+	// InvalidFields returns validation error with field-level messages, fields are passed to the response as is.
+	InvalidFields(err error, msg string, fields map[string]any, args ...any) error
 	Ok(err error, msg string, args ...any) error
 }
 
@@ -27,12 +31,21 @@ func (h *ErrHandler) InternalTrouble(err error, msg string, args ...any) error {
 func (h *ErrHandler) Unauthorized(err error, msg string, args ...any) error {
 	return newErr(UnauthorizedErr, err, msg, nil, args...)
 }
+func (h *ErrHandler) Forbidden(err error, msg string, args ...any) error {
+	return newErr(ForbiddenErr, err, msg, nil, args...)
+}
+func (h *ErrHandler) TooManyRequests(err error, msg string, args ...any) error {
+	return newErr(TooManyRequestsErr, err, msg, nil, args...)
+}
 func (h *ErrHandler) NotFound(err error, msg string, args ...any) error {
 	return newErr(NotFoundErr, err, msg, nil, args...)
 }
 func (h *ErrHandler) BadRequest(err error, msg string, args ...any) error {
 	return newErr(ValidationErr, err, msg, nil, args...)
 }
+func (h *ErrHandler) InvalidFields(err error, msg string, fields map[string]any, args ...any) error {
+	return newErr(ValidationErr, err, msg, fields, args...)
+}
 func (h *ErrHandler) Validation(err error) error {
 	return newErr(ValidationErr, err, "request validation failed", nil, "error", err)
 }
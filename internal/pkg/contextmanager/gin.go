@@ -1,6 +1,8 @@
 package contextmanager
 
 import (
+	"context"
+	"crypto/rand"
 	"fmt"
 	"net/http"
 	"task-trail/internal/pkg/uuid"
@@ -11,45 +13,96 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// requestIDKey is the key of request id in gin context
+const requestIDKey = "reqID"
+
+// HeaderRequestID is the header carrying request id from the client and back in the response.
+const HeaderRequestID = "X-Request-ID"
+
+// maxRequestIDLength limits incoming request id, longer ones are replaced by generated id.
+const maxRequestIDLength = 64
+
+// requestIDCtxKey is the key of request id in context.Context
+type requestIDCtxKey struct{}
+
+// userIDCtxKey is the key of authenticated user id in context.Context
+type userIDCtxKey struct{}
+
 type Gin interface {
 	DeleteAccessToken(c *gin.Context, name string)
 	DeleteTokens(c *gin.Context, atName string, rtName string, refreshPath string)
 	SetTokens(c *gin.Context, at *dto.AccessTokenRes, rt *dto.RefreshTokenRes, atName string, rtName string, refreshPath string)
+	EnsureCSRFToken(c *gin.Context)
 	SetUserID(c *gin.Context, userID int)
 	GetUserID(c *gin.Context) (int, error)
+	SetAccessTokenExp(c *gin.Context, exp time.Time)
+	GetAccessTokenExp(c *gin.Context) (time.Time, error)
 	SetRequestID(c *gin.Context)
 	GetRequestID(c *gin.Context) string
 }
 
 type GinContextManager struct {
 	uuidGenerator uuid.Generator
+	csrfName      string
+	// attributes of the auth cookies
+	domain   string
+	secure   bool
+	sameSite http.SameSite
 }
 
-func NewGin(uuidGenerator uuid.Generator) *GinContextManager {
-	return &GinContextManager{uuidGenerator: uuidGenerator}
+// csrfName is the cookie of csrf token issued with the tokens pair, it is readable by the frontend
+// and must be sent back in the header of state-changing requests.
+func NewGin(uuidGenerator uuid.Generator, csrfName string, opts ...Option) *GinContextManager {
+	m := &GinContextManager{
+		uuidGenerator: uuidGenerator,
+		csrfName:      csrfName,
+		secure:        true,
+		sameSite:      http.SameSiteLaxMode,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
 }
 
 func (m *GinContextManager) DeleteAccessToken(c *gin.Context, name string) {
-	c.SetSameSite(http.SameSiteLaxMode)
-	c.SetCookie(name, "", -1, "/", "", true, true)
+	m.setCookie(c, name, "", -1, "/", true)
 }
 
 func (m *GinContextManager) DeleteTokens(c *gin.Context, atName string, rtName string, refreshPath string) {
-	c.SetSameSite(http.SameSiteLaxMode)
-	c.SetCookie(atName, "", -1, "/", "", true, true)
-	c.SetCookie(rtName, "", -1, refreshPath, "", true, true)
+	m.setCookie(c, atName, "", -1, "/", true)
+	m.setCookie(c, rtName, "", -1, refreshPath, true)
+	m.setCookie(c, m.csrfName, "", -1, "/", false)
 }
 
 func (m *GinContextManager) SetTokens(c *gin.Context, at *dto.AccessTokenRes, rt *dto.RefreshTokenRes, atName string, rtName string, refreshPath string) {
-	c.SetSameSite(http.SameSiteLaxMode)
 	atTime := int(time.Until(at.Exp).Seconds())
 	rtTime := int(time.Until(rt.Exp).Seconds())
-	c.SetCookie(atName, at.Token, atTime, "/", "", true, true)
-	c.SetCookie(rtName, rt.Token, rtTime, refreshPath, "", true, true)
+	m.setCookie(c, atName, at.Token, atTime, "/", true)
+	m.setCookie(c, rtName, rt.Token, rtTime, refreshPath, true)
+	// csrf token lives as long as the session, so refresh requests are protected too
+	m.setCookie(c, m.csrfName, rand.Text(), rtTime, "/", false)
+}
+
+// EnsureCSRFToken issues csrf cookie to the session without it, e.g. started before csrf protection.
+// The cookie lives until the browser is closed, it gets the session lifetime on the next refresh.
+func (m *GinContextManager) EnsureCSRFToken(c *gin.Context) {
+	if v, err := c.Cookie(m.csrfName); err == nil && v != "" {
+		return
+	}
+	m.setCookie(c, m.csrfName, rand.Text(), 0, "/", false)
+}
+
+// setCookie sets cookie with the configured domain, secure flag and SameSite,
+// deleting cookie must have the same attributes as the one being deleted.
+func (m *GinContextManager) setCookie(c *gin.Context, name, value string, maxAge int, path string, httpOnly bool) {
+	c.SetSameSite(m.sameSite)
+	c.SetCookie(name, value, maxAge, path, m.domain, m.secure, httpOnly)
 }
 
 func (m *GinContextManager) SetUserID(c *gin.Context, userID int) {
 	c.Set("userID", userID)
+	c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), userIDCtxKey{}, userID))
 }
 
 func (m *GinContextManager) GetUserID(c *gin.Context) (int, error) {
@@ -62,12 +115,68 @@ func (m *GinContextManager) GetUserID(c *gin.Context) (int, error) {
 	return 0, fmt.Errorf("user id not found in request")
 }
 
+// SetAccessTokenExp keeps expiration time of the token the request is authenticated with,
+// so long-lived requests can be ended when the token expires.
+func (m *GinContextManager) SetAccessTokenExp(c *gin.Context, exp time.Time) {
+	c.Set("accessTokenExp", exp)
+}
+
+func (m *GinContextManager) GetAccessTokenExp(c *gin.Context) (time.Time, error) {
+	if exp, ok := c.Keys["accessTokenExp"].(time.Time); ok {
+		return exp, nil
+	}
+	return time.Time{}, fmt.Errorf("access token expiration not found in request")
+}
+
+// SetRequestID accepts request id of the client or generates a new one if it is missing or malformed.
+// The id is echoed in the response header and attached to the request context.
 func (m *GinContextManager) SetRequestID(c *gin.Context) {
-	c.Set("reqID", m.uuidGenerator.Generate())
+	id := c.GetHeader(HeaderRequestID)
+	if !validRequestID(id) {
+		id = m.uuidGenerator.Generate()
+	}
+	c.Set(requestIDKey, id)
+	c.Request = c.Request.WithContext(WithRequestID(c.Request.Context(), id))
+	c.Header(HeaderRequestID, id)
 }
 
-// return request id or nil if not found
+// return request id or empty string if not found
 func (m *GinContextManager) GetRequestID(c *gin.Context) string {
-	return c.Keys["reqID"].(string)
+	id, _ := c.Keys[requestIDKey].(string)
+	return id
+}
+
+// WithRequestID returns context carrying the request id, it is used to keep the id
+// in work done on behalf of the request outside of it, e.g. delivery of emails.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDCtxKey{}, id)
+}
 
+// RequestIDFromContext returns request id or empty string if not found.
+// Gin context falls back to the request context, so it works for it and contexts derived from it.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDCtxKey{}).(string)
+	return id
+}
+
+// UserIDFromContext returns id of the authenticated user, false if the request is anonymous.
+func UserIDFromContext(ctx context.Context) (int, bool) {
+	id, ok := ctx.Value(userIDCtxKey{}).(int)
+	return id, ok
+}
+
+// validRequestID allows only short ids of safe symbols, so client can't inject anything into logs.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-' || r == '_' || r == '.' || r == ':':
+		default:
+			return false
+		}
+	}
+	return true
 }
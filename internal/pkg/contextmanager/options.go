@@ -0,0 +1,36 @@
+package contextmanager
+
+import (
+	"net/http"
+	"strings"
+)
+
+type Option func(*GinContextManager)
+
+// CookieDomain sets domain of the auth cookies, empty domain limits them to the host of the API.
+func CookieDomain(d string) Option {
+	return func(m *GinContextManager) {
+		m.domain = d
+	}
+}
+
+// CookieSecure sets the secure flag of the auth cookies, it is disabled for local HTTP development.
+func CookieSecure(s bool) Option {
+	return func(m *GinContextManager) {
+		m.secure = s
+	}
+}
+
+// CookieSameSite sets SameSite of the auth cookies: lax, strict or none, unknown values fall back to lax.
+func CookieSameSite(s string) Option {
+	return func(m *GinContextManager) {
+		switch strings.ToLower(s) {
+		case "strict":
+			m.sameSite = http.SameSiteStrictMode
+		case "none":
+			m.sameSite = http.SameSiteNoneMode
+		default:
+			m.sameSite = http.SameSiteLaxMode
+		}
+	}
+}
@@ -0,0 +1,164 @@
+// Package health checks availability of the application dependencies and describes the running build.
+package health
+
+import (
+	"context"
+	"runtime/debug"
+	"sync"
+	"task-trail/internal/pkg/logger"
+	"time"
+)
+
+// Commit is the build commit, set with -ldflags "-X task-trail/internal/pkg/health.Commit=<sha>".
+// VCS revision embedded by the go tool is used when it is empty.
+var Commit string
+
+type Status string
+
+const (
+	StatusUp   Status = "up"
+	StatusDown Status = "down"
+)
+
+// Checker reports whether the dependency is available.
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+// CheckerFunc adapts the function to Checker.
+type CheckerFunc func(ctx context.Context) error
+
+func (f CheckerFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+// MigrationSource reports version of the applied database migrations.
+type MigrationSource interface {
+	MigrationVersion(ctx context.Context) (version int, dirty bool, err error)
+}
+
+type Result struct {
+	Name     string
+	Status   Status
+	Error    string
+	Duration time.Duration
+}
+
+// Report is the result of all checks, it is up only when all dependencies are up.
+type Report struct {
+	Status Status
+	Checks []Result
+}
+
+type BuildInfo struct {
+	Commit           string
+	GoVersion        string
+	MigrationVersion int
+	MigrationDirty   bool
+}
+
+type check struct {
+	name    string
+	checker Checker
+}
+
+type Service struct {
+	timeout    time.Duration
+	migrations MigrationSource
+	logger     logger.Logger
+	checks     []check
+}
+
+// New creates service running every check with the timeout, failed checks are logged.
+func New(timeout time.Duration, migrations MigrationSource, l logger.Logger) *Service {
+	return &Service{timeout: timeout, migrations: migrations, logger: l}
+}
+
+// Add registers the dependency checked by Check.
+func (s *Service) Add(name string, c Checker) {
+	s.checks = append(s.checks, check{name: name, checker: c})
+}
+
+// Check runs all checks concurrently, results are in the order of registration.
+func (s *Service) Check(ctx context.Context) *Report {
+	report := &Report{Status: StatusUp, Checks: make([]Result, len(s.checks))}
+	var wg sync.WaitGroup
+	for i, c := range s.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report.Checks[i] = s.run(ctx, c)
+		}()
+	}
+	wg.Wait()
+	for _, r := range report.Checks {
+		if r.Status != StatusUp {
+			report.Status = StatusDown
+		}
+	}
+	return report
+}
+
+// BuildInfo describes the running build, migration version is zero when it cannot be read.
+func (s *Service) BuildInfo(ctx context.Context) (*BuildInfo, error) {
+	info := &BuildInfo{Commit: Commit}
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		info.GoVersion = bi.GoVersion
+		if info.Commit == "" {
+			for _, setting := range bi.Settings {
+				if setting.Key == "vcs.revision" {
+					info.Commit = setting.Value
+				}
+			}
+		}
+	}
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	version, dirty, err := s.migrations.MigrationVersion(ctx)
+	if err != nil {
+		return info, err
+	}
+	info.MigrationVersion = version
+	info.MigrationDirty = dirty
+	return info, nil
+}
+
+func (s *Service) run(ctx context.Context, c check) Result {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	start := time.Now()
+	err := c.checker.Check(ctx)
+	res := Result{Name: c.name, Status: StatusUp, Duration: time.Since(start)}
+	if err != nil {
+		res.Status = StatusDown
+		res.Error = err.Error()
+		s.logger.WarnContext(ctx, "dependency check failed", "dependency", c.name, "error", res.Error, "duration", res.Duration)
+	}
+	return res
+}
+
+// Cache returns checker reusing the result of c for ttl,
+// it suits checks too expensive to run on every probe, e.g. opening an SMTP connection.
+func Cache(c Checker, ttl time.Duration) Checker {
+	return &cachedChecker{checker: c, ttl: ttl}
+}
+
+type cachedChecker struct {
+	checker Checker
+	ttl     time.Duration
+
+	mu        sync.Mutex
+	checkedAt time.Time
+	err       error
+}
+
+func (c *cachedChecker) Check(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.checkedAt.IsZero() && time.Since(c.checkedAt) < c.ttl {
+		return c.err
+	}
+	c.err = c.checker.Check(ctx)
+	c.checkedAt = time.Now()
+	return c.err
+}
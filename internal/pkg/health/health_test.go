@@ -0,0 +1,96 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"task-trail/internal/pkg/health"
+	"task-trail/test/mocks"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestServiceCheck(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	l := mocks.NewMockLogger(ctrl)
+	s := health.New(time.Second, nil, l)
+	s.Add("postgres", health.CheckerFunc(func(context.Context) error { return nil }))
+	s.Add("smtp", health.CheckerFunc(func(context.Context) error { return errors.New("dial tcp: connection refused") }))
+	l.EXPECT().WarnContext(gomock.Any(), "dependency check failed", gomock.Any()).Times(1)
+
+	report := s.Check(context.Background())
+	if report.Status != health.StatusDown {
+		t.Errorf("status = %s, want %s", report.Status, health.StatusDown)
+	}
+	if len(report.Checks) != 2 || report.Checks[0].Status != health.StatusUp || report.Checks[1].Status != health.StatusDown {
+		t.Errorf("unexpected checks: %+v", report.Checks)
+	}
+}
+
+func TestServiceCheckResults(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	l := mocks.NewMockLogger(ctrl)
+	const timeout = 50 * time.Millisecond
+	s := health.New(timeout, nil, l)
+	s.Add("postgres", health.CheckerFunc(func(context.Context) error { return nil }))
+	// check ignoring the timeout is reported by the error of its context
+	s.Add("s3", health.CheckerFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}))
+	l.EXPECT().WarnContext(gomock.Any(), "dependency check failed", gomock.Any()).Times(1)
+
+	report := s.Check(context.Background())
+	want := []struct {
+		name   string
+		status health.Status
+		err    string
+	}{
+		{name: "postgres", status: health.StatusUp},
+		{name: "s3", status: health.StatusDown, err: context.DeadlineExceeded.Error()},
+	}
+	if len(report.Checks) != len(want) {
+		t.Fatalf("checks = %+v, want %d", report.Checks, len(want))
+	}
+	for i, w := range want {
+		got := report.Checks[i]
+		if got.Name != w.name || got.Status != w.status || got.Error != w.err {
+			t.Errorf("check %d = %+v, want %+v", i, got, w)
+		}
+	}
+	if d := report.Checks[1].Duration; d < timeout || d > timeout*10 {
+		t.Errorf("duration of timed out check = %s, want about %s", d, timeout)
+	}
+}
+
+func TestCache(t *testing.T) {
+	calls := 0
+	checkErr := errors.New("down")
+	c := health.Cache(health.CheckerFunc(func(context.Context) error {
+		calls++
+		if calls == 1 {
+			return checkErr
+		}
+		return nil
+	}), 50*time.Millisecond)
+
+	for range 3 {
+		if err := c.Check(context.Background()); !errors.Is(err, checkErr) {
+			t.Errorf("cached error = %v, want %v", err, checkErr)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1", calls)
+	}
+	time.Sleep(60 * time.Millisecond)
+	if err := c.Check(context.Background()); err != nil {
+		t.Errorf("error after ttl = %v, want nil", err)
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+}
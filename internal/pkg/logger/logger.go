@@ -1,8 +1,17 @@
 package logger
 
+import "context"
+
 type Logger interface {
 	Debug(msg string, args ...any)
 	Info(msg string, args ...any)
 	Warn(msg string, args ...any)
 	Error(msg string, args ...any)
+	// context variants add request id, user id and trace id carried by ctx to the record
+	DebugContext(ctx context.Context, msg string, args ...any)
+	InfoContext(ctx context.Context, msg string, args ...any)
+	WarnContext(ctx context.Context, msg string, args ...any)
+	ErrorContext(ctx context.Context, msg string, args ...any)
+	// With returns logger adding args to every record
+	With(args ...any) Logger
 }
@@ -0,0 +1,127 @@
+package slogger
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"task-trail/internal/pkg/contextmanager"
+	"task-trail/internal/pkg/tracing"
+	"time"
+)
+
+// levelHandler drops records below the level of the component.
+type levelHandler struct {
+	next  slog.Handler
+	level slog.Level
+}
+
+func (h *levelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.level
+}
+
+func (h *levelHandler) Handle(ctx context.Context, r slog.Record) error {
+	return h.next.Handle(ctx, r)
+}
+
+func (h *levelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &levelHandler{next: h.next.WithAttrs(attrs), level: h.level}
+}
+
+func (h *levelHandler) WithGroup(name string) slog.Handler {
+	return &levelHandler{next: h.next.WithGroup(name), level: h.level}
+}
+
+// contextHandler adds ids of the request, user and trace carried by ctx.
+type contextHandler struct {
+	next slog.Handler
+}
+
+func (h *contextHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := contextmanager.RequestIDFromContext(ctx); id != "" {
+		r.AddAttrs(slog.String("requestID", id))
+	}
+	if id, ok := contextmanager.UserIDFromContext(ctx); ok {
+		r.AddAttrs(slog.Int("userID", id))
+	}
+	if id := tracing.TraceID(ctx); id != "" {
+		r.AddAttrs(slog.String("traceID", id))
+	}
+	return h.next.Handle(ctx, r)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{next: h.next.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{next: h.next.WithGroup(name)}
+}
+
+// samplingHandler limits identical debug and info records, so noisy messages don't flood the output.
+type samplingHandler struct {
+	next    slog.Handler
+	sampler *sampler
+}
+
+func (h *samplingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *samplingHandler) Handle(ctx context.Context, r slog.Record) error {
+	if !h.sampler.allow(r.Level, r.Message) {
+		return nil
+	}
+	return h.next.Handle(ctx, r)
+}
+
+func (h *samplingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &samplingHandler{next: h.next.WithAttrs(attrs), sampler: h.sampler}
+}
+
+func (h *samplingHandler) WithGroup(name string) slog.Handler {
+	return &samplingHandler{next: h.next.WithGroup(name), sampler: h.sampler}
+}
+
+type sampleKey struct {
+	level slog.Level
+	msg   string
+}
+
+// sampler counts records by level and message, counters are reset every tick.
+type sampler struct {
+	initial    int
+	thereafter int
+	tick       time.Duration
+
+	mu      sync.Mutex
+	resetAt time.Time
+	counts  map[sampleKey]int
+}
+
+func newSampler(initial int, thereafter int, tick time.Duration) *sampler {
+	return &sampler{initial: initial, thereafter: thereafter, tick: tick, counts: make(map[sampleKey]int)}
+}
+
+func (s *sampler) allow(level slog.Level, msg string) bool {
+	if level > slog.LevelInfo {
+		return true
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	if now.After(s.resetAt) {
+		clear(s.counts)
+		s.resetAt = now.Add(s.tick)
+	}
+	k := sampleKey{level: level, msg: msg}
+	s.counts[k]++
+	n := s.counts[k]
+	if n <= s.initial {
+		return true
+	}
+	return s.thereafter > 0 && (n-s.initial)%s.thereafter == 0
+}
@@ -0,0 +1,46 @@
+package slogger
+
+import (
+	"io"
+	"time"
+)
+
+type Option func(*settings)
+
+// Format sets output format: json or pretty colored output for local development.
+func Format(f string) Option {
+	return func(s *settings) {
+		s.format = f
+	}
+}
+
+// Output sets destination of json records, stderr by default.
+func Output(w io.Writer) Option {
+	return func(s *settings) {
+		s.output = w
+	}
+}
+
+// Level sets minimal level of records: debug, info, warn or error.
+func Level(l string) Option {
+	return func(s *settings) {
+		s.level = l
+	}
+}
+
+// ComponentLevels sets minimal levels of component loggers overriding the common one.
+func ComponentLevels(levels map[string]string) Option {
+	return func(s *settings) {
+		s.componentLevels = levels
+	}
+}
+
+// Sampling logs first initial identical debug and info records per tick and then every thereafter-th one,
+// warnings and errors are never sampled. Zero initial disables sampling.
+func Sampling(initial int, thereafter int, tick time.Duration) Option {
+	return func(s *settings) {
+		s.sampleInitial = initial
+		s.sampleThereafter = thereafter
+		s.sampleTick = tick
+	}
+}
@@ -1,38 +1,195 @@
 package slogger
 
 import (
+	"context"
+	"fmt"
+	"io"
 	"log"
 	"log/slog"
 	"os"
 	"path"
+	"runtime"
 	"strings"
+	"task-trail/internal/pkg/logger"
+	"time"
 )
 
-func New(debug bool, source bool) *slog.Logger {
-	var handler slog.Handler
-	attrs := func(groups []string, a slog.Attr) slog.Attr {
-		if a.Key == slog.SourceKey && source {
-			s := a.Value.Any().(*slog.Source)
-			p := strings.Split(s.Function, ".")
-			s.File = p[0] + "/" + path.Base(s.File)
-			s.Function = strings.Join(p[1:], ".")
-		}
-		return a
+// output formats
+const (
+	FormatJSON   = "json"
+	FormatPretty = "pretty"
+)
+
+const (
+	_defSampleThereafter = 100
+	_defSampleTick       = time.Second
+)
+
+type settings struct {
+	format           string
+	output           io.Writer
+	level            string
+	componentLevels  map[string]string
+	sampleInitial    int
+	sampleThereafter int
+	sampleTick       time.Duration
+}
+
+// Logger writes structured records with slog, records of context variants get request, user and trace ids.
+type Logger struct {
+	l               *slog.Logger
+	source          bool
+	level           slog.Level
+	componentLevels map[string]slog.Level
+}
+
+func New(opts ...Option) (*Logger, error) {
+	s := &settings{
+		format:           FormatJSON,
+		output:           os.Stderr,
+		level:            slog.LevelInfo.String(),
+		sampleThereafter: _defSampleThereafter,
+		sampleTick:       _defSampleTick,
 	}
-	options := &slog.HandlerOptions{Level: slog.LevelDebug, ReplaceAttr: attrs}
-	if source {
-		options.AddSource = true
+	for _, opt := range opts {
+		opt(s)
+	}
+	level, err := parseLevel(s.level)
+	if err != nil {
+		return nil, err
+	}
+	componentLevels := make(map[string]slog.Level, len(s.componentLevels))
+	for name, l := range s.componentLevels {
+		componentLevels[name], err = parseLevel(l)
+		if err != nil {
+			return nil, fmt.Errorf("component %s: %w", name, err)
+		}
 	}
-	if !debug {
 
-		options.Level = slog.LevelInfo
-		handler = slog.NewJSONHandler(os.Stderr, options)
-	} else {
+	// level is checked by levelHandler, so the output handler accepts everything
+	options := &slog.HandlerOptions{Level: slog.LevelDebug, AddSource: true, ReplaceAttr: replaceSource}
+	var handler slog.Handler
+	switch s.format {
+	case FormatJSON:
+		handler = slog.NewJSONHandler(s.output, options)
+	case FormatPretty:
 		handler = NewHandler(options)
+	default:
+		return nil, fmt.Errorf("unknown log format: %s", s.format)
+	}
+	handler = &contextHandler{next: handler}
+	if s.sampleInitial > 0 {
+		handler = &samplingHandler{next: handler, sampler: newSampler(s.sampleInitial, s.sampleThereafter, s.sampleTick)}
 	}
 
-	kek := slog.New(handler)
-	slog.SetDefault(kek)
+	l := &Logger{
+		l:               slog.New(&levelHandler{next: handler, level: level}),
+		source:          true,
+		level:           level,
+		componentLevels: componentLevels,
+	}
+	slog.SetDefault(l.l)
 	log.SetFlags(log.Lshortfile)
-	return kek
+	return l, nil
+}
+
+// Component returns logger of the application part, its records have the component attribute
+// and the level configured for the component. Attributes added by With are kept.
+func (l *Logger) Component(name string) *Logger {
+	level, ok := l.componentLevels[name]
+	if !ok {
+		level = l.level
+	}
+	next := l.l.Handler()
+	if h, ok := next.(*levelHandler); ok {
+		next = h.next
+	}
+	c := *l
+	c.l = slog.New(&levelHandler{next: next, level: level}).With("component", name)
+	return &c
+}
+
+// WithoutSource returns logger omitting the caller, it is used by middlewares logging from the same line.
+func (l *Logger) WithoutSource() *Logger {
+	c := *l
+	c.source = false
+	return &c
+}
+
+func (l *Logger) With(args ...any) logger.Logger {
+	c := *l
+	c.l = l.l.With(args...)
+	return &c
+}
+
+func (l *Logger) Debug(msg string, args ...any) {
+	l.log(context.Background(), slog.LevelDebug, msg, args...)
+}
+
+func (l *Logger) Info(msg string, args ...any) {
+	l.log(context.Background(), slog.LevelInfo, msg, args...)
+}
+
+func (l *Logger) Warn(msg string, args ...any) {
+	l.log(context.Background(), slog.LevelWarn, msg, args...)
+}
+
+func (l *Logger) Error(msg string, args ...any) {
+	l.log(context.Background(), slog.LevelError, msg, args...)
+}
+
+func (l *Logger) DebugContext(ctx context.Context, msg string, args ...any) {
+	l.log(ctx, slog.LevelDebug, msg, args...)
+}
+
+func (l *Logger) InfoContext(ctx context.Context, msg string, args ...any) {
+	l.log(ctx, slog.LevelInfo, msg, args...)
+}
+
+func (l *Logger) WarnContext(ctx context.Context, msg string, args ...any) {
+	l.log(ctx, slog.LevelWarn, msg, args...)
+}
+
+func (l *Logger) ErrorContext(ctx context.Context, msg string, args ...any) {
+	l.log(ctx, slog.LevelError, msg, args...)
+}
+
+// log builds the record itself, so the source points to the caller of the logger instead of this file.
+func (l *Logger) log(ctx context.Context, level slog.Level, msg string, args ...any) {
+	if !l.l.Enabled(ctx, level) {
+		return
+	}
+	var pc uintptr
+	if l.source {
+		var pcs [1]uintptr
+		// skip runtime.Callers, log and the exported method
+		runtime.Callers(3, pcs[:])
+		pc = pcs[0]
+	}
+	r := slog.NewRecord(time.Now(), level, msg, pc)
+	r.Add(args...)
+	_ = l.l.Handler().Handle(ctx, r)
+}
+
+// replaceSource shortens the source of the record and drops it when the caller is unknown.
+func replaceSource(groups []string, a slog.Attr) slog.Attr {
+	if a.Key != slog.SourceKey {
+		return a
+	}
+	s, ok := a.Value.Any().(*slog.Source)
+	if !ok || s == nil || s.Function == "" {
+		return slog.Attr{}
+	}
+	p := strings.Split(s.Function, ".")
+	s.File = p[0] + "/" + path.Base(s.File)
+	s.Function = strings.Join(p[1:], ".")
+	return a
+}
+
+func parseLevel(s string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(s)); err != nil {
+		return level, fmt.Errorf("unknown log level: %s", s)
+	}
+	return level, nil
 }
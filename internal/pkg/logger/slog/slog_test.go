@@ -0,0 +1,165 @@
+package slogger_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"task-trail/internal/pkg/contextmanager"
+	slogger "task-trail/internal/pkg/logger/slog"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// newLogger returns logger writing json records to the buffer.
+func newLogger(t *testing.T, opts ...slogger.Option) (*slogger.Logger, *bytes.Buffer) {
+	t.Helper()
+	b := &bytes.Buffer{}
+	l, err := slogger.New(append([]slogger.Option{slogger.Output(b)}, opts...)...)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return l, b
+}
+
+// records decodes json records written to the buffer.
+func records(t *testing.T, b *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var retVal []map[string]any
+	dec := json.NewDecoder(b)
+	for dec.More() {
+		var r map[string]any
+		if err := dec.Decode(&r); err != nil {
+			t.Fatalf("failed to decode record: %v", err)
+		}
+		retVal = append(retVal, r)
+	}
+	return retVal
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []slogger.Option
+	}{
+		{name: "unknown format", opts: []slogger.Option{slogger.Format("xml")}},
+		{name: "unknown level", opts: []slogger.Option{slogger.Level("verbose")}},
+		{name: "unknown component level", opts: []slogger.Option{slogger.ComponentLevels(map[string]string{"http": "loud"})}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := slogger.New(tt.opts...); err == nil {
+				t.Errorf("expected error but got nil")
+			}
+		})
+	}
+}
+
+func TestComponentLevels(t *testing.T) {
+	l, b := newLogger(t, slogger.Level("info"), slogger.ComponentLevels(map[string]string{"postgres": "warn", "http": "debug"}))
+
+	l.Debug("root debug")
+	l.Info("root info")
+	pg := l.Component("postgres")
+	pg.Info("postgres info")
+	pg.Warn("postgres warn")
+	l.Component("http").Debug("http debug")
+	l.Component("tasks").Debug("tasks debug")
+	l.Component("tasks").Info("tasks info")
+
+	var got []string
+	for _, r := range records(t, b) {
+		got = append(got, r["msg"].(string))
+	}
+	want := []string{"root info", "postgres warn", "http debug", "tasks info"}
+	if len(got) != len(want) {
+		t.Fatalf("got = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("got = %v, want %v", got, want)
+			break
+		}
+	}
+}
+
+func TestComponentKeepsAttrs(t *testing.T) {
+	l, b := newLogger(t, slogger.ComponentLevels(map[string]string{"tasks": "debug"}))
+
+	withJob := l.With("job", "cleanup").(*slogger.Logger)
+	withJob.Component("tasks").Debug("started")
+
+	rs := records(t, b)
+	if len(rs) != 1 {
+		t.Fatalf("got %d records, want 1", len(rs))
+	}
+	if rs[0]["job"] != "cleanup" || rs[0]["component"] != "tasks" {
+		t.Errorf("unexpected record: %v", rs[0])
+	}
+}
+
+func TestSampling(t *testing.T) {
+	const tick = 100 * time.Millisecond
+	l, b := newLogger(t, slogger.Sampling(2, 3, tick))
+
+	// first 2 records are logged, then every 3rd one: 1, 2, 5, 8
+	for range 8 {
+		l.Info("noisy")
+	}
+	for range 3 {
+		l.Warn("never sampled")
+	}
+	l.Info("other message")
+	counts := map[string]int{}
+	for _, r := range records(t, b) {
+		counts[r["msg"].(string)]++
+	}
+	want := map[string]int{"noisy": 4, "never sampled": 3, "other message": 1}
+	for msg, n := range want {
+		if counts[msg] != n {
+			t.Errorf("%q logged %d times, want %d", msg, counts[msg], n)
+		}
+	}
+
+	// counters are reset every tick
+	time.Sleep(tick + 50*time.Millisecond)
+	for range 3 {
+		l.Info("noisy")
+	}
+	if n := len(records(t, b)); n != 2 {
+		t.Errorf("got %d records after reset, want 2", n)
+	}
+}
+
+func TestContextAttrs(t *testing.T) {
+	l, b := newLogger(t)
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("GET", "/", nil)
+	contextmanager.NewGin(nil, "csrf").SetUserID(c, 7)
+	ctx := contextmanager.WithRequestID(c.Request.Context(), "req-1")
+	traceID := trace.TraceID{1, 2, 3}
+	ctx = trace.ContextWithSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  trace.SpanID{1},
+	}))
+
+	l.InfoContext(ctx, "with ids")
+	l.InfoContext(context.Background(), "without ids")
+
+	rs := records(t, b)
+	if len(rs) != 2 {
+		t.Fatalf("got %d records, want 2", len(rs))
+	}
+	if rs[0]["requestID"] != "req-1" || rs[0]["userID"] != float64(7) || rs[0]["traceID"] != traceID.String() {
+		t.Errorf("unexpected record: %v", rs[0])
+	}
+	for _, k := range []string{"requestID", "userID", "traceID"} {
+		if _, ok := rs[1][k]; ok {
+			t.Errorf("unexpected %s in record: %v", k, rs[1])
+		}
+	}
+}
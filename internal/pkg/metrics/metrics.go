@@ -0,0 +1,136 @@
+// Package metrics collects Prometheus metrics of http requests, database pool, background tasks and emails.
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"task-trail/internal/pkg/smtp"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "tasktrail"
+
+// task and email outcomes
+const (
+	StatusSuccess = "success"
+	StatusFailure = "failure"
+)
+
+type Metrics struct {
+	registry      *prometheus.Registry
+	httpRequests  *prometheus.CounterVec
+	httpDuration  *prometheus.HistogramVec
+	taskRuns      *prometheus.CounterVec
+	taskDuration  *prometheus.HistogramVec
+	emails        *prometheus.CounterVec
+	emailDuration *prometheus.HistogramVec
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "Number of handled http requests by route template and status.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of http requests by route template and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		taskRuns: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "task",
+			Name:      "runs_total",
+			Help:      "Number of background task runs by task and status.",
+		}, []string{"task", "status"}),
+		taskDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "task",
+			Name:      "run_duration_seconds",
+			Help:      "Duration of background task runs.",
+			Buckets:   []float64{0.01, 0.05, 0.1, 0.5, 1, 5, 10, 30, 60, 300},
+		}, []string{"task"}),
+		emails: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "email",
+			Name:      "sends_total",
+			Help:      "Number of sent and failed emails.",
+		}, []string{"status"}),
+		emailDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "email",
+			Name:      "send_duration_seconds",
+			Help:      "Duration of a send call, including immediate retries of the sender but not later outbox attempts.",
+			Buckets:   []float64{0.1, 0.5, 1, 2, 5, 10, 30, 60},
+		}, []string{"status"}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.taskRuns,
+		m.taskDuration,
+		m.emails,
+		m.emailDuration,
+	)
+	return m
+}
+
+// Handler serves collected metrics in the Prometheus format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+func (m *Metrics) ObserveRequest(method string, route string, status int, d time.Duration) {
+	code := strconv.Itoa(status)
+	m.httpRequests.WithLabelValues(method, route, code).Inc()
+	m.httpDuration.WithLabelValues(method, route, code).Observe(d.Seconds())
+}
+
+func (m *Metrics) ObserveTask(name string, err error, d time.Duration) {
+	m.taskRuns.WithLabelValues(name, status(err)).Inc()
+	m.taskDuration.WithLabelValues(name).Observe(d.Seconds())
+}
+
+// RegisterPool exposes statistics of the database pool.
+func (m *Metrics) RegisterPool(pool *pgxpool.Pool) {
+	m.registry.MustRegister(newPoolCollector(pool))
+}
+
+// Sender wraps the sender counting sent and failed emails.
+func (m *Metrics) Sender(s smtp.Sender) smtp.Sender {
+	return &sender{next: s, metrics: m}
+}
+
+type sender struct {
+	next    smtp.Sender
+	metrics *Metrics
+}
+
+func (s *sender) Send(ctx context.Context, msg smtp.Message, eventID string) error {
+	start := time.Now()
+	err := s.next.Send(ctx, msg, eventID)
+	st := status(err)
+	s.metrics.emails.WithLabelValues(st).Inc()
+	s.metrics.emailDuration.WithLabelValues(st).Observe(time.Since(start).Seconds())
+	return err
+}
+
+func status(err error) string {
+	if err != nil {
+		return StatusFailure
+	}
+	return StatusSuccess
+}
@@ -0,0 +1,132 @@
+package argon2id
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"task-trail/internal/pkg/password"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	_defMemory      = 64 * 1024
+	_defIterations  = 3
+	_defParallelism = 2
+	_saltLength     = 16
+	_keyLength      = 32
+	// hashes are stored in the PHC string format:
+	// $argon2id$v=19$m=65536,t=3,p=2$<salt>$<key>
+	_prefix = "$argon2id$"
+)
+
+var (
+	ErrMismatch      = errors.New("password does not match hash")
+	ErrInvalidHash   = errors.New("invalid argon2id hash")
+	ErrIncompatible  = errors.New("incompatible argon2 version")
+	ErrUnknownFormat = errors.New("unknown hash format")
+)
+
+type argon2idService struct {
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+	legacy      password.Service
+}
+
+type params struct {
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+	salt        []byte
+	key         []byte
+}
+
+func New(opts ...Option) (password.Service, error) {
+	s := &argon2idService{
+		memory:      _defMemory,
+		iterations:  _defIterations,
+		parallelism: _defParallelism,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.memory < 8*uint32(s.parallelism) || s.iterations < 1 || s.parallelism < 1 {
+		return nil, fmt.Errorf("invalid argon2id parameters: m=%d, t=%d, p=%d", s.memory, s.iterations, s.parallelism)
+	}
+	return s, nil
+}
+
+func (s *argon2idService) ComparePassword(password string, hash string) error {
+	if !strings.HasPrefix(hash, _prefix) {
+		if s.legacy == nil {
+			return ErrUnknownFormat
+		}
+		return s.legacy.ComparePassword(password, hash)
+	}
+	p, err := decode(hash)
+	if err != nil {
+		return err
+	}
+	key := argon2.IDKey([]byte(password), p.salt, p.iterations, p.memory, p.parallelism, uint32(len(p.key)))
+	if subtle.ConstantTimeCompare(key, p.key) != 1 {
+		return ErrMismatch
+	}
+	return nil
+}
+
+func (s *argon2idService) HashPassword(password string) (string, error) {
+	salt := make([]byte, _saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+	key := argon2.IDKey([]byte(password), salt, s.iterations, s.memory, s.parallelism, _keyLength)
+	return fmt.Sprintf(
+		"%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		_prefix,
+		argon2.Version,
+		s.memory,
+		s.iterations,
+		s.parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func (s *argon2idService) NeedsRehash(hash string) bool {
+	p, err := decode(hash)
+	if err != nil {
+		return true
+	}
+	return p.memory != s.memory || p.iterations != s.iterations || p.parallelism != s.parallelism || len(p.key) != _keyLength
+}
+
+func decode(hash string) (*params, error) {
+	parts := strings.Split(hash, "$")
+	// "", "argon2id", "v=19", "m=65536,t=3,p=2", salt, key
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return nil, ErrInvalidHash
+	}
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return nil, ErrInvalidHash
+	}
+	if version != argon2.Version {
+		return nil, ErrIncompatible
+	}
+	p := &params{}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.memory, &p.iterations, &p.parallelism); err != nil {
+		return nil, ErrInvalidHash
+	}
+	var err error
+	if p.salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return nil, ErrInvalidHash
+	}
+	if p.key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil || len(p.key) == 0 {
+		return nil, ErrInvalidHash
+	}
+	return p, nil
+}
@@ -0,0 +1,153 @@
+package argon2id_test
+
+import (
+	"errors"
+	"strings"
+	"task-trail/internal/pkg/password"
+	"task-trail/internal/pkg/password/argon2id"
+	"task-trail/internal/pkg/password/bcrypt"
+	"testing"
+)
+
+const testPwd = "Tr4il-Mix-Kettle"
+
+// cheap parameters keep the tests fast, the algorithm is the same
+var testOpts = []argon2id.Option{argon2id.Memory(64), argon2id.Iterations(1), argon2id.Parallelism(1)}
+
+func newService(t *testing.T, opts ...argon2id.Option) password.Service {
+	t.Helper()
+	s, err := argon2id.New(append(append([]argon2id.Option{}, testOpts...), opts...)...)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return s
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []argon2id.Option
+		wantErr bool
+	}{
+		{name: "defaults"},
+		{name: "zero iterations", opts: []argon2id.Option{argon2id.Iterations(0)}, wantErr: true},
+		{name: "zero parallelism", opts: []argon2id.Option{argon2id.Parallelism(0)}, wantErr: true},
+		{name: "memory below 8 KiB per thread", opts: []argon2id.Option{argon2id.Memory(15), argon2id.Parallelism(2)}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := argon2id.New(tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	s := newService(t)
+	hash, err := s.HashPassword(testPwd)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "$argon2id$v=19$m=64,t=1,p=1$"; !strings.HasPrefix(hash, want) {
+		t.Errorf("hash = %q, want prefix %q", hash, want)
+	}
+	if err := s.ComparePassword(testPwd, hash); err != nil {
+		t.Errorf("ComparePassword() with valid password error = %v", err)
+	}
+	if err := s.ComparePassword(testPwd+"x", hash); !errors.Is(err, argon2id.ErrMismatch) {
+		t.Errorf("ComparePassword() with invalid password error = %v, want %v", err, argon2id.ErrMismatch)
+	}
+	other, err := s.HashPassword(testPwd)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if other == hash {
+		t.Errorf("hashes of the same password must differ by salt")
+	}
+	if s.NeedsRehash(hash) {
+		t.Errorf("NeedsRehash() = true for hash created with current parameters")
+	}
+}
+
+func TestComparePasswordMalformed(t *testing.T) {
+	s := newService(t)
+	tests := []struct {
+		name string
+		hash string
+		want error
+	}{
+		{name: "not a phc string", hash: "plain", want: argon2id.ErrUnknownFormat},
+		{name: "missing parts", hash: "$argon2id$v=19$m=64,t=1,p=1$c2FsdHNhbHQ", want: argon2id.ErrInvalidHash},
+		{name: "bad version", hash: "$argon2id$v=x$m=64,t=1,p=1$c2FsdHNhbHQ$a2V5", want: argon2id.ErrInvalidHash},
+		{name: "other version", hash: "$argon2id$v=16$m=64,t=1,p=1$c2FsdHNhbHQ$a2V5", want: argon2id.ErrIncompatible},
+		{name: "bad params", hash: "$argon2id$v=19$m=64;t=1;p=1$c2FsdHNhbHQ$a2V5", want: argon2id.ErrInvalidHash},
+		{name: "bad salt", hash: "$argon2id$v=19$m=64,t=1,p=1$!!!$a2V5", want: argon2id.ErrInvalidHash},
+		{name: "bad key", hash: "$argon2id$v=19$m=64,t=1,p=1$c2FsdHNhbHQ$!!!", want: argon2id.ErrInvalidHash},
+		{name: "empty key", hash: "$argon2id$v=19$m=64,t=1,p=1$c2FsdHNhbHQ$", want: argon2id.ErrInvalidHash},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := s.ComparePassword(testPwd, tt.hash); !errors.Is(err, tt.want) {
+				t.Errorf("ComparePassword() error = %v, want %v", err, tt.want)
+			}
+			if !s.NeedsRehash(tt.hash) {
+				t.Errorf("NeedsRehash() = false for malformed hash")
+			}
+		})
+	}
+}
+
+func TestNeedsRehash(t *testing.T) {
+	hash, err := newService(t).HashPassword(testPwd)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tests := []struct {
+		name string
+		opts []argon2id.Option
+		want bool
+	}{
+		{name: "same parameters", want: false},
+		{name: "memory changed", opts: []argon2id.Option{argon2id.Memory(128)}, want: true},
+		{name: "iterations changed", opts: []argon2id.Option{argon2id.Iterations(2)}, want: true},
+		{name: "parallelism changed", opts: []argon2id.Option{argon2id.Parallelism(2)}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newService(t, tt.opts...)
+			if got := s.NeedsRehash(hash); got != tt.want {
+				t.Errorf("NeedsRehash() = %v, want %v", got, tt.want)
+			}
+			// hash created with outdated parameters must still be accepted
+			if err := s.ComparePassword(testPwd, hash); err != nil {
+				t.Errorf("ComparePassword() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestLegacyFallback(t *testing.T) {
+	legacy := bcrypt.New()
+	hash, err := legacy.HashPassword(testPwd)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	s := newService(t, argon2id.Legacy(legacy))
+	if err := s.ComparePassword(testPwd, hash); err != nil {
+		t.Errorf("ComparePassword() with valid password error = %v", err)
+	}
+	if err := s.ComparePassword(testPwd+"x", hash); err == nil {
+		t.Errorf("ComparePassword() with invalid password error = nil")
+	}
+	if !s.NeedsRehash(hash) {
+		t.Errorf("NeedsRehash() = false for bcrypt hash")
+	}
+
+	withoutLegacy := newService(t)
+	if err := withoutLegacy.ComparePassword(testPwd, hash); !errors.Is(err, argon2id.ErrUnknownFormat) {
+		t.Errorf("ComparePassword() without legacy service error = %v, want %v", err, argon2id.ErrUnknownFormat)
+	}
+}
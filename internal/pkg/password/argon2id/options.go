@@ -0,0 +1,34 @@
+package argon2id
+
+import "task-trail/internal/pkg/password"
+
+type Option func(*argon2idService)
+
+// Memory sets amount of memory used by the algorithm in KiB.
+func Memory(m uint32) Option {
+	return func(s *argon2idService) {
+		s.memory = m
+	}
+}
+
+// Iterations sets number of passes over the memory.
+func Iterations(i uint32) Option {
+	return func(s *argon2idService) {
+		s.iterations = i
+	}
+}
+
+// Parallelism sets number of threads used by the algorithm.
+func Parallelism(p uint8) Option {
+	return func(s *argon2idService) {
+		s.parallelism = p
+	}
+}
+
+// Legacy sets service used to compare hashes created by another algorithm, e.g. bcrypt.
+// Such hashes are reported by NeedsRehash, so they can be upgraded after successful comparison.
+func Legacy(l password.Service) Option {
+	return func(s *argon2idService) {
+		s.legacy = l
+	}
+}
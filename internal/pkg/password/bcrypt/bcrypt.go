@@ -23,3 +23,8 @@ func (s *bcryptService) HashPassword(password string) (string, error) {
 	val, err := bcrypt.GenerateFromPassword([]byte(password), cost)
 	return string(val), err
 }
+
+func (s *bcryptService) NeedsRehash(hash string) bool {
+	c, err := bcrypt.Cost([]byte(hash))
+	return err != nil || c != cost
+}
@@ -1,6 +1,27 @@
 package password
 
+import "strings"
+
 type Service interface {
 	ComparePassword(password string, hash string) error
 	HashPassword(password string) (string, error)
+	// NeedsRehash reports whether hash was created by another algorithm or with outdated parameters
+	// and should be replaced with a new one.
+	NeedsRehash(hash string) bool
+}
+
+// Policy validates password strength.
+type Policy interface {
+	// Validate checks password against the policy, email is used to forbid passwords containing it.
+	// Returns *PolicyError if the password violates the policy.
+	Validate(password string, email string) error
+}
+
+// PolicyError contains all violated password policy rules.
+type PolicyError struct {
+	Violations []string
+}
+
+func (e *PolicyError) Error() string {
+	return strings.Join(e.Violations, ", ")
 }
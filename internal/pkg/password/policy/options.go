@@ -0,0 +1,60 @@
+package policy
+
+type Option func(*Policy)
+
+func MinLength(l int) Option {
+	return func(p *Policy) {
+		p.minLength = l
+	}
+}
+
+func MaxLength(l int) Option {
+	return func(p *Policy) {
+		p.maxLength = l
+	}
+}
+
+func RequireUpper(r bool) Option {
+	return func(p *Policy) {
+		p.requireUpper = r
+	}
+}
+
+func RequireLower(r bool) Option {
+	return func(p *Policy) {
+		p.requireLower = r
+	}
+}
+
+func RequireDigit(r bool) Option {
+	return func(p *Policy) {
+		p.requireDigit = r
+	}
+}
+
+func RequireSpecial(r bool) Option {
+	return func(p *Policy) {
+		p.requireSpecial = r
+	}
+}
+
+func DisallowEmail(d bool) Option {
+	return func(p *Policy) {
+		p.disallowEmail = d
+	}
+}
+
+// BreachedCheck enables check against the bundled list of breached passwords.
+func BreachedCheck(c bool) Option {
+	return func(p *Policy) {
+		p.breachedCheck = c
+	}
+}
+
+// BreachedListPath sets path to additional list of SHA-1 hashes of breached passwords.
+// Each line contains one upper case hex hash, optionally followed by ":count" as in the HIBP dumps.
+func BreachedListPath(path string) Option {
+	return func(p *Policy) {
+		p.breachedListPath = path
+	}
+}
@@ -0,0 +1,163 @@
+package policy
+
+import (
+	"bufio"
+	"crypto/sha1"
+	_ "embed"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"task-trail/internal/pkg/password"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	_defMinLength = 8
+	_defMaxLength = 50
+	// bcrypt fails on longer passwords, a symbol takes up to 4 bytes, so the limit in symbols is not enough
+	_maxBytes = 72
+	// local part of the email shorter than this is not checked, it gives too many false positives
+	_minEmailPartLength = 3
+)
+
+// breached.txt contains SHA-1 hashes of the most common leaked passwords
+//
+//go:embed breached.txt
+var bundledBreached string
+
+type Policy struct {
+	minLength        int
+	maxLength        int
+	requireUpper     bool
+	requireLower     bool
+	requireDigit     bool
+	requireSpecial   bool
+	disallowEmail    bool
+	breachedCheck    bool
+	breachedListPath string
+
+	breached map[string]struct{}
+}
+
+func New(opts ...Option) (*Policy, error) {
+	p := &Policy{
+		minLength:     _defMinLength,
+		maxLength:     _defMaxLength,
+		requireUpper:  true,
+		requireLower:  true,
+		requireDigit:  true,
+		disallowEmail: true,
+		breachedCheck: true,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	if p.minLength > p.maxLength {
+		return nil, fmt.Errorf("password min length %d is greater than max length %d", p.minLength, p.maxLength)
+	}
+	if !p.breachedCheck {
+		return p, nil
+	}
+	p.breached = make(map[string]struct{})
+	if err := p.loadBreached(strings.NewReader(bundledBreached)); err != nil {
+		return nil, fmt.Errorf("failed to load bundled breached passwords: %w", err)
+	}
+	if p.breachedListPath != "" {
+		f, err := os.Open(p.breachedListPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open breached passwords list: %w", err)
+		}
+		defer func() {
+			_ = f.Close()
+		}()
+		if err := p.loadBreached(f); err != nil {
+			return nil, fmt.Errorf("failed to load breached passwords list: %w", err)
+		}
+	}
+	return p, nil
+}
+
+func (p *Policy) Validate(pwd string, email string) error {
+	var violations []string
+	length := utf8.RuneCountInString(pwd)
+	if length < p.minLength {
+		violations = append(violations, fmt.Sprintf("min length: %d symbols", p.minLength))
+	}
+	if length > p.maxLength {
+		violations = append(violations, fmt.Sprintf("max length: %d symbols", p.maxLength))
+	} else if len(pwd) > _maxBytes {
+		violations = append(violations, fmt.Sprintf("max length: %d bytes", _maxBytes))
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSpecial bool
+	for _, r := range pwd {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			hasSpecial = true
+		}
+	}
+	if p.requireUpper && !hasUpper {
+		violations = append(violations, "must contain an uppercase letter")
+	}
+	if p.requireLower && !hasLower {
+		violations = append(violations, "must contain a lowercase letter")
+	}
+	if p.requireDigit && !hasDigit {
+		violations = append(violations, "must contain a digit")
+	}
+	if p.requireSpecial && !hasSpecial {
+		violations = append(violations, "must contain a special symbol")
+	}
+	if p.disallowEmail && containsEmail(pwd, email) {
+		violations = append(violations, "must not contain email")
+	}
+	if p.breachedCheck && p.isBreached(pwd) {
+		violations = append(violations, "password is too common and was found in data breaches")
+	}
+
+	if len(violations) > 0 {
+		return &password.PolicyError{Violations: violations}
+	}
+	return nil
+}
+
+func (p *Policy) isBreached(pwd string) bool {
+	sum := sha1.Sum([]byte(pwd))
+	_, ok := p.breached[strings.ToUpper(hex.EncodeToString(sum[:]))]
+	return ok
+}
+
+func (p *Policy) loadBreached(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		hash, _, _ := strings.Cut(line, ":")
+		p.breached[strings.ToUpper(hash)] = struct{}{}
+	}
+	return scanner.Err()
+}
+
+func containsEmail(pwd string, email string) bool {
+	if email == "" {
+		return false
+	}
+	pwd = strings.ToLower(pwd)
+	email = strings.ToLower(email)
+	if strings.Contains(pwd, email) {
+		return true
+	}
+	local, _, _ := strings.Cut(email, "@")
+	return len(local) >= _minEmailPartLength && strings.Contains(pwd, local)
+}
@@ -0,0 +1,198 @@
+package policy_test
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"task-trail/internal/pkg/password"
+	"task-trail/internal/pkg/password/policy"
+	"testing"
+)
+
+const testEmail = "john.doe@example.com"
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       []policy.Option
+		pwd        string
+		email      string
+		violations []string
+	}{
+		{
+			name: "valid password",
+			pwd:  "Tr4il-Mix-Kettle",
+		},
+		{
+			name:       "too short",
+			pwd:        "Ab1xyz",
+			violations: []string{"min length: 8 symbols"},
+		},
+		{
+			name:       "too long",
+			opts:       []policy.Option{policy.MaxLength(10)},
+			pwd:        "Tr4il-Mix-Kettle",
+			violations: []string{"max length: 10 symbols"},
+		},
+		{
+			name: "length is counted in symbols",
+			opts: []policy.Option{policy.MaxLength(10)},
+			pwd:  "Пароль1Ёжик",
+			violations: []string{
+				"max length: 10 symbols",
+			},
+		},
+		{
+			name:       "length is limited in bytes",
+			opts:       []policy.Option{policy.MaxLength(100)},
+			pwd:        strings.Repeat("Пароль1", 6),
+			violations: []string{"max length: 72 bytes"},
+		},
+		{
+			name: "configured lengths beyond defaults",
+			opts: []policy.Option{policy.MinLength(4), policy.MaxLength(100)},
+			pwd:  "Ab1z",
+		},
+		{
+			name:       "no uppercase letter",
+			pwd:        "tr4il-mix-kettle",
+			violations: []string{"must contain an uppercase letter"},
+		},
+		{
+			name:       "no lowercase letter",
+			pwd:        "TR4IL-MIX-KETTLE",
+			violations: []string{"must contain a lowercase letter"},
+		},
+		{
+			name:       "no digit",
+			pwd:        "Trail-Mix-Kettle",
+			violations: []string{"must contain a digit"},
+		},
+		{
+			name:       "no special symbol",
+			opts:       []policy.Option{policy.RequireSpecial(true)},
+			pwd:        "Tr4ilMixKettle",
+			violations: []string{"must contain a special symbol"},
+		},
+		{
+			name: "character rules disabled",
+			opts: []policy.Option{
+				policy.RequireUpper(false),
+				policy.RequireLower(false),
+				policy.RequireDigit(false),
+			},
+			pwd: "trail-mix-kettle",
+		},
+		{
+			name: "all violations are reported",
+			opts: []policy.Option{policy.RequireSpecial(true)},
+			pwd:  "abc",
+			violations: []string{
+				"min length: 8 symbols",
+				"must contain an uppercase letter",
+				"must contain a digit",
+				"must contain a special symbol",
+			},
+		},
+		{
+			name:       "contains email",
+			pwd:        "Xy1" + strings.ToUpper(testEmail),
+			email:      testEmail,
+			violations: []string{"must not contain email"},
+		},
+		{
+			name:       "contains local part of email",
+			pwd:        "Tr4il-John.Doe",
+			email:      testEmail,
+			violations: []string{"must not contain email"},
+		},
+		{
+			name:  "short local part of email is ignored",
+			pwd:   "Tr4il-Jo-Kettle",
+			email: "jo@example.com",
+		},
+		{
+			name:  "email check disabled",
+			opts:  []policy.Option{policy.DisallowEmail(false)},
+			pwd:   "Tr4il-John.Doe",
+			email: testEmail,
+		},
+		{
+			name:       "breached password",
+			pwd:        "Password123",
+			violations: []string{"password is too common and was found in data breaches"},
+		},
+		{
+			name: "breached check disabled",
+			opts: []policy.Option{policy.BreachedCheck(false)},
+			pwd:  "Password123",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := policy.New(tt.opts...)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			assertViolations(t, p.Validate(tt.pwd, tt.email), tt.violations)
+		})
+	}
+}
+
+func TestBreachedListPath(t *testing.T) {
+	pwd := "Tr4il-Mix-Kettle"
+	sum := sha1.Sum([]byte(pwd))
+	path := filepath.Join(t.TempDir(), "breached.txt")
+	list := "0000000000000000000000000000000000000000:3\n\n" + strings.ToLower(hex.EncodeToString(sum[:])) + ":12\n"
+	if err := os.WriteFile(path, []byte(list), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := policy.New(policy.BreachedListPath(path))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertViolations(t, p.Validate(pwd, ""), []string{"password is too common and was found in data breaches"})
+	// bundled list is still checked
+	assertViolations(t, p.Validate("Password123", ""), []string{"password is too common and was found in data breaches"})
+}
+
+func TestNew(t *testing.T) {
+	t.Run("min length greater than max length", func(t *testing.T) {
+		if _, err := policy.New(policy.MinLength(20), policy.MaxLength(10)); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+	t.Run("missing breached list", func(t *testing.T) {
+		if _, err := policy.New(policy.BreachedListPath(filepath.Join(t.TempDir(), "missing.txt"))); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+	t.Run("missing breached list is not read when check is disabled", func(t *testing.T) {
+		_, err := policy.New(policy.BreachedCheck(false), policy.BreachedListPath(filepath.Join(t.TempDir(), "missing.txt")))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
+
+func assertViolations(t *testing.T, err error, want []string) {
+	t.Helper()
+	if len(want) == 0 {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return
+	}
+	var policyErr *password.PolicyError
+	if !errors.As(err, &policyErr) {
+		t.Fatalf("expected *password.PolicyError, got %v", err)
+	}
+	if !reflect.DeepEqual(policyErr.Violations, want) {
+		t.Errorf("violations = %q, want %q", policyErr.Violations, want)
+	}
+}
@@ -1,6 +1,10 @@
 package postgres
 
-import "time"
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
 
 type Option func(*Postgres)
 
@@ -21,3 +25,10 @@ func ConnTimeout(t time.Duration) Option {
 		p.connTimeout = t
 	}
 }
+
+// Tracer sets tracer of every query executed by the pool.
+func Tracer(t pgx.QueryTracer) Option {
+	return func(p *Postgres) {
+		p.tracer = t
+	}
+}
@@ -2,9 +2,11 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"task-trail/internal/pkg/logger"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -18,6 +20,7 @@ type Postgres struct {
 	maxPoolSize  int
 	connAttempts int
 	connTimeout  time.Duration
+	tracer       pgx.QueryTracer
 
 	Pool *pgxpool.Pool
 }
@@ -38,6 +41,9 @@ func New(connString string, l logger.Logger, opts ...Option) (*Postgres, error)
 		return nil, err
 	}
 	config.MaxConns = int32(pg.maxPoolSize)
+	if pg.tracer != nil {
+		config.ConnConfig.Tracer = pg.tracer
+	}
 
 	for pg.connAttempts > 0 {
 		pg.Pool, err = pgxpool.NewWithConfig(context.Background(), config)
@@ -64,3 +70,22 @@ func (p *Postgres) Close() {
 		p.Pool.Close()
 	}
 }
+
+// Check pings the database, it is used by readiness probe.
+func (p *Postgres) Check(ctx context.Context) error {
+	return p.Pool.Ping(ctx)
+}
+
+// MigrationVersion returns version of the last applied migration, zero if migrations were never applied.
+func (p *Postgres) MigrationVersion(ctx context.Context) (int, bool, error) {
+	var version int
+	var dirty bool
+	err := p.Pool.QueryRow(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+	return version, dirty, nil
+}
@@ -0,0 +1,35 @@
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// event types streamed to project members
+const (
+	ProjectCreated      = "project.created"
+	ProjectMembersAdded = "project.members_added"
+	// WebhookTest is sent only to the webhook on user request
+	WebhookTest = "webhook.test"
+)
+
+// Event is the change in the project delivered to its members.
+type Event struct {
+	Type      string          `json:"type"`
+	ProjectID int             `json:"projectId"`
+	Data      json.RawMessage `json:"data,omitempty" swaggertype:"object"`
+}
+
+type Publisher interface {
+	// Publish delivers the event to subscribers of the event project.
+	Publish(ctx context.Context, event Event) error
+}
+
+type Broker interface {
+	Publisher
+	// Subscribe returns the channel receiving events of the given projects, events of all projects if none are given.
+	// The subscription is closed and the channel is closed when ctx is done.
+	Subscribe(ctx context.Context, projectIDs []int) <-chan Event
+	// Close closes channels of all subscriptions, so streaming handlers return on shutdown.
+	Close()
+}
@@ -0,0 +1,70 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"task-trail/internal/pkg/pubsub"
+)
+
+// size of the subscriber buffer, events are dropped for subscribers that do not keep up
+const bufferSize = 64
+
+// subscriber without projects receives events of all projects
+type subscriber struct {
+	projects map[int]bool
+	events   chan pubsub.Event
+}
+
+// Hub is the in-process broker, events are delivered only to subscribers of the same instance.
+type Hub struct {
+	mu          sync.RWMutex
+	subscribers map[*subscriber]struct{}
+	done        chan struct{}
+	closeOnce   sync.Once
+}
+
+func New() *Hub {
+	return &Hub{subscribers: make(map[*subscriber]struct{}), done: make(chan struct{})}
+}
+
+func (h *Hub) Publish(_ context.Context, event pubsub.Event) error {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for s := range h.subscribers {
+		if len(s.projects) > 0 && !s.projects[event.ProjectID] {
+			continue
+		}
+		select {
+		case s.events <- event:
+		default:
+		}
+	}
+	return nil
+}
+
+func (h *Hub) Subscribe(ctx context.Context, projectIDs []int) <-chan pubsub.Event {
+	s := &subscriber{projects: make(map[int]bool, len(projectIDs)), events: make(chan pubsub.Event, bufferSize)}
+	for _, ID := range projectIDs {
+		s.projects[ID] = true
+	}
+	h.mu.Lock()
+	h.subscribers[s] = struct{}{}
+	h.mu.Unlock()
+
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-h.done:
+		}
+		h.mu.Lock()
+		delete(h.subscribers, s)
+		h.mu.Unlock()
+		close(s.events)
+	}()
+	return s.events
+}
+
+// Close closes all subscriptions, channels of new ones are closed right away.
+func (h *Hub) Close() {
+	h.closeOnce.Do(func() { close(h.done) })
+}
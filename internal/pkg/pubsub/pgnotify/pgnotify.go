@@ -0,0 +1,92 @@
+package pgnotify
+
+import (
+	"context"
+	"encoding/json"
+	"task-trail/internal/pkg/logger"
+	"task-trail/internal/pkg/pubsub"
+	"task-trail/internal/pkg/pubsub/memory"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// delay before listening is restarted after the connection error
+const reconnectDelay = time.Second * 5
+
+// Broker publishes events with Postgres NOTIFY, so they are received by all application instances.
+// Every instance listens to the channel and passes received events to the local hub.
+type Broker struct {
+	pool    *pgxpool.Pool
+	channel string
+	logger  logger.Logger
+	hub     *memory.Hub
+}
+
+// New creates broker and starts listening to the channel until ctx is done.
+func New(ctx context.Context, pool *pgxpool.Pool, channel string, l logger.Logger) *Broker {
+	b := &Broker{pool: pool, channel: channel, logger: l, hub: memory.New()}
+	go b.listen(ctx)
+	return b
+}
+
+func (b *Broker) Publish(ctx context.Context, event pubsub.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = b.pool.Exec(ctx, "SELECT pg_notify($1, $2)", b.channel, string(payload))
+	return err
+}
+
+func (b *Broker) Subscribe(ctx context.Context, projectIDs []int) <-chan pubsub.Event {
+	return b.hub.Subscribe(ctx, projectIDs)
+}
+
+// Close closes subscriptions of the local hub, listening is stopped by ctx given to New.
+func (b *Broker) Close() {
+	b.hub.Close()
+}
+
+func (b *Broker) listen(ctx context.Context) {
+	for {
+		err := b.receive(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		b.logger.Error("postgres event listener failed", "error", err, "channel", b.channel)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+// receive passes notifications to the hub until the connection error.
+func (b *Broker) receive(ctx context.Context) error {
+	conn, err := b.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// connection in LISTEN state must not be returned to the pool
+	listener := conn.Hijack()
+	defer listener.Close(context.Background())
+
+	if _, err := listener.Exec(ctx, "LISTEN "+pgx.Identifier{b.channel}.Sanitize()); err != nil {
+		return err
+	}
+	for {
+		n, err := listener.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		var event pubsub.Event
+		if err := json.Unmarshal([]byte(n.Payload), &event); err != nil {
+			b.logger.Warn("invalid event received", "error", err, "channel", b.channel)
+			continue
+		}
+		_ = b.hub.Publish(ctx, event)
+	}
+}
@@ -0,0 +1,13 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+type Store interface {
+	// Take takes a token from the bucket of the key, the request is allowed if the bucket was not empty.
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+	// Cleanup deletes buckets not used for the idle time, they are full again and equal to new ones.
+	Cleanup(ctx context.Context, idle time.Duration) (int64, error)
+}
@@ -0,0 +1,54 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"task-trail/internal/pkg/ratelimit"
+	"time"
+)
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// Store keeps buckets in process, requests are counted per instance.
+type Store struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+func New() *Store {
+	return &Store{buckets: make(map[string]*bucket)}
+}
+
+func (s *Store) Take(_ context.Context, key string, limit ratelimit.Limit) (ratelimit.Result, error) {
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Requests), updated: now}
+		s.buckets[key] = b
+	}
+	b.tokens = min(float64(limit.Requests), b.tokens+now.Sub(b.updated).Seconds()*limit.Rate())
+	b.updated = now
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens--
+	}
+	return ratelimit.NewResult(limit, allowed, b.tokens), nil
+}
+
+func (s *Store) Cleanup(_ context.Context, idle time.Duration) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var deleted int64
+	for key, b := range s.buckets {
+		if time.Since(b.updated) > idle {
+			delete(s.buckets, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
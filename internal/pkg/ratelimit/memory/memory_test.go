@@ -0,0 +1,91 @@
+package memory_test
+
+import (
+	"context"
+	"task-trail/internal/pkg/ratelimit"
+	"task-trail/internal/pkg/ratelimit/memory"
+	"testing"
+	"time"
+)
+
+func take(t *testing.T, s *memory.Store, key string, limit ratelimit.Limit) ratelimit.Result {
+	t.Helper()
+	res, err := s.Take(context.Background(), key, limit)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return res
+}
+
+func TestTakeBurst(t *testing.T) {
+	s := memory.New()
+	limit := ratelimit.Limit{Requests: 3, Period: time.Minute}
+	for i := 2; i >= 0; i-- {
+		res := take(t, s, "key", limit)
+		if !res.Allowed || res.Remaining != i || res.RetryAfter != 0 {
+			t.Fatalf("request %d: got %+v, want allowed with %d remaining", 3-i, res, i)
+		}
+	}
+	res := take(t, s, "key", limit)
+	if res.Allowed || res.Remaining != 0 {
+		t.Fatalf("got %+v, want denied", res)
+	}
+	// one token is added every 20 seconds
+	if res.RetryAfter <= time.Second*19 || res.RetryAfter > time.Second*20 {
+		t.Errorf("retry after = %v, want about 20s", res.RetryAfter)
+	}
+	if res.Reset <= time.Second*59 || res.Reset > time.Minute {
+		t.Errorf("reset = %v, want about 1m", res.Reset)
+	}
+}
+
+func TestTakeRefill(t *testing.T) {
+	s := memory.New()
+	// one token is added every 50ms
+	limit := ratelimit.Limit{Requests: 2, Period: time.Millisecond * 100}
+	take(t, s, "key", limit)
+	take(t, s, "key", limit)
+	if res := take(t, s, "key", limit); res.Allowed {
+		t.Fatalf("got %+v, want denied", res)
+	}
+	time.Sleep(time.Millisecond * 60)
+	if res := take(t, s, "key", limit); !res.Allowed {
+		t.Fatalf("got %+v, want allowed after refill", res)
+	}
+	if res := take(t, s, "key", limit); res.Allowed {
+		t.Fatalf("got %+v, want denied, only one token is refilled", res)
+	}
+}
+
+func TestTakeKeysAreIndependent(t *testing.T) {
+	s := memory.New()
+	limit := ratelimit.Limit{Requests: 1, Period: time.Minute}
+	take(t, s, "first", limit)
+	if res := take(t, s, "first", limit); res.Allowed {
+		t.Fatalf("got %+v, want denied", res)
+	}
+	if res := take(t, s, "second", limit); !res.Allowed {
+		t.Fatalf("got %+v, want allowed for another key", res)
+	}
+}
+
+func TestCleanup(t *testing.T) {
+	ctx := context.Background()
+	s := memory.New()
+	limit := ratelimit.Limit{Requests: 1, Period: time.Minute}
+	take(t, s, "key", limit)
+
+	deleted, err := s.Cleanup(ctx, time.Hour)
+	if err != nil || deleted != 0 {
+		t.Fatalf("got %d, %v, want recently used bucket kept", deleted, err)
+	}
+	time.Sleep(time.Millisecond * 10)
+	deleted, err = s.Cleanup(ctx, time.Millisecond*5)
+	if err != nil || deleted != 1 {
+		t.Fatalf("got %d, %v, want idle bucket deleted", deleted, err)
+	}
+	// deleted bucket is full again
+	if res := take(t, s, "key", limit); !res.Allowed {
+		t.Errorf("got %+v, want allowed", res)
+	}
+}
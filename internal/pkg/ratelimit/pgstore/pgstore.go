@@ -0,0 +1,65 @@
+package pgstore
+
+import (
+	"context"
+	"errors"
+	"task-trail/internal/pkg/ratelimit"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// takeQuery refills the bucket and takes a token in one statement, so concurrent requests of all
+// instances are counted correctly. The row is not returned when the bucket is empty.
+const takeQuery = `
+INSERT INTO rate_limit_buckets AS b (key, tokens, updated_at)
+VALUES ($1, $2::float8 - 1, now())
+ON CONFLICT (key) DO UPDATE
+SET tokens = LEAST($2::float8, b.tokens + EXTRACT(EPOCH FROM now() - b.updated_at)::float8 * $3::float8) - 1,
+	updated_at = now()
+WHERE LEAST($2::float8, b.tokens + EXTRACT(EPOCH FROM now() - b.updated_at)::float8 * $3::float8) >= 1
+RETURNING tokens`
+
+const tokensQuery = `
+SELECT LEAST($2::float8, tokens + EXTRACT(EPOCH FROM now() - updated_at)::float8 * $3::float8)
+FROM rate_limit_buckets
+WHERE key = $1`
+
+// Store keeps buckets in Postgres, so requests are counted across all application instances.
+type Store struct {
+	pool *pgxpool.Pool
+}
+
+func New(pool *pgxpool.Pool) *Store {
+	return &Store{pool: pool}
+}
+
+func (s *Store) Take(ctx context.Context, key string, limit ratelimit.Limit) (ratelimit.Result, error) {
+	requests := float64(limit.Requests)
+	var tokens float64
+	err := s.pool.QueryRow(ctx, takeQuery, key, requests, limit.Rate()).Scan(&tokens)
+	if err == nil {
+		return ratelimit.NewResult(limit, true, tokens), nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return ratelimit.Result{}, err
+	}
+
+	err = s.pool.QueryRow(ctx, tokensQuery, key, requests, limit.Rate()).Scan(&tokens)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return ratelimit.Result{}, err
+	}
+	return ratelimit.NewResult(limit, false, tokens), nil
+}
+
+func (s *Store) Cleanup(ctx context.Context, idle time.Duration) (int64, error) {
+	tag, err := s.pool.Exec(ctx,
+		"DELETE FROM rate_limit_buckets WHERE updated_at < now() - make_interval(secs => $1)",
+		idle.Seconds(),
+	)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
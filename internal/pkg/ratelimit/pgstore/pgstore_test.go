@@ -0,0 +1,156 @@
+//go:build integration
+
+package pgstore
+
+import (
+	"context"
+	"os"
+	"strings"
+	"task-trail/config"
+	slogger "task-trail/internal/pkg/logger/slog"
+	"task-trail/internal/pkg/postgres"
+	"task-trail/internal/pkg/ratelimit"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+)
+
+// migrations of the repository root, tests are run in the package directory
+const migrationPath = "file://../../../../migrations"
+
+var pg *postgres.Postgres
+var store *Store
+
+func TestMain(m *testing.M) {
+	cfg, err := config.New()
+	if err != nil {
+		panic(err)
+	}
+	logger, err := slogger.New(slogger.Format(cfg.Log.Format), slogger.Level(cfg.Log.Level))
+	if err != nil {
+		panic(err)
+	}
+	// own database, so it is not dropped by tests of other packages running in parallel
+	cfg.PG.ConnString += "_ratelimit_test"
+
+	dsn := cfg.PG.ConnString[:strings.LastIndex(cfg.PG.ConnString, "/")+1] + "postgres"
+	if err := createTestDatabase(context.Background(), dsn, cfg.PG.ConnString[strings.LastIndex(cfg.PG.ConnString, "/")+1:]); err != nil {
+		logger.Error("Test db creation failed", "error", err)
+		os.Exit(1)
+	}
+	if err := postgres.Migrate(cfg.PG.ConnString, migrationPath, logger); err != nil {
+		logger.Error("db migration error", "error", err.Error())
+		os.Exit(1)
+	}
+	pg, err = postgres.New(cfg.PG.ConnString, logger, postgres.MaxPoolSize(cfg.PG.MaxPoolSize))
+	if err != nil {
+		logger.Error("postgres connection error", "error", err.Error())
+		os.Exit(1)
+	}
+	defer pg.Close()
+	store = New(pg.Pool)
+	os.Exit(m.Run())
+}
+
+func createTestDatabase(ctx context.Context, adminDSN, dbName string) error {
+	conn, err := pgx.Connect(ctx, adminDSN)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(ctx)
+
+	if _, err = conn.Exec(ctx, "DROP DATABASE IF EXISTS "+dbName); err != nil {
+		return err
+	}
+	_, err = conn.Exec(ctx, "CREATE DATABASE "+dbName)
+	return err
+}
+
+func cleanDB(t *testing.T) {
+	_, err := pg.Pool.Exec(context.Background(), "TRUNCATE TABLE rate_limit_buckets")
+	require.NoError(t, err)
+}
+
+func TestTake(t *testing.T) {
+	ctx := t.Context()
+
+	t.Run("burst", func(t *testing.T) {
+		cleanDB(t)
+		limit := ratelimit.Limit{Requests: 2, Period: time.Hour}
+		for _, remaining := range []int{1, 0} {
+			res, err := store.Take(ctx, "burst", limit)
+			require.NoError(t, err)
+			require.True(t, res.Allowed)
+			require.Equal(t, 2, res.Limit)
+			require.Equal(t, remaining, res.Remaining)
+			require.Zero(t, res.RetryAfter)
+		}
+		res, err := store.Take(ctx, "burst", limit)
+		require.NoError(t, err)
+		require.False(t, res.Allowed)
+		require.Equal(t, 0, res.Remaining)
+		// one token is added every 30 minutes
+		require.InDelta(t, (time.Minute * 30).Seconds(), res.RetryAfter.Seconds(), 1)
+		require.InDelta(t, time.Hour.Seconds(), res.Reset.Seconds(), 1)
+	})
+	t.Run("refill", func(t *testing.T) {
+		cleanDB(t)
+		// one token is added every 100ms
+		limit := ratelimit.Limit{Requests: 1, Period: time.Millisecond * 100}
+		res, err := store.Take(ctx, "refill", limit)
+		require.NoError(t, err)
+		require.True(t, res.Allowed)
+		res, err = store.Take(ctx, "refill", limit)
+		require.NoError(t, err)
+		require.False(t, res.Allowed)
+		time.Sleep(time.Millisecond * 150)
+		res, err = store.Take(ctx, "refill", limit)
+		require.NoError(t, err)
+		require.True(t, res.Allowed)
+	})
+	t.Run("keys are independent", func(t *testing.T) {
+		cleanDB(t)
+		limit := ratelimit.Limit{Requests: 1, Period: time.Hour}
+		res, err := store.Take(ctx, "first", limit)
+		require.NoError(t, err)
+		require.True(t, res.Allowed)
+		res, err = store.Take(ctx, "first", limit)
+		require.NoError(t, err)
+		require.False(t, res.Allowed)
+		res, err = store.Take(ctx, "second", limit)
+		require.NoError(t, err)
+		require.True(t, res.Allowed)
+	})
+	t.Run("store error", func(t *testing.T) {
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+		_, err := store.Take(canceled, "key", ratelimit.Limit{Requests: 1, Period: time.Hour})
+		require.Error(t, err)
+	})
+}
+
+func TestCleanup(t *testing.T) {
+	ctx := t.Context()
+	cleanDB(t)
+	limit := ratelimit.Limit{Requests: 1, Period: time.Hour}
+	for _, key := range []string{"first", "second"} {
+		_, err := store.Take(ctx, key, limit)
+		require.NoError(t, err)
+	}
+
+	deleted, err := store.Cleanup(ctx, time.Hour)
+	require.NoError(t, err)
+	require.Zero(t, deleted)
+
+	time.Sleep(time.Millisecond * 50)
+	deleted, err = store.Cleanup(ctx, time.Millisecond*10)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), deleted)
+
+	// deleted bucket is full again
+	res, err := store.Take(ctx, "first", limit)
+	require.NoError(t, err)
+	require.True(t, res.Allowed)
+}
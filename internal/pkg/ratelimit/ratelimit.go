@@ -0,0 +1,79 @@
+package ratelimit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limit is the token bucket holding Requests tokens and refilled evenly over Period,
+// so Requests are allowed at once and then one request every Period/Requests.
+type Limit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// ParseLimit parses limit written as requests/period, e.g. 100/1m.
+func ParseLimit(s string) (Limit, error) {
+	requests, period, ok := strings.Cut(strings.TrimSpace(s), "/")
+	if !ok {
+		return Limit{}, fmt.Errorf("invalid rate limit %q, expected requests/period", s)
+	}
+	r, err := strconv.Atoi(requests)
+	if err != nil || r <= 0 {
+		return Limit{}, fmt.Errorf("invalid requests of rate limit %q", s)
+	}
+	p, err := time.ParseDuration(period)
+	if err != nil || p <= 0 {
+		return Limit{}, fmt.Errorf("invalid period of rate limit %q", s)
+	}
+	return Limit{Requests: r, Period: p}, nil
+}
+
+// Rate returns tokens added to the bucket per second.
+func (l Limit) Rate() float64 {
+	return float64(l.Requests) / l.Period.Seconds()
+}
+
+// Result is the state of the bucket after the request, it is returned to the client in RateLimit headers.
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is the time until the bucket is full again
+	Reset time.Duration
+	// RetryAfter is the time until the next request is allowed, zero for allowed requests
+	RetryAfter time.Duration
+}
+
+// NewResult builds result of the bucket with the tokens left after the request.
+func NewResult(l Limit, allowed bool, tokens float64) Result {
+	rate := l.Rate()
+	r := Result{
+		Allowed:   allowed,
+		Limit:     l.Requests,
+		Remaining: int(tokens),
+		Reset:     seconds((float64(l.Requests) - tokens) / rate),
+	}
+	if !allowed {
+		r.RetryAfter = seconds((1 - tokens) / rate)
+	}
+	return r
+}
+
+func seconds(s float64) time.Duration {
+	if s < 0 {
+		return 0
+	}
+	return time.Duration(s * float64(time.Second))
+}
+
+// LongestPeriod returns the longest period of the limits, buckets unused for it are full.
+func LongestPeriod(limits map[string]Limit) time.Duration {
+	var longest time.Duration
+	for _, l := range limits {
+		longest = max(longest, l.Period)
+	}
+	return longest
+}
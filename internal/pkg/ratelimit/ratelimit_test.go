@@ -0,0 +1,99 @@
+package ratelimit_test
+
+import (
+	"task-trail/internal/pkg/ratelimit"
+	"testing"
+	"time"
+)
+
+func TestParseLimit(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    ratelimit.Limit
+		wantErr bool
+	}{
+		{name: "requests per minute", s: "100/1m", want: ratelimit.Limit{Requests: 100, Period: time.Minute}},
+		{name: "spaces are trimmed", s: " 5/30s ", want: ratelimit.Limit{Requests: 5, Period: time.Second * 30}},
+		{name: "missing period", s: "100", wantErr: true},
+		{name: "invalid requests", s: "many/1m", wantErr: true},
+		{name: "zero requests", s: "0/1m", wantErr: true},
+		{name: "negative requests", s: "-1/1m", wantErr: true},
+		{name: "invalid period", s: "10/minute", wantErr: true},
+		{name: "zero period", s: "10/0s", wantErr: true},
+		{name: "negative period", s: "10/-1s", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ratelimit.ParseLimit(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewResult(t *testing.T) {
+	// one token is added every second
+	limit := ratelimit.Limit{Requests: 10, Period: time.Second * 10}
+	tests := []struct {
+		name    string
+		allowed bool
+		tokens  float64
+		want    ratelimit.Result
+	}{
+		{
+			name:    "allowed",
+			allowed: true,
+			tokens:  7,
+			want:    ratelimit.Result{Allowed: true, Limit: 10, Remaining: 7, Reset: time.Second * 3},
+		},
+		{
+			name:    "remaining tokens are rounded down",
+			allowed: true,
+			tokens:  2.5,
+			want:    ratelimit.Result{Allowed: true, Limit: 10, Remaining: 2, Reset: time.Millisecond * 7500},
+		},
+		{
+			name:    "full bucket",
+			allowed: true,
+			tokens:  10,
+			want:    ratelimit.Result{Allowed: true, Limit: 10, Remaining: 10},
+		},
+		{
+			name:    "denied",
+			allowed: false,
+			tokens:  0.25,
+			want: ratelimit.Result{
+				Limit:      10,
+				Reset:      time.Millisecond * 9750,
+				RetryAfter: time.Millisecond * 750,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ratelimit.NewResult(limit, tt.allowed, tt.tokens)
+			if got != tt.want {
+				t.Errorf("got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLongestPeriod(t *testing.T) {
+	limits := map[string]ratelimit.Limit{
+		"auth":    {Requests: 10, Period: time.Minute},
+		"default": {Requests: 100, Period: time.Hour},
+		"upload":  {Requests: 5, Period: time.Second * 30},
+	}
+	if got := ratelimit.LongestPeriod(limits); got != time.Hour {
+		t.Errorf("got = %v, want %v", got, time.Hour)
+	}
+	if got := ratelimit.LongestPeriod(nil); got != 0 {
+		t.Errorf("got = %v, want 0", got)
+	}
+}
@@ -1,11 +1,37 @@
 package smtp
 
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrPermanent is wrapped by errors of messages which can't be delivered on retry, e.g. rejected recipient.
+var ErrPermanent = errors.New("permanent delivery failure")
+
 type Sender interface {
-	Send(msg Message, eventID string) error
+	// Send delivers the message, ctx carries values of the caller such as request id, it doesn't cancel sending.
+	Send(ctx context.Context, msg Message, eventID string) error
+}
+
+// Mailbox is implemented by senders keeping delivered messages, it is used to inspect emails in development.
+type Mailbox interface {
+	Messages() []SentMessage
+	Clear()
 }
 
 type Message struct {
 	Subject    string
 	Recipients []string
-	Text       string
+	// Text is the plain text body
+	Text string
+	// HTML is the optional html alternative of the body
+	HTML string
+}
+
+type SentMessage struct {
+	Message
+	EventID   string
+	RequestID string
+	SentAt    time.Time
 }
@@ -0,0 +1,41 @@
+// Package file implements smtp.Sender writing emails as .eml files instead of sending them.
+package file
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"task-trail/internal/pkg/logger"
+	"task-trail/internal/pkg/smtp"
+	"task-trail/internal/pkg/smtp/gomail"
+	"time"
+)
+
+type FileSender struct {
+	dir    string
+	from   string
+	logger logger.Logger
+}
+
+// New creates sender writing emails to dir, the directory is created if it does not exist.
+func New(logger logger.Logger, dir string, from string) (*FileSender, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create mail directory: %w", err)
+	}
+	return &FileSender{dir: dir, from: from, logger: logger}, nil
+}
+
+func (s *FileSender) Send(ctx context.Context, msg smtp.Message, eventID string) error {
+	m, err := gomail.NewMsg(s.from, msg)
+	if err != nil {
+		return err
+	}
+	name := filepath.Join(s.dir, fmt.Sprintf("%s-%s.eml", time.Now().UTC().Format("20060102T150405.000000000"), eventID))
+	if err := m.WriteToFile(name); err != nil {
+		s.logger.ErrorContext(ctx, "writing email file failed", "eventID", eventID, "error", err)
+		return err
+	}
+	s.logger.InfoContext(ctx, "email written to file", "eventID", eventID, "recipients", msg.Recipients, "file", name)
+	return nil
+}
@@ -0,0 +1,112 @@
+package file_test
+
+import (
+	"context"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"task-trail/internal/pkg/smtp"
+	"task-trail/internal/pkg/smtp/file"
+	"task-trail/test/mocks"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestSend(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	l := mocks.NewMockLogger(ctrl)
+	l.EXPECT().InfoContext(gomock.Any(), "email written to file", gomock.Any()).Times(2)
+
+	// sender creates the missing directory
+	dir := filepath.Join(t.TempDir(), "mail")
+	s, err := file.New(l, dir, "noreply@test.test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	msg := smtp.Message{
+		Subject:    "subject",
+		Recipients: []string{"first@test.test", "second@test.test"},
+		Text:       "text body",
+		HTML:       "<p>html body</p>",
+	}
+	if err := s.Send(context.Background(), msg, "first-event"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := s.Send(context.Background(), msg, "second-event"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("failed to read mail directory: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("files = %d, want 2", len(entries))
+	}
+	// names start with the time, so files are listed in the order of sending
+	for i, eventID := range []string{"first-event", "second-event"} {
+		name := entries[i].Name()
+		if !strings.HasSuffix(name, "-"+eventID+".eml") {
+			t.Errorf("file name = %s, want suffix -%s.eml", name, eventID)
+		}
+		f, err := os.Open(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("failed to open email file: %v", err)
+		}
+		m, err := mail.ReadMessage(f)
+		_ = f.Close()
+		if err != nil {
+			t.Fatalf("failed to parse email file: %v", err)
+		}
+		if got := m.Header.Get("Subject"); got != msg.Subject {
+			t.Errorf("subject = %q, want %q", got, msg.Subject)
+		}
+		from, err := m.Header.AddressList("From")
+		if err != nil || len(from) != 1 || from[0].Address != "noreply@test.test" {
+			t.Errorf("from = %v (%v), want noreply@test.test", from, err)
+		}
+		to, err := m.Header.AddressList("To")
+		if err != nil {
+			t.Fatalf("failed to parse recipients: %v", err)
+		}
+		if len(to) != len(msg.Recipients) {
+			t.Fatalf("recipients = %v, want %v", to, msg.Recipients)
+		}
+		for j, addr := range to {
+			if addr.Address != msg.Recipients[j] {
+				t.Errorf("recipient = %s, want %s", addr.Address, msg.Recipients[j])
+			}
+		}
+		body, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("failed to read email file: %v", err)
+		}
+		for _, part := range []string{msg.Text, msg.HTML} {
+			if !strings.Contains(string(body), part) {
+				t.Errorf("email doesn't contain %q", part)
+			}
+		}
+	}
+}
+
+func TestSendFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	l := mocks.NewMockLogger(ctrl)
+	l.EXPECT().ErrorContext(gomock.Any(), "writing email file failed", gomock.Any())
+
+	dir := t.TempDir()
+	s, err := file.New(l, dir, "noreply@test.test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// the directory is removed after creation of the sender
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatalf("failed to remove mail directory: %v", err)
+	}
+	msg := smtp.Message{Subject: "subject", Recipients: []string{"first@test.test"}, Text: "text"}
+	if err := s.Send(context.Background(), msg, "event"); err == nil {
+		t.Error("expected error, got nil")
+	}
+}
@@ -0,0 +1,6 @@
+package gomail
+
+// MailClient and NewWithClient let tests replace the go-mail client with a fake one.
+type MailClient = mailClient
+
+var NewWithClient = newSender
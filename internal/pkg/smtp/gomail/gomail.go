@@ -1,52 +1,276 @@
 package gomail
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"task-trail/internal/pkg/contextmanager"
 	"task-trail/internal/pkg/logger"
 	"task-trail/internal/pkg/smtp"
+	"time"
 
 	"github.com/wneessen/go-mail"
+	mailsmtp "github.com/wneessen/go-mail/smtp"
 )
 
+// ErrClosed is returned by Send when the sender is shut down or its context is done.
+var ErrClosed = errors.New("smtp sender is closed")
+
+// mailClient is the part of go-mail client used by the sender.
+type mailClient interface {
+	DialToSMTPClientWithContext(ctx context.Context) (*mailsmtp.Client, error)
+	SendWithSMTPClient(conn *mailsmtp.Client, msgs ...*mail.Msg) error
+	CloseWithSMTPClient(conn *mailsmtp.Client) error
+}
+
+type job struct {
+	msg       *mail.Msg
+	eventID   string
+	requestID string
+	result    chan error
+}
+
+// GomailSender sends emails by a bounded pool of workers reusing their SMTP connections.
+// Send blocks until the message is delivered or failed, so callers like the outbox know about failures.
+// Transient failures are retried a few times with a short delay,
+// callers keeping failed messages (outbox, digest) retry them later with their own backoff.
 type GomailSender struct {
-	client *mail.Client
-	logger logger.Logger
-	from   string
+	ctx         context.Context
+	cancel      context.CancelFunc
+	client      mailClient
+	logger      logger.Logger
+	from        string
+	workers     int
+	queueSize   int
+	maxRetries  int
+	retryDelay  time.Duration
+	idleTimeout time.Duration
+
+	mu     sync.RWMutex
+	closed bool
+	jobs   chan job
+	wg     sync.WaitGroup
 }
 
-func New(logger logger.Logger, host string, port int, login string, password string, sender string) *GomailSender {
-	opts := []mail.Option{
+// New creates sender and starts its workers, they are stopped when ctx is done or the sender is shut down.
+func New(ctx context.Context, logger logger.Logger, host string, port int, login string, password string, sender string, opts ...Option) (*GomailSender, error) {
+	client, err := mail.NewClient(host,
 		mail.WithSMTPAuth(mail.SMTPAuthPlain),
 		mail.WithUsername(login),
 		mail.WithPassword(password),
 		mail.WithPort(port),
 		mail.WithTLSPolicy(mail.TLSMandatory),
-	}
-	client, err := mail.NewClient(host, opts...)
+	)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
-	return &GomailSender{client: client, logger: logger, from: sender}
+	return newSender(ctx, logger, client, sender, opts...), nil
 }
 
-func (s *GomailSender) Send(msg smtp.Message, eventID string) error {
-	m := mail.NewMsg()
-	if err := m.From(s.from); err != nil {
+func newSender(ctx context.Context, logger logger.Logger, client mailClient, sender string, opts ...Option) *GomailSender {
+	ctx, cancel := context.WithCancel(ctx)
+	s := &GomailSender{
+		ctx:         ctx,
+		cancel:      cancel,
+		client:      client,
+		logger:      logger,
+		from:        sender,
+		workers:     4,
+		queueSize:   100,
+		maxRetries:  3,
+		retryDelay:  time.Second,
+		idleTimeout: 30 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.jobs = make(chan job, s.queueSize)
+	for range s.workers {
+		s.wg.Add(1)
+		go s.work()
+	}
+	return s
+}
+
+func (s *GomailSender) Send(ctx context.Context, msg smtp.Message, eventID string) error {
+	m, err := NewMsg(s.from, msg)
+	if err != nil {
 		return err
 	}
-	if err := m.To(msg.Recipients...); err != nil {
+	j := job{
+		msg:       m,
+		eventID:   eventID,
+		requestID: contextmanager.RequestIDFromContext(ctx),
+		result:    make(chan error, 1),
+	}
+	if err := s.enqueue(j); err != nil {
 		return err
 	}
-	m.Subject(msg.Subject)
-	m.SetBodyString(mail.TypeTextHTML, msg.Text)
-	s.logger.Info("start email sending event", "eventID", eventID, "recipients", msg.Recipients)
-	go func(msg *mail.Msg, eventID string, logger logger.Logger) {
-		err := s.client.DialAndSend(m)
-		if err != nil {
-			logger.Error("sending email failed", "eventID", eventID, "error", err)
+	select {
+	case err := <-j.result:
+		return err
+	case <-s.ctx.Done():
+		return ErrClosed
+	}
+}
+
+// Shutdown stops accepting new messages and waits until the queued ones are processed.
+// Sending is aborted when ctx is done first.
+func (s *GomailSender) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.jobs)
+	}
+	s.mu.Unlock()
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+	defer s.cancel()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.cancel()
+		<-done
+		return ctx.Err()
+	}
+}
+
+// Check connects to the SMTP server, it is used by readiness probe.
+func (s *GomailSender) Check(ctx context.Context) error {
+	conn, err := s.client.DialToSMTPClientWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	s.closeConn(conn)
+	return nil
+}
+
+func (s *GomailSender) enqueue(j job) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return ErrClosed
+	}
+	select {
+	case s.jobs <- j:
+		s.logger.Info("email queued", "eventID", j.eventID, "requestID", j.requestID, "recipients", j.msg.GetToString())
+		return nil
+	case <-s.ctx.Done():
+		return ErrClosed
+	}
+}
+
+func (s *GomailSender) work() {
+	defer s.wg.Done()
+	var conn *mailsmtp.Client
+	defer func() {
+		s.closeConn(conn)
+	}()
+	for {
+		// unused connection is closed, so it is not dropped by the server in the middle of sending
+		var idle <-chan time.Time
+		if conn != nil {
+			idle = time.After(s.idleTimeout)
+		}
+		select {
+		case <-s.ctx.Done():
 			return
+		case <-idle:
+			s.closeConn(conn)
+			conn = nil
+		case j, ok := <-s.jobs:
+			if !ok {
+				return
+			}
+			var err error
+			conn, err = s.deliver(conn, j)
+			j.result <- err
 		}
-		logger.Info("email successfully sent", "eventID", eventID)
-	}(m, eventID, s.logger)
+	}
+}
 
-	return nil
+// deliver sends the message retrying transient failures, broken connection is replaced with a new one.
+// Reused connection may be dropped by the server while idle, so the first failure on it is repeated at once on a new one.
+func (s *GomailSender) deliver(conn *mailsmtp.Client, j job) (*mailsmtp.Client, error) {
+	reused := conn != nil
+	delay := s.retryDelay
+	for attempt := 0; ; {
+		var err error
+		if conn == nil {
+			conn, err = s.client.DialToSMTPClientWithContext(s.ctx)
+		}
+		if err == nil {
+			err = s.client.SendWithSMTPClient(conn, j.msg)
+			if err == nil {
+				s.logger.Info("email successfully sent", "eventID", j.eventID, "requestID", j.requestID)
+				return conn, nil
+			}
+			s.closeConn(conn)
+			conn = nil
+		}
+		if !isTransient(err) {
+			err = fmt.Errorf("%w: %w", smtp.ErrPermanent, err)
+			s.logger.Error("sending email failed", "eventID", j.eventID, "requestID", j.requestID, "error", err)
+			return conn, err
+		}
+		if reused {
+			reused = false
+			s.logger.Debug("sending email over reused connection failed, reconnecting", "eventID", j.eventID, "error", err)
+			continue
+		}
+		if attempt >= s.maxRetries {
+			s.logger.Error("sending email failed", "eventID", j.eventID, "requestID", j.requestID, "attempts", attempt+1, "error", err)
+			return conn, err
+		}
+		attempt++
+		s.logger.Warn("sending email failed, retrying", "eventID", j.eventID, "requestID", j.requestID, "attempt", attempt, "error", err)
+		select {
+		case <-s.ctx.Done():
+			return conn, ErrClosed
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+func (s *GomailSender) closeConn(conn *mailsmtp.Client) {
+	if conn == nil {
+		return
+	}
+	if err := s.client.CloseWithSMTPClient(conn); err != nil {
+		s.logger.Debug("closing smtp connection failed", "error", err)
+	}
+}
+
+// isTransient reports whether sending may succeed on retry, only permanent SMTP rejections are not transient.
+func isTransient(err error) bool {
+	var sendErr *mail.SendError
+	if errors.As(err, &sendErr) {
+		return sendErr.IsTemp() || sendErr.Reason == mail.ErrConnCheck || sendErr.Reason == mail.ErrSMTPReset
+	}
+	return true
+}
+
+// NewMsg builds MIME message from the given one, from is skipped when empty.
+func NewMsg(from string, msg smtp.Message) (*mail.Msg, error) {
+	m := mail.NewMsg()
+	if from != "" {
+		if err := m.From(from); err != nil {
+			return nil, err
+		}
+	}
+	if err := m.To(msg.Recipients...); err != nil {
+		return nil, err
+	}
+	m.Subject(msg.Subject)
+	m.SetBodyString(mail.TypeTextPlain, msg.Text)
+	if msg.HTML != "" {
+		m.AddAlternativeString(mail.TypeTextHTML, msg.HTML)
+	}
+	return m, nil
 }
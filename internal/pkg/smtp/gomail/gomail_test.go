@@ -0,0 +1,269 @@
+package gomail_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"task-trail/internal/pkg/smtp"
+	"task-trail/internal/pkg/smtp/gomail"
+	"task-trail/test/mocks"
+	"testing"
+	"time"
+
+	"github.com/wneessen/go-mail"
+	mailsmtp "github.com/wneessen/go-mail/smtp"
+	"go.uber.org/mock/gomock"
+)
+
+var testMsg = smtp.Message{Subject: "subject", Recipients: []string{"test@test.test"}, Text: "text"}
+
+// fakeClient counts dials and sends, sends fail with the queued errors first.
+type fakeClient struct {
+	mu        sync.Mutex
+	dialErr   error
+	sendErrs  []error
+	sendDelay time.Duration
+	dials     int
+	sends     int
+	closes    int
+	active    int
+	maxActive int
+}
+
+var _ gomail.MailClient = (*fakeClient)(nil)
+
+func (c *fakeClient) DialToSMTPClientWithContext(context.Context) (*mailsmtp.Client, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.dials++
+	if c.dialErr != nil {
+		return nil, c.dialErr
+	}
+	return &mailsmtp.Client{}, nil
+}
+
+func (c *fakeClient) SendWithSMTPClient(*mailsmtp.Client, ...*mail.Msg) error {
+	c.mu.Lock()
+	c.sends++
+	c.active++
+	c.maxActive = max(c.maxActive, c.active)
+	var err error
+	if len(c.sendErrs) > 0 {
+		err, c.sendErrs = c.sendErrs[0], c.sendErrs[1:]
+	}
+	c.mu.Unlock()
+	time.Sleep(c.sendDelay)
+	c.mu.Lock()
+	c.active--
+	c.mu.Unlock()
+	return err
+}
+
+func (c *fakeClient) CloseWithSMTPClient(*mailsmtp.Client) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closes++
+	return nil
+}
+
+func newSender(t *testing.T, c *fakeClient, opts ...gomail.Option) *gomail.GomailSender {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	l := mocks.NewMockLogger(ctrl)
+	l.EXPECT().Info(gomock.Any(), gomock.Any()).AnyTimes()
+	l.EXPECT().Debug(gomock.Any(), gomock.Any()).AnyTimes()
+	l.EXPECT().Warn(gomock.Any(), gomock.Any()).AnyTimes()
+	l.EXPECT().Error(gomock.Any(), gomock.Any()).AnyTimes()
+	s := gomail.NewWithClient(context.Background(), l, c, "noreply@test.test", opts...)
+	t.Cleanup(func() {
+		_ = s.Shutdown(context.Background())
+	})
+	return s
+}
+
+func TestSendReusesConnection(t *testing.T) {
+	c := &fakeClient{}
+	s := newSender(t, c, gomail.Workers(1))
+	for range 3 {
+		if err := s.Send(context.Background(), testMsg, "event"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if c.dials != 1 || c.sends != 3 {
+		t.Errorf("dials = %d, sends = %d, want 1 and 3", c.dials, c.sends)
+	}
+}
+
+func TestSendByWorkerPool(t *testing.T) {
+	const workers = 3
+	c := &fakeClient{sendDelay: 50 * time.Millisecond}
+	s := newSender(t, c, gomail.Workers(workers))
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := s.Send(context.Background(), testMsg, "event"); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if c.maxActive != workers {
+		t.Errorf("concurrent sends = %d, want %d", c.maxActive, workers)
+	}
+	if c.dials != workers {
+		t.Errorf("dials = %d, want %d", c.dials, workers)
+	}
+}
+
+func TestSendFailures(t *testing.T) {
+	plainErr := errors.New("connection reset by peer")
+	tests := []struct {
+		name string
+		c    *fakeClient
+		// message sent before the tested one, so the connection is reused
+		warmUp        bool
+		wantErr       error
+		wantPermanent bool
+		wantDials     int
+		wantSends     int
+	}{
+		{
+			name:      "dial error is retried",
+			c:         &fakeClient{dialErr: plainErr},
+			wantErr:   plainErr,
+			wantDials: 2,
+		},
+		{
+			name:      "transient error is retried",
+			c:         &fakeClient{sendErrs: []error{plainErr}},
+			wantDials: 2,
+			wantSends: 2,
+		},
+		{
+			name:      "transient error is retried up to max retries",
+			c:         &fakeClient{sendErrs: []error{plainErr, plainErr}},
+			wantErr:   plainErr,
+			wantDials: 2,
+			wantSends: 2,
+		},
+		{
+			name:      "connection check error is transient",
+			c:         &fakeClient{sendErrs: []error{&mail.SendError{Reason: mail.ErrConnCheck}, &mail.SendError{Reason: mail.ErrConnCheck}}},
+			wantErr:   &mail.SendError{Reason: mail.ErrConnCheck},
+			wantDials: 2,
+			wantSends: 2,
+		},
+		{
+			name:          "rejected recipient is permanent",
+			c:             &fakeClient{sendErrs: []error{&mail.SendError{Reason: mail.ErrSMTPRcptTo}}},
+			wantErr:       &mail.SendError{Reason: mail.ErrSMTPRcptTo},
+			wantPermanent: true,
+			wantDials:     1,
+			wantSends:     1,
+		},
+		{
+			name:      "failure on reused connection is repeated on a new one",
+			c:         &fakeClient{sendErrs: []error{nil, plainErr}},
+			warmUp:    true,
+			wantDials: 2,
+			wantSends: 3,
+		},
+		{
+			name:      "repeat on reused connection is not counted as retry",
+			c:         &fakeClient{sendErrs: []error{nil, plainErr, plainErr}},
+			warmUp:    true,
+			wantDials: 3,
+			wantSends: 4,
+		},
+		{
+			name:      "failure on reused connection is repeated only once",
+			c:         &fakeClient{sendErrs: []error{nil, plainErr, plainErr, plainErr}},
+			warmUp:    true,
+			wantErr:   plainErr,
+			wantDials: 3,
+			wantSends: 4,
+		},
+		{
+			name:          "permanent failure on reused connection is not repeated",
+			c:             &fakeClient{sendErrs: []error{nil, &mail.SendError{Reason: mail.ErrSMTPRcptTo}}},
+			warmUp:        true,
+			wantErr:       &mail.SendError{Reason: mail.ErrSMTPRcptTo},
+			wantPermanent: true,
+			wantDials:     1,
+			wantSends:     2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newSender(t, tt.c, gomail.Workers(1), gomail.MaxRetries(1), gomail.RetryDelay(time.Millisecond))
+			if tt.warmUp {
+				if err := s.Send(context.Background(), testMsg, "warm-up"); err != nil {
+					t.Fatalf("unexpected warm-up error: %v", err)
+				}
+			}
+			err := s.Send(context.Background(), testMsg, "event")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Send() error = %v, want %v", err, tt.wantErr)
+			}
+			if got := errors.Is(err, smtp.ErrPermanent); got != tt.wantPermanent {
+				t.Errorf("permanent = %v, want %v", got, tt.wantPermanent)
+			}
+			if tt.c.dials != tt.wantDials || tt.c.sends != tt.wantSends {
+				t.Errorf("dials = %d, sends = %d, want %d and %d", tt.c.dials, tt.c.sends, tt.wantDials, tt.wantSends)
+			}
+		})
+	}
+}
+
+func TestRetryAbortedOnShutdown(t *testing.T) {
+	c := &fakeClient{sendErrs: []error{errors.New("connection reset by peer")}}
+	s := newSender(t, c, gomail.RetryDelay(time.Hour))
+	res := make(chan error, 1)
+	go func() {
+		res <- s.Send(context.Background(), testMsg, "event")
+	}()
+	// wait for the first attempt, the retry is delayed for an hour
+	for deadline := time.Now().Add(time.Second); ; time.Sleep(time.Millisecond) {
+		c.mu.Lock()
+		sends := c.sends
+		c.mu.Unlock()
+		if sends > 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("message is not sent")
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := s.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Shutdown() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	select {
+	case err := <-res:
+		if !errors.Is(err, gomail.ErrClosed) {
+			t.Errorf("Send() error = %v, want %v", err, gomail.ErrClosed)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("Send() is not aborted")
+	}
+}
+
+func TestSendAfterShutdown(t *testing.T) {
+	c := &fakeClient{}
+	s := newSender(t, c)
+	if err := s.Send(context.Background(), testMsg, "event"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := s.Shutdown(context.Background()); err != nil {
+		t.Fatalf("unexpected shutdown error: %v", err)
+	}
+	if err := s.Send(context.Background(), testMsg, "event"); !errors.Is(err, gomail.ErrClosed) {
+		t.Errorf("Send() error = %v, want %v", err, gomail.ErrClosed)
+	}
+	if c.closes != c.dials {
+		t.Errorf("closes = %d, want %d", c.closes, c.dials)
+	}
+}
@@ -0,0 +1,40 @@
+package gomail
+
+import "time"
+
+type Option func(*GomailSender)
+
+// Workers sets the number of workers, each of them keeps its own SMTP connection.
+func Workers(n int) Option {
+	return func(s *GomailSender) {
+		s.workers = n
+	}
+}
+
+// QueueSize sets the number of messages waiting for a free worker before Send blocks.
+func QueueSize(n int) Option {
+	return func(s *GomailSender) {
+		s.queueSize = n
+	}
+}
+
+// MaxRetries sets the number of retries of a message after transient failures.
+func MaxRetries(n int) Option {
+	return func(s *GomailSender) {
+		s.maxRetries = n
+	}
+}
+
+// RetryDelay sets the delay before the first retry, it is doubled on each next one.
+func RetryDelay(d time.Duration) Option {
+	return func(s *GomailSender) {
+		s.retryDelay = d
+	}
+}
+
+// IdleTimeout sets the time after which an unused connection is closed.
+func IdleTimeout(d time.Duration) Option {
+	return func(s *GomailSender) {
+		s.idleTimeout = d
+	}
+}
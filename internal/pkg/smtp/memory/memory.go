@@ -0,0 +1,54 @@
+// Package memory implements smtp.Sender keeping emails in memory, it is intended for development and tests.
+package memory
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"task-trail/internal/pkg/contextmanager"
+	"task-trail/internal/pkg/smtp"
+	"time"
+)
+
+// capacity is the number of the latest messages kept by the sender.
+const capacity = 100
+
+type MemorySender struct {
+	mu       sync.Mutex
+	messages []smtp.SentMessage
+}
+
+func New() *MemorySender {
+	return &MemorySender{}
+}
+
+func (s *MemorySender) Send(ctx context.Context, msg smtp.Message, eventID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	msg.Recipients = slices.Clone(msg.Recipients)
+	s.messages = append(s.messages, smtp.SentMessage{
+		Message:   msg,
+		EventID:   eventID,
+		RequestID: contextmanager.RequestIDFromContext(ctx),
+		SentAt:    time.Now(),
+	})
+	if len(s.messages) > capacity {
+		s.messages = slices.Delete(s.messages, 0, len(s.messages)-capacity)
+	}
+	return nil
+}
+
+// Messages returns kept messages, the latest one goes first.
+func (s *MemorySender) Messages() []smtp.SentMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	res := slices.Clone(s.messages)
+	slices.Reverse(res)
+	return res
+}
+
+func (s *MemorySender) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages = nil
+}
@@ -0,0 +1,99 @@
+package memory_test
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"task-trail/internal/pkg/contextmanager"
+	"task-trail/internal/pkg/smtp"
+	"task-trail/internal/pkg/smtp/memory"
+	"testing"
+)
+
+func TestSendAndMessages(t *testing.T) {
+	s := memory.New()
+	ctx := contextmanager.WithRequestID(context.Background(), "request")
+	recipients := []string{"first@test.test"}
+	first := smtp.Message{Subject: "first", Recipients: recipients, Text: "text", HTML: "<p>html</p>"}
+	second := smtp.Message{Subject: "second", Recipients: []string{"second@test.test"}, Text: "text"}
+	if err := s.Send(ctx, first, "first-event"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := s.Send(context.Background(), second, "second-event"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// changes of the caller don't affect kept message
+	recipients[0] = "changed@test.test"
+
+	got := s.Messages()
+	if len(got) != 2 {
+		t.Fatalf("messages = %d, want 2", len(got))
+	}
+	if got[0].Subject != "second" || got[0].EventID != "second-event" || got[0].RequestID != "" {
+		t.Errorf("latest message = %+v, want second one without request id", got[0])
+	}
+	if got[1].Subject != "first" || got[1].EventID != "first-event" || got[1].RequestID != "request" {
+		t.Errorf("first message = %+v, want first one with request id", got[1])
+	}
+	if got[1].Recipients[0] != "first@test.test" || got[1].Text != "text" || got[1].HTML != "<p>html</p>" {
+		t.Errorf("first message = %+v, want it unchanged", got[1])
+	}
+	if got[1].SentAt.IsZero() {
+		t.Error("sent time is not set")
+	}
+
+	s.Clear()
+	if got := s.Messages(); len(got) != 0 {
+		t.Errorf("messages after clear = %d, want 0", len(got))
+	}
+}
+
+func TestCapacity(t *testing.T) {
+	s := memory.New()
+	for i := range 150 {
+		msg := smtp.Message{Subject: strconv.Itoa(i), Recipients: []string{"first@test.test"}}
+		if err := s.Send(context.Background(), msg, strconv.Itoa(i)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	got := s.Messages()
+	if len(got) != 100 {
+		t.Fatalf("messages = %d, want 100", len(got))
+	}
+	// the oldest messages are dropped
+	if got[0].EventID != "149" || got[len(got)-1].EventID != "50" {
+		t.Errorf("kept messages = %s..%s, want 149..50", got[0].EventID, got[len(got)-1].EventID)
+	}
+}
+
+func TestConcurrentSend(t *testing.T) {
+	const senders, perSender = 5, 10
+	s := memory.New()
+	var wg sync.WaitGroup
+	for i := range senders {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range perSender {
+				msg := smtp.Message{Subject: "subject", Recipients: []string{"first@test.test"}}
+				if err := s.Send(context.Background(), msg, strconv.Itoa(i*perSender+j)); err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				_ = s.Messages()
+			}
+		}()
+	}
+	wg.Wait()
+
+	got := s.Messages()
+	if len(got) != senders*perSender {
+		t.Fatalf("messages = %d, want %d", len(got), senders*perSender)
+	}
+	seen := make(map[string]bool, len(got))
+	for _, m := range got {
+		if seen[m.EventID] {
+			t.Errorf("message %s is kept twice", m.EventID)
+		}
+		seen[m.EventID] = true
+	}
+}
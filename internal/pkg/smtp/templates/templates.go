@@ -0,0 +1,122 @@
+package templates
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"slices"
+	"strings"
+	texttemplate "text/template"
+)
+
+// template names, each of them has <locale>/<name>.txt with "subject" and "text" blocks
+// and <locale>/<name>.html with "content" block rendered inside the common layout
+const (
+	Verification      = "verification"
+	ResetPassword     = "reset_password"
+	Activation        = "activation"
+	ProjectInvite     = "project_invite"
+	EmailChange       = "email_change"
+	EmailChangeNotice = "email_change_notice"
+	Digest            = "digest"
+)
+
+// Locales contains all supported locales.
+var Locales = []string{"en", "ru"}
+
+var names = []string{
+	Verification,
+	ResetPassword,
+	Activation,
+	ProjectInvite,
+	EmailChange,
+	EmailChangeNotice,
+	Digest,
+}
+
+//go:embed files
+var files embed.FS
+
+// Rendered is the email content ready to be sent.
+type Rendered struct {
+	Subject string
+	Text    string
+	HTML    string
+}
+
+type button struct {
+	URL   string
+	Label string
+}
+
+type layoutData struct {
+	Locale  string
+	Subject string
+	Data    any
+}
+
+type Renderer struct {
+	defaultLocale string
+	text          map[string]*texttemplate.Template
+	html          map[string]*htmltemplate.Template
+}
+
+// New parses all embedded templates, defaultLocale is used when the requested one is not supported.
+func New(defaultLocale string) (*Renderer, error) {
+	if !slices.Contains(Locales, defaultLocale) {
+		return nil, fmt.Errorf("unsupported default locale: %s", defaultLocale)
+	}
+	r := &Renderer{
+		defaultLocale: defaultLocale,
+		text:          make(map[string]*texttemplate.Template),
+		html:          make(map[string]*htmltemplate.Template),
+	}
+	funcs := htmltemplate.FuncMap{
+		"button": func(url string, label string) button { return button{URL: url, Label: label} },
+	}
+	for _, locale := range Locales {
+		for _, name := range names {
+			key := locale + "/" + name
+			t, err := texttemplate.ParseFS(files, "files/"+key+".txt")
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse text template %s: %w", key, err)
+			}
+			h, err := htmltemplate.New(name).Funcs(funcs).ParseFS(files, "files/layout.html", "files/button.html", "files/"+key+".html")
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse html template %s: %w", key, err)
+			}
+			r.text[key] = t
+			r.html[key] = h
+		}
+	}
+	return r, nil
+}
+
+// Render renders template with the given name for the locale, data is passed to all template blocks.
+func (r *Renderer) Render(locale string, name string, data any) (*Rendered, error) {
+	if !slices.Contains(Locales, locale) {
+		locale = r.defaultLocale
+	}
+	key := locale + "/" + name
+	t, ok := r.text[key]
+	if !ok {
+		return nil, fmt.Errorf("template %s not found", key)
+	}
+	var subject, text, html bytes.Buffer
+	if err := t.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, fmt.Errorf("failed to render subject %s: %w", key, err)
+	}
+	if err := t.ExecuteTemplate(&text, "text", data); err != nil {
+		return nil, fmt.Errorf("failed to render text %s: %w", key, err)
+	}
+	l := layoutData{Locale: locale, Subject: subject.String(), Data: data}
+	if err := r.html[key].ExecuteTemplate(&html, "layout", l); err != nil {
+		return nil, fmt.Errorf("failed to render html %s: %w", key, err)
+	}
+	return &Rendered{
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    strings.TrimSpace(text.String()),
+		HTML:    html.String(),
+	}, nil
+}
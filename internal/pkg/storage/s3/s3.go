@@ -78,3 +78,12 @@ func (s *Service) Delete(ctx context.Context, name string) error {
 func (s *Service) GetPath(name string) string {
 	return fmt.Sprintf("%s/%s/%s", s.publicURL, s.bucket, name)
 }
+
+// Check verifies the bucket exists and is accessible, it is used by readiness probe.
+func (s *Service) Check(ctx context.Context) error {
+	_, err := s.client.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: aws.String(s.bucket)})
+	if err != nil {
+		return fmt.Errorf("cant access bucket: %w", err)
+	}
+	return nil
+}
@@ -1,12 +1,16 @@
 package token
 
-import "task-trail/internal/usecase/dto"
+import (
+	"task-trail/internal/usecase/dto"
+	"time"
+)
 
 type Service interface {
 	// Generate access token by user id
 	GenAccessToken(userID int) (*dto.AccessTokenRes, error)
 	// Generate refresh token and jti by user id
 	GenRefreshToken(userID int) (*dto.RefreshTokenRes, error)
-	VerifyAccessToken(token string) (userID int, err error)
+	// Verify access token and return user id and expiration time of the token
+	VerifyAccessToken(token string) (userID int, exp time.Time, err error)
 	VerifyRefreshToken(token string) (userID int, jti string, err error)
 }
@@ -75,7 +75,7 @@ func (s *jwtService) genToken(claims jwt.Claims) (string, error) {
 	return token.SignedString(s.acSecret)
 }
 
-func (s *jwtService) VerifyAccessToken(token string) (userID int, err error) {
+func (s *jwtService) VerifyAccessToken(token string) (userID int, exp time.Time, err error) {
 	claims, err := s.verifyToken(token, s.acSecret)
 	if err != nil {
 		return
@@ -84,6 +84,10 @@ func (s *jwtService) VerifyAccessToken(token string) (userID int, err error) {
 	if err != nil {
 		return
 	}
+	exp, err = s.extractExp(claims)
+	if err != nil {
+		return
+	}
 	return
 }
 func (s *jwtService) VerifyRefreshToken(token string) (userID int, jti string, err error) {
@@ -128,6 +132,17 @@ func (s *jwtService) extractSub(claims jwt.MapClaims) (int, error) {
 	return v, nil
 }
 
+func (s *jwtService) extractExp(claims jwt.MapClaims) (time.Time, error) {
+	exp, err := claims.GetExpirationTime()
+	if err != nil {
+		return time.Time{}, err
+	}
+	if exp == nil {
+		return time.Time{}, fmt.Errorf("exp is missing")
+	}
+	return exp.Time, nil
+}
+
 func (s *jwtService) extractClaim(claims jwt.MapClaims, name string) (string, error) {
 	claim, ok := claims[name].(string)
 	if !ok {
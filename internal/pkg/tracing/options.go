@@ -0,0 +1,31 @@
+package tracing
+
+type Option func(*Provider)
+
+// Endpoint sets host:port of the OTLP/HTTP collector.
+func Endpoint(e string) Option {
+	return func(p *Provider) {
+		p.endpoint = e
+	}
+}
+
+// Insecure disables TLS of the connection to the collector.
+func Insecure(i bool) Option {
+	return func(p *Provider) {
+		p.insecure = i
+	}
+}
+
+// SampleRatio sets share of recorded root traces, sampling decision of the caller is respected.
+func SampleRatio(r float64) Option {
+	return func(p *Provider) {
+		p.sampleRatio = r
+	}
+}
+
+// Version sets version of the service reported with spans.
+func Version(v string) Option {
+	return func(p *Provider) {
+		p.version = v
+	}
+}
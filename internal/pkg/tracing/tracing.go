@@ -0,0 +1,114 @@
+// Package tracing configures OpenTelemetry tracing with W3C trace context propagation,
+// spans are exported via OTLP or written to stdout for local use.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// span exporters
+const (
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+	ExporterNone   = "none"
+)
+
+const (
+	_defEndpoint    = "localhost:4318"
+	_defSampleRatio = 1
+)
+
+// Provider owns the global tracer provider, spans are flushed on shutdown.
+type Provider struct {
+	endpoint    string
+	insecure    bool
+	sampleRatio float64
+	version     string
+
+	tp *sdktrace.TracerProvider
+}
+
+// New installs the global tracer provider and W3C propagator.
+// With the none exporter spans are not recorded, but incoming trace context is still propagated.
+func New(ctx context.Context, serviceName string, exporter string, opts ...Option) (*Provider, error) {
+	p := &Provider{
+		endpoint:    _defEndpoint,
+		sampleRatio: _defSampleRatio,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var (
+		exp sdktrace.SpanExporter
+		err error
+	)
+	switch exporter {
+	case ExporterOTLP:
+		clientOpts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(p.endpoint)}
+		if p.insecure {
+			clientOpts = append(clientOpts, otlptracehttp.WithInsecure())
+		}
+		exp, err = otlptracehttp.New(ctx, clientOpts...)
+	case ExporterStdout:
+		exp, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+	case ExporterNone:
+		return p, nil
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter: %s", exporter)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	attrs := []attribute.KeyValue{semconv.ServiceName(serviceName)}
+	if p.version != "" {
+		attrs = append(attrs, semconv.ServiceVersion(p.version))
+	}
+	p.tp = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exp),
+		sdktrace.WithResource(resource.NewSchemaless(attrs...)),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(p.sampleRatio))),
+	)
+	otel.SetTracerProvider(p.tp)
+	return p, nil
+}
+
+// Shutdown exports buffered spans and stops the provider.
+func (p *Provider) Shutdown(ctx context.Context) error {
+	if p.tp == nil {
+		return nil
+	}
+	return p.tp.Shutdown(ctx)
+}
+
+// TraceID returns id of the trace the ctx belongs to, empty string if there is no trace.
+func TraceID(ctx context.Context) string {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.HasTraceID() {
+		return ""
+	}
+	return sc.TraceID().String()
+}
+
+// End records the error on the span and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,38 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// request headers of the webhook delivery
+const (
+	HeaderEvent     = "X-TaskTrail-Event"
+	HeaderDelivery  = "X-TaskTrail-Delivery"
+	HeaderSignature = "X-TaskTrail-Signature"
+)
+
+type Request struct {
+	URL    string
+	Secret string
+	// EventID is the unique delivery identifier, the same for all attempts
+	EventID   string
+	EventType string
+	// Body is the JSON encoded event
+	Body []byte
+}
+
+type Sender interface {
+	// Send posts the signed request and returns the response status code.
+	// Error is returned if the request failed or the status code is not 2xx.
+	Send(ctx context.Context, req *Request) (int, error)
+}
+
+// Sign returns the signature header value: "sha256=" followed by hex encoded HMAC-SHA256 of the body.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
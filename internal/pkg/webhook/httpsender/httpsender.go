@@ -0,0 +1,106 @@
+package httpsender
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"task-trail/internal/pkg/webhook"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+const userAgent = "TaskTrail-Webhook/1.0"
+
+// ErrPrivateAddress is returned when the webhook URL resolves to the address of private network.
+var ErrPrivateAddress = errors.New("address of private network is not allowed")
+
+// blockedPrefixes are special-purpose ranges not covered by netip.Addr methods
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+type Sender struct {
+	client       *http.Client
+	allowPrivate bool
+}
+
+func New(timeout time.Duration, opts ...Option) *Sender {
+	s := &Sender{}
+	for _, opt := range opts {
+		opt(s)
+	}
+	dialer := &net.Dialer{Timeout: timeout}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if !s.allowPrivate {
+		// address is checked after DNS resolution, so names pointing to internal services are rejected too,
+		// proxy is not used because its address would be checked instead of the receiver one
+		dialer.Control = checkAddress
+		transport.Proxy = nil
+	}
+	transport.DialContext = dialer.DialContext
+	s.client = &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		// redirects are not followed, receiver must respond on the configured URL
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+	return s
+}
+
+func (s *Sender) Send(ctx context.Context, req *webhook.Request) (int, error) {
+	r, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Body))
+	if err != nil {
+		return 0, err
+	}
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("User-Agent", userAgent)
+	r.Header.Set(webhook.HeaderEvent, req.EventType)
+	r.Header.Set(webhook.HeaderDelivery, req.EventID)
+	r.Header.Set(webhook.HeaderSignature, webhook.Sign(req.Secret, req.Body))
+	// receivers instrumented with OpenTelemetry continue the trace of the delivery
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(r.Header))
+
+	res, err := s.client.Do(r)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	// drain body to reuse the connection
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 1<<16))
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return res.StatusCode, fmt.Errorf("unexpected response status: %s", res.Status)
+	}
+	return res.StatusCode, nil
+}
+
+// checkAddress rejects connections to loopback, private, link-local and other special-purpose addresses.
+func checkAddress(_, address string, _ syscall.RawConn) error {
+	ap, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	addr := ap.Addr().Unmap()
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsMulticast() || addr.IsUnspecified() {
+		return fmt.Errorf("%s: %w", addr, ErrPrivateAddress)
+	}
+	for _, p := range blockedPrefixes {
+		if p.Contains(addr) {
+			return fmt.Errorf("%s: %w", addr, ErrPrivateAddress)
+		}
+	}
+	return nil
+}
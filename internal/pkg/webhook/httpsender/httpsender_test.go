@@ -0,0 +1,69 @@
+package httpsender_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"task-trail/internal/pkg/webhook"
+	"task-trail/internal/pkg/webhook/httpsender"
+	"testing"
+	"time"
+)
+
+func TestSend(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name    string
+		url     string
+		opts    []httpsender.Option
+		status  int
+		private bool
+	}{
+		{
+			name:    "loopback is rejected by default",
+			url:     srv.URL,
+			private: true,
+		},
+		{
+			name:    "name resolving to loopback is rejected",
+			url:     "http://localhost:1/hook",
+			private: true,
+		},
+		{
+			name:    "link-local metadata address is rejected",
+			url:     "http://169.254.169.254/latest/meta-data",
+			private: true,
+		},
+		{
+			name:    "private address is rejected",
+			url:     "http://10.0.0.1/hook",
+			private: true,
+		},
+		{
+			name:   "loopback is allowed with private networks",
+			url:    srv.URL,
+			opts:   []httpsender.Option{httpsender.AllowPrivateNetworks(true)},
+			status: http.StatusNoContent,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := httpsender.New(time.Second, tt.opts...)
+			status, err := s.Send(context.Background(), &webhook.Request{URL: tt.url, Body: []byte("{}")})
+			if got := errors.Is(err, httpsender.ErrPrivateAddress); got != tt.private {
+				t.Fatalf("private address error = %v, want %v: %v", got, tt.private, err)
+			}
+			if !tt.private && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if status != tt.status {
+				t.Errorf("status = %d, want %d", status, tt.status)
+			}
+		})
+	}
+}
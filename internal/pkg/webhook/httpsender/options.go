@@ -0,0 +1,11 @@
+package httpsender
+
+type Option func(*Sender)
+
+// AllowPrivateNetworks lets webhooks reach loopback, private and link-local addresses,
+// it is enabled for local testing with stub receivers.
+func AllowPrivateNetworks(a bool) Option {
+	return func(s *Sender) {
+		s.allowPrivate = a
+	}
+}
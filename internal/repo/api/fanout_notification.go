@@ -0,0 +1,140 @@
+package api
+
+import (
+	"context"
+	"task-trail/internal/repo"
+	"task-trail/internal/usecase/dto"
+)
+
+// FanoutNotificationRepo delivers notifications to both email and in-app channels
+// according to recipients notification preferences. Emails of users subscribed to digests
+// are collected and sent later in the summary.
+// Security related notifications, like verification or password reset, are always sent by email only.
+type FanoutNotificationRepo struct {
+	email      repo.NotificationRepository
+	inApp      repo.InAppNotificationRepository
+	preference repo.NotificationPreferenceRepository
+	digest     repo.NotificationDigestRepository
+	userRepo   repo.UserRepository
+}
+
+func NewFanoutNotificationRepo(
+	email repo.NotificationRepository,
+	inApp repo.InAppNotificationRepository,
+	preference repo.NotificationPreferenceRepository,
+	digest repo.NotificationDigestRepository,
+	userRepo repo.UserRepository,
+) *FanoutNotificationRepo {
+	return &FanoutNotificationRepo{
+		email:      email,
+		inApp:      inApp,
+		preference: preference,
+		digest:     digest,
+		userRepo:   userRepo,
+	}
+}
+
+func (r *FanoutNotificationRepo) SendVerificationEmail(ctx context.Context, email string, token string) error {
+	return r.email.SendVerificationEmail(ctx, email, token)
+}
+
+func (r *FanoutNotificationRepo) SendResetPasswordEmail(ctx context.Context, email string, token string) error {
+	return r.email.SendResetPasswordEmail(ctx, email, token)
+}
+
+func (r *FanoutNotificationRepo) SendAutoRegisterEmail(ctx context.Context, email string, token string) error {
+	return r.email.SendAutoRegisterEmail(ctx, email, token)
+}
+
+func (r *FanoutNotificationRepo) SendEmailChangeEmail(ctx context.Context, email string, token string) error {
+	return r.email.SendEmailChangeEmail(ctx, email, token)
+}
+
+func (r *FanoutNotificationRepo) SendEmailChangeNotice(ctx context.Context, data *dto.NotificationEmailChange) error {
+	return r.email.SendEmailChangeNotice(ctx, data)
+}
+
+func (r *FanoutNotificationRepo) SendInvintationInProject(ctx context.Context, data *dto.NotificationProjectInvite) error {
+	n := &dto.NotificationCreate{
+		Type:      dto.NotificationTypeProjectInvite,
+		ProjectID: &data.ProjectID,
+		Payload:   map[string]string{"projectName": data.ProjectName},
+	}
+	recipients, err := r.deliver(ctx, data.Recipients, n)
+	if err != nil || len(recipients) == 0 {
+		return err
+	}
+	email := *data
+	email.Recipients = recipients
+	return r.email.SendInvintationInProject(ctx, &email)
+}
+
+// deliver creates a copy of notification for every registered recipient with enabled in-app channel
+// and collects it for recipients subscribed to digests.
+// Returns recipients that should receive the notification by email immediately:
+// unknown addresses and users with enabled email channel without digest.
+func (r *FanoutNotificationRepo) deliver(ctx context.Context, recipients []string, n *dto.NotificationCreate) ([]string, error) {
+	if len(recipients) == 0 {
+		return nil, nil
+	}
+	users, err := r.userRepo.GetIdsByEmails(ctx, recipients)
+	if err != nil {
+		return nil, err
+	}
+	if len(users) == 0 {
+		return recipients, nil
+	}
+	userIDs := make([]int, 0, len(users))
+	emails := make(map[string]int, len(users))
+	for _, u := range users {
+		userIDs = append(userIDs, u.ID)
+		emails[u.Email] = u.ID
+	}
+
+	inAppIDs, err := r.preference.GetEnabled(ctx, userIDs, n.Type, dto.NotificationChannelInApp)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.inApp.Create(ctx, copyForUsers(n, inAppIDs)); err != nil {
+		return nil, err
+	}
+
+	emailIDs, err := r.preference.GetEnabled(ctx, userIDs, n.Type, dto.NotificationChannelEmail)
+	if err != nil {
+		return nil, err
+	}
+	digests, err := r.preference.GetDigests(ctx, emailIDs)
+	if err != nil {
+		return nil, err
+	}
+	immediate := make(map[int]bool, len(emailIDs))
+	digestIDs := make([]int, 0, len(emailIDs))
+	for _, ID := range emailIDs {
+		if digest, ok := digests[ID]; ok && digest != dto.DigestNone {
+			digestIDs = append(digestIDs, ID)
+			continue
+		}
+		immediate[ID] = true
+	}
+	if err := r.digest.Create(ctx, copyForUsers(n, digestIDs)); err != nil {
+		return nil, err
+	}
+
+	retVal := make([]string, 0, len(recipients))
+	for _, email := range recipients {
+		if ID, ok := emails[email]; !ok || immediate[ID] {
+			retVal = append(retVal, email)
+		}
+	}
+	return retVal, nil
+}
+
+func copyForUsers(n *dto.NotificationCreate, userIDs []int) []*dto.NotificationCreate {
+	retVal := make([]*dto.NotificationCreate, 0, len(userIDs))
+	for _, ID := range userIDs {
+		item := *n
+		item.UserID = ID
+		retVal = append(retVal, &item)
+	}
+	return retVal
+}
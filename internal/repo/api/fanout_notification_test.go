@@ -0,0 +1,222 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"task-trail/internal/repo"
+	"task-trail/internal/repo/api"
+	"task-trail/internal/usecase/dto"
+	"task-trail/test/mocks"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+type fanoutDeps struct {
+	email      *mocks.MockNotificationRepository
+	inApp      *mocks.MockInAppNotificationRepository
+	preference *mocks.MockNotificationPreferenceRepository
+	digest     *mocks.MockNotificationDigestRepository
+	userRepo   *mocks.MockUserRepository
+}
+
+func mockFanoutRepo(ctrl *gomock.Controller) (*api.FanoutNotificationRepo, *fanoutDeps) {
+	email := mocks.NewMockNotificationRepository(ctrl)
+	inApp := mocks.NewMockInAppNotificationRepository(ctrl)
+	preference := mocks.NewMockNotificationPreferenceRepository(ctrl)
+	digest := mocks.NewMockNotificationDigestRepository(ctrl)
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	r := api.NewFanoutNotificationRepo(email, inApp, preference, digest, userRepo)
+	return r, &fanoutDeps{email: email, inApp: inApp, preference: preference, digest: digest, userRepo: userRepo}
+}
+
+func TestFanoutSendInvintationInProject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	recipients := []string{"first@test.test", "second@test.test", "third@test.test", "unknown@test.test"}
+	data := &dto.NotificationProjectInvite{ProjectID: 1, ProjectName: "Alpha", Recipients: recipients}
+	users := []*dto.UserEmailAndID{
+		{ID: 1, Email: "first@test.test"},
+		{ID: 2, Email: "second@test.test"},
+		{ID: 3, Email: "third@test.test"},
+	}
+	userIDs := []int{1, 2, 3}
+	projectID := 1
+	// notifications is the invitation copied for every given user
+	notifications := func(IDs ...int) []*dto.NotificationCreate {
+		retVal := make([]*dto.NotificationCreate, 0, len(IDs))
+		for _, ID := range IDs {
+			retVal = append(retVal, &dto.NotificationCreate{
+				UserID:    ID,
+				Type:      dto.NotificationTypeProjectInvite,
+				ProjectID: &projectID,
+				Payload:   map[string]string{"projectName": "Alpha"},
+			})
+		}
+		return retVal
+	}
+	invite := func(recipients ...string) *dto.NotificationProjectInvite {
+		return &dto.NotificationProjectInvite{ProjectID: 1, ProjectName: "Alpha", Recipients: recipients}
+	}
+	repoErr := errors.New("db failure")
+
+	tests := []struct {
+		name    string
+		data    *dto.NotificationProjectInvite
+		repo    func(ctrl *gomock.Controller) *api.FanoutNotificationRepo
+		wantErr error
+	}{
+		{
+			name: "channels are chosen by preferences",
+			data: data,
+			repo: func(ctrl *gomock.Controller) *api.FanoutNotificationRepo {
+				r, deps := mockFanoutRepo(ctrl)
+				deps.userRepo.EXPECT().GetIdsByEmails(ctx, recipients).Return(users, nil)
+				deps.preference.EXPECT().GetEnabled(ctx, userIDs, dto.NotificationTypeProjectInvite, dto.NotificationChannelInApp).Return([]int{1, 2}, nil)
+				deps.inApp.EXPECT().Create(ctx, notifications(1, 2)).Return(nil)
+				deps.preference.EXPECT().GetEnabled(ctx, userIDs, dto.NotificationTypeProjectInvite, dto.NotificationChannelEmail).Return([]int{1, 2}, nil)
+				deps.preference.EXPECT().GetDigests(ctx, []int{1, 2}).Return(map[int]dto.DigestFrequency{1: dto.DigestDaily, 2: dto.DigestNone}, nil)
+				deps.digest.EXPECT().Create(ctx, notifications(1)).Return(nil)
+				// third user disabled emails, unknown address has no preferences
+				deps.email.EXPECT().SendInvintationInProject(ctx, invite("second@test.test", "unknown@test.test")).Return(nil)
+				return r
+			},
+		},
+		{
+			name: "unknown recipients receive email",
+			data: invite("unknown@test.test"),
+			repo: func(ctrl *gomock.Controller) *api.FanoutNotificationRepo {
+				r, deps := mockFanoutRepo(ctrl)
+				deps.userRepo.EXPECT().GetIdsByEmails(ctx, []string{"unknown@test.test"}).Return(nil, nil)
+				deps.email.EXPECT().SendInvintationInProject(ctx, invite("unknown@test.test")).Return(nil)
+				return r
+			},
+		},
+		{
+			name: "no email if all recipients get digest",
+			data: invite("first@test.test"),
+			repo: func(ctrl *gomock.Controller) *api.FanoutNotificationRepo {
+				r, deps := mockFanoutRepo(ctrl)
+				deps.userRepo.EXPECT().GetIdsByEmails(ctx, []string{"first@test.test"}).Return(users[:1], nil)
+				deps.preference.EXPECT().GetEnabled(ctx, []int{1}, dto.NotificationTypeProjectInvite, dto.NotificationChannelInApp).Return(nil, nil)
+				deps.inApp.EXPECT().Create(ctx, notifications()).Return(nil)
+				deps.preference.EXPECT().GetEnabled(ctx, []int{1}, dto.NotificationTypeProjectInvite, dto.NotificationChannelEmail).Return([]int{1}, nil)
+				deps.preference.EXPECT().GetDigests(ctx, []int{1}).Return(map[int]dto.DigestFrequency{1: dto.DigestWeekly}, nil)
+				deps.digest.EXPECT().Create(ctx, notifications(1)).Return(nil)
+				return r
+			},
+		},
+		{
+			name: "no recipients",
+			data: invite(),
+			repo: func(ctrl *gomock.Controller) *api.FanoutNotificationRepo {
+				r, _ := mockFanoutRepo(ctrl)
+				return r
+			},
+		},
+		{
+			name: "failed to get users",
+			data: data,
+			repo: func(ctrl *gomock.Controller) *api.FanoutNotificationRepo {
+				r, deps := mockFanoutRepo(ctrl)
+				deps.userRepo.EXPECT().GetIdsByEmails(ctx, recipients).Return(nil, repoErr)
+				return r
+			},
+			wantErr: repoErr,
+		},
+		{
+			name: "failed to create in-app notifications",
+			data: data,
+			repo: func(ctrl *gomock.Controller) *api.FanoutNotificationRepo {
+				r, deps := mockFanoutRepo(ctrl)
+				deps.userRepo.EXPECT().GetIdsByEmails(ctx, recipients).Return(users, nil)
+				deps.preference.EXPECT().GetEnabled(ctx, userIDs, dto.NotificationTypeProjectInvite, dto.NotificationChannelInApp).Return([]int{1}, nil)
+				deps.inApp.EXPECT().Create(ctx, notifications(1)).Return(repoErr)
+				return r
+			},
+			wantErr: repoErr,
+		},
+		{
+			name: "failed to get digests",
+			data: data,
+			repo: func(ctrl *gomock.Controller) *api.FanoutNotificationRepo {
+				r, deps := mockFanoutRepo(ctrl)
+				deps.userRepo.EXPECT().GetIdsByEmails(ctx, recipients).Return(users, nil)
+				deps.preference.EXPECT().GetEnabled(ctx, userIDs, dto.NotificationTypeProjectInvite, dto.NotificationChannelInApp).Return(nil, nil)
+				deps.inApp.EXPECT().Create(ctx, notifications()).Return(nil)
+				deps.preference.EXPECT().GetEnabled(ctx, userIDs, dto.NotificationTypeProjectInvite, dto.NotificationChannelEmail).Return([]int{1}, nil)
+				deps.preference.EXPECT().GetDigests(ctx, []int{1}).Return(nil, repoErr)
+				return r
+			},
+			wantErr: repoErr,
+		},
+		{
+			name: "failed to collect digest events",
+			data: data,
+			repo: func(ctrl *gomock.Controller) *api.FanoutNotificationRepo {
+				r, deps := mockFanoutRepo(ctrl)
+				deps.userRepo.EXPECT().GetIdsByEmails(ctx, recipients).Return(users, nil)
+				deps.preference.EXPECT().GetEnabled(ctx, userIDs, dto.NotificationTypeProjectInvite, dto.NotificationChannelInApp).Return(nil, nil)
+				deps.inApp.EXPECT().Create(ctx, notifications()).Return(nil)
+				deps.preference.EXPECT().GetEnabled(ctx, userIDs, dto.NotificationTypeProjectInvite, dto.NotificationChannelEmail).Return([]int{1}, nil)
+				deps.preference.EXPECT().GetDigests(ctx, []int{1}).Return(map[int]dto.DigestFrequency{1: dto.DigestDaily}, nil)
+				deps.digest.EXPECT().Create(ctx, notifications(1)).Return(repoErr)
+				return r
+			},
+			wantErr: repoErr,
+		},
+		{
+			name: "failed to send email",
+			data: invite("unknown@test.test"),
+			repo: func(ctrl *gomock.Controller) *api.FanoutNotificationRepo {
+				r, deps := mockFanoutRepo(ctrl)
+				deps.userRepo.EXPECT().GetIdsByEmails(ctx, gomock.Any()).Return(nil, nil)
+				deps.email.EXPECT().SendInvintationInProject(ctx, gomock.Any()).Return(repo.ErrInternal)
+				return r
+			},
+			wantErr: repo.ErrInternal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := tt.repo(ctrl)
+			err := r.SendInvintationInProject(ctx, tt.data)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFanoutSecurityEmails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	// security emails skip preferences and are sent by email only
+	r, deps := mockFanoutRepo(ctrl)
+	deps.email.EXPECT().SendVerificationEmail(ctx, "first@test.test", "token").Return(nil)
+	deps.email.EXPECT().SendResetPasswordEmail(ctx, "first@test.test", "token").Return(nil)
+	deps.email.EXPECT().SendAutoRegisterEmail(ctx, "first@test.test", "token").Return(nil)
+	deps.email.EXPECT().SendEmailChangeEmail(ctx, "new@test.test", "token").Return(nil)
+	notice := &dto.NotificationEmailChange{Recipient: "first@test.test", NewEmail: "new@test.test", TokenID: "token"}
+	deps.email.EXPECT().SendEmailChangeNotice(ctx, notice).Return(repo.ErrInternal)
+
+	if err := r.SendVerificationEmail(ctx, "first@test.test", "token"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := r.SendResetPasswordEmail(ctx, "first@test.test", "token"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := r.SendAutoRegisterEmail(ctx, "first@test.test", "token"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := r.SendEmailChangeEmail(ctx, "new@test.test", "token"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := r.SendEmailChangeNotice(ctx, notice); !errors.Is(err, repo.ErrInternal) {
+		t.Errorf("error = %v, want %v", err, repo.ErrInternal)
+	}
+}
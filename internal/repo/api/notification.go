@@ -2,82 +2,138 @@ package api
 
 import (
 	"context"
-	"fmt"
 	"strconv"
-	"task-trail/internal/pkg/logger"
-	"task-trail/internal/pkg/smtp"
+	"task-trail/internal/pkg/contextmanager"
+	"task-trail/internal/pkg/smtp/templates"
 	"task-trail/internal/pkg/uuid"
 	"task-trail/internal/repo"
 	"task-trail/internal/usecase/dto"
 )
 
+// SmtpNotificationRepo renders emails and stores them in the outbox,
+// so they are sent only if the surrounding transaction is committed.
 type SmtpNotificationRepo struct {
-	sender           smtp.Sender
-	logger           logger.Logger
+	outboxRepo       repo.OutboxRepository
 	uuidGenerator    uuid.Generator
+	renderer         *templates.Renderer
+	userRepo         repo.UserRepository
 	verificationUrl  string
 	resetPasswordURL string
 	projectURL       string
+	emailChangeURL   string
+	emailCancelURL   string
+	activationURL    string
 }
 
 func NewSmtpNotificationRepo(
-	sender smtp.Sender,
-	logger logger.Logger,
+	outboxRepo repo.OutboxRepository,
 	uuidGenerator uuid.Generator,
+	renderer *templates.Renderer,
+	userRepo repo.UserRepository,
 	verificationUrl string,
 	resetPasswordURL string,
 	projectURL string,
+	emailChangeURL string,
+	emailCancelURL string,
+	activationURL string,
 ) *SmtpNotificationRepo {
 	return &SmtpNotificationRepo{
-		sender:           sender,
-		logger:           logger,
+		outboxRepo:       outboxRepo,
 		uuidGenerator:    uuidGenerator,
+		renderer:         renderer,
+		userRepo:         userRepo,
 		verificationUrl:  verificationUrl,
 		resetPasswordURL: resetPasswordURL,
 		projectURL:       projectURL,
+		emailChangeURL:   emailChangeURL,
+		emailCancelURL:   emailCancelURL,
+		activationURL:    activationURL,
 	}
 }
 
+type urlData struct {
+	URL string
+}
+
+type projectInviteData struct {
+	ProjectName string
+	URL         string
+}
+
+type emailChangeNoticeData struct {
+	NewEmail string
+	URL      string
+}
+
 func (r *SmtpNotificationRepo) SendVerificationEmail(ctx context.Context, email string, token string) error {
-	msg := smtp.Message{
-		Recipients: []string{email},
-		Subject:    "Account Verification",
-		Text:       r.verificationUrl + token + "&email=" + email,
-	}
-	return r.send(msg)
+	data := urlData{URL: r.verificationUrl + token + "&email=" + email}
+	return r.send(ctx, []string{email}, templates.Verification, data)
 }
 
 func (r *SmtpNotificationRepo) SendResetPasswordEmail(ctx context.Context, email string, token string) error {
-	msg := smtp.Message{
-		Recipients: []string{email},
-		Subject:    "Reset password",
-		Text:       r.resetPasswordURL + token,
-	}
-	return r.send(msg)
+	data := urlData{URL: r.resetPasswordURL + token}
+	return r.send(ctx, []string{email}, templates.ResetPassword, data)
 }
 
-func (r *SmtpNotificationRepo) SendAutoRegisterEmail(ctx context.Context, email string) error {
-	msg := smtp.Message{
-		Recipients: []string{email},
-		Subject:    "Welcome to Task Trail",
-		Text:       "Welcome! You was automaticly registered. To enter in app, use reset password form on main page",
-	}
-	return r.send(msg)
+func (r *SmtpNotificationRepo) SendAutoRegisterEmail(ctx context.Context, email string, token string) error {
+	data := urlData{URL: r.activationURL + token}
+	return r.send(ctx, []string{email}, templates.Activation, data)
 }
 
 func (r *SmtpNotificationRepo) SendInvintationInProject(ctx context.Context, data *dto.NotificationProjectInvite) error {
-	url := r.projectURL + strconv.Itoa(data.ProjectID)
-	msg := smtp.Message{
-		Recipients: data.Recipients,
-		Subject:    fmt.Sprintf("Welcome to project: %s", data.ProjectName),
-		Text:       fmt.Sprintf("Hello! You have been invited to the project \"%s\". Follow the link to get to the project: %s", data.ProjectName, url),
-	}
-	return r.send(msg)
+	d := projectInviteData{ProjectName: data.ProjectName, URL: r.projectURL + strconv.Itoa(data.ProjectID)}
+	return r.send(ctx, data.Recipients, templates.ProjectInvite, d)
 }
-func (r *SmtpNotificationRepo) send(msg smtp.Message) error {
-	eventID := r.uuidGenerator.Generate()
-	if err := r.sender.Send(msg, eventID); err != nil {
-		return repo.Wrap(repo.ErrInternal, err)
+
+func (r *SmtpNotificationRepo) SendEmailChangeEmail(ctx context.Context, email string, token string) error {
+	data := urlData{URL: r.emailChangeURL + token}
+	return r.send(ctx, []string{email}, templates.EmailChange, data)
+}
+
+func (r *SmtpNotificationRepo) SendEmailChangeNotice(ctx context.Context, data *dto.NotificationEmailChange) error {
+	d := emailChangeNoticeData{NewEmail: data.NewEmail, URL: r.emailCancelURL + data.TokenID}
+	return r.send(ctx, []string{data.Recipient}, templates.EmailChangeNotice, d)
+}
+
+// send renders template in the preferred language of each recipient and puts it to the outbox,
+// recipients with the same language receive one message.
+func (r *SmtpNotificationRepo) send(ctx context.Context, recipients []string, name string, data any) error {
+	groups, err := r.groupByLocale(ctx, recipients)
+	if err != nil {
+		return err
+	}
+	for locale, group := range groups {
+		content, err := r.renderer.Render(locale, name, data)
+		if err != nil {
+			return repo.Wrap(repo.ErrInternal, err)
+		}
+		msg := &dto.OutboxMessageCreate{
+			EventID:    r.uuidGenerator.Generate(),
+			RequestID:  contextmanager.RequestIDFromContext(ctx),
+			Recipients: group,
+			Subject:    content.Subject,
+			Text:       content.Text,
+			HTML:       content.HTML,
+		}
+		if err := r.outboxRepo.Create(ctx, msg); err != nil {
+			return err
+		}
 	}
 	return nil
 }
+
+// groupByLocale groups recipients by their language, unknown recipients are grouped under empty locale,
+// so renderer uses the default one.
+// The lookup runs in the transaction of the caller, so its error is returned, the failed transaction can't continue.
+func (r *SmtpNotificationRepo) groupByLocale(ctx context.Context, recipients []string) (map[string][]string, error) {
+	languages, err := r.userRepo.GetLanguagesByEmails(ctx, recipients)
+	if err != nil {
+		return nil, err
+	}
+	retVal := make(map[string][]string)
+	for _, email := range recipients {
+		locale := languages[email]
+		retVal[locale] = append(retVal[locale], email)
+	}
+	return retVal, nil
+}
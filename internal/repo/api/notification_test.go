@@ -0,0 +1,168 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"task-trail/internal/pkg/contextmanager"
+	"task-trail/internal/pkg/smtp/templates"
+	"task-trail/internal/repo"
+	"task-trail/internal/repo/api"
+	"task-trail/internal/usecase/dto"
+	"task-trail/test/mocks"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+type smtpDeps struct {
+	outboxRepo    *mocks.MockOutboxRepository
+	uuidGenerator *mocks.MockGenerator
+	userRepo      *mocks.MockUserRepository
+}
+
+func mockSmtpRepo(t *testing.T, ctrl *gomock.Controller) (*api.SmtpNotificationRepo, *smtpDeps) {
+	renderer, err := templates.New("en")
+	if err != nil {
+		t.Fatalf("failed to create renderer: %v", err)
+	}
+	outboxRepo := mocks.NewMockOutboxRepository(ctrl)
+	uuidGenerator := mocks.NewMockGenerator(ctrl)
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	r := api.NewSmtpNotificationRepo(
+		outboxRepo,
+		uuidGenerator,
+		renderer,
+		userRepo,
+		"http://verify?token=",
+		"http://reset/",
+		"http://project/",
+		"http://change/",
+		"http://cancel/",
+		"http://activate/",
+	)
+	return r, &smtpDeps{outboxRepo: outboxRepo, uuidGenerator: uuidGenerator, userRepo: userRepo}
+}
+
+// outboxMessage matches message sent to the recipients with the subject starting with prefix
+func outboxMessage(recipients []string, subjectPrefix string) gomock.Matcher {
+	return gomock.Cond(func(m *dto.OutboxMessageCreate) bool {
+		return m.EventID == "uuid" &&
+			slices.Equal(m.Recipients, recipients) &&
+			strings.HasPrefix(m.Subject, subjectPrefix) &&
+			m.Text != "" &&
+			m.HTML != ""
+	})
+}
+
+func TestSmtpSendVerificationEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := contextmanager.WithRequestID(context.Background(), "request")
+	email := "first@test.test"
+	repoErr := errors.New("db failure")
+
+	tests := []struct {
+		name    string
+		repo    func(ctrl *gomock.Controller) *api.SmtpNotificationRepo
+		wantErr error
+	}{
+		{
+			name: "message is written to outbox",
+			repo: func(ctrl *gomock.Controller) *api.SmtpNotificationRepo {
+				r, deps := mockSmtpRepo(t, ctrl)
+				deps.userRepo.EXPECT().GetLanguagesByEmails(ctx, []string{email}).Return(map[string]string{}, nil)
+				deps.uuidGenerator.EXPECT().Generate().Return("uuid")
+				deps.outboxRepo.EXPECT().Create(ctx, gomock.Cond(func(m *dto.OutboxMessageCreate) bool {
+					return m.EventID == "uuid" &&
+						m.RequestID == "request" &&
+						slices.Equal(m.Recipients, []string{email}) &&
+						m.Subject == "Account verification" &&
+						strings.Contains(m.Text, "http://verify?token=token&email="+email)
+				})).Return(nil)
+				return r
+			},
+		},
+		{
+			name: "failed to get languages",
+			repo: func(ctrl *gomock.Controller) *api.SmtpNotificationRepo {
+				r, deps := mockSmtpRepo(t, ctrl)
+				deps.userRepo.EXPECT().GetLanguagesByEmails(ctx, []string{email}).Return(nil, repoErr)
+				return r
+			},
+			wantErr: repoErr,
+		},
+		{
+			name: "failed to write to outbox",
+			repo: func(ctrl *gomock.Controller) *api.SmtpNotificationRepo {
+				r, deps := mockSmtpRepo(t, ctrl)
+				deps.userRepo.EXPECT().GetLanguagesByEmails(ctx, []string{email}).Return(map[string]string{}, nil)
+				deps.uuidGenerator.EXPECT().Generate().Return("uuid")
+				deps.outboxRepo.EXPECT().Create(ctx, gomock.Any()).Return(repo.ErrInternal)
+				return r
+			},
+			wantErr: repo.ErrInternal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := tt.repo(ctrl)
+			err := r.SendVerificationEmail(ctx, email, "token")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSmtpSendInvintationInProject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	recipients := []string{"first@test.test", "second@test.test", "third@test.test", "fourth@test.test"}
+	data := &dto.NotificationProjectInvite{ProjectID: 1, ProjectName: "Alpha", Recipients: recipients}
+
+	tests := []struct {
+		name    string
+		repo    func(ctrl *gomock.Controller) *api.SmtpNotificationRepo
+		wantErr error
+	}{
+		{
+			name: "one message per language",
+			repo: func(ctrl *gomock.Controller) *api.SmtpNotificationRepo {
+				r, deps := mockSmtpRepo(t, ctrl)
+				// second user didn't choose the language, fourth one is unknown
+				languages := map[string]string{"first@test.test": "ru", "third@test.test": "en"}
+				deps.userRepo.EXPECT().GetLanguagesByEmails(ctx, recipients).Return(languages, nil)
+				deps.uuidGenerator.EXPECT().Generate().Return("uuid").Times(3)
+				deps.outboxRepo.EXPECT().Create(ctx, outboxMessage([]string{"first@test.test"}, "Приглашение в проект: Alpha")).Return(nil)
+				deps.outboxRepo.EXPECT().Create(ctx, outboxMessage([]string{"second@test.test", "fourth@test.test"}, "Welcome to project: Alpha")).Return(nil)
+				deps.outboxRepo.EXPECT().Create(ctx, outboxMessage([]string{"third@test.test"}, "Welcome to project: Alpha")).Return(nil)
+				return r
+			},
+		},
+		{
+			name: "unsupported language falls back to default",
+			repo: func(ctrl *gomock.Controller) *api.SmtpNotificationRepo {
+				r, deps := mockSmtpRepo(t, ctrl)
+				languages := map[string]string{"first@test.test": "de", "second@test.test": "de", "third@test.test": "de", "fourth@test.test": "de"}
+				deps.userRepo.EXPECT().GetLanguagesByEmails(ctx, recipients).Return(languages, nil)
+				deps.uuidGenerator.EXPECT().Generate().Return("uuid")
+				deps.outboxRepo.EXPECT().Create(ctx, outboxMessage(recipients, "Welcome to project: Alpha")).Return(nil)
+				return r
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := tt.repo(ctrl)
+			err := r.SendInvintationInProject(ctx, data)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
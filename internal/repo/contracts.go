@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"task-trail/internal/usecase/dto"
+	"time"
 )
 
 var ErrNotFound = errors.New("entity not found")
@@ -28,6 +29,15 @@ type UserRepository interface {
 	// other fields are optional and only those provided will be updated.
 	Update(ctx context.Context, dto *dto.UserUpdate) error
 	GetIdsByEmails(ctx context.Context, emails []string) ([]*dto.UserEmailAndID, error)
+	// GetLanguagesByEmails returns preferred language of users by their emails,
+	// unknown emails and users without preferred language are skipped.
+	GetLanguagesByEmails(ctx context.Context, emails []string) (map[string]string, error)
+	// SetDeletionRequestedAt schedules user deletion, nil value cancels scheduled deletion.
+	SetDeletionRequestedAt(ctx context.Context, ID int, requestedAt *time.Time) error
+	// GetScheduledForDeletion returns IDs of users whose deletion was requested more than olderThan days ago.
+	GetScheduledForDeletion(ctx context.Context, olderThan int) ([]int, error)
+	// Anonymize removes personal data from the user row and marks it as deleted.
+	Anonymize(ctx context.Context, ID int) error
 }
 type VerificationRepository interface {
 	Create(ctx context.Context, userID int, code int) error
@@ -40,6 +50,7 @@ type RefreshTokenRepository interface {
 	Revoke(ctx context.Context, tokenID string) error
 	RevokeAllUsersTokens(ctx context.Context, userID int) (int, error)
 	DeleteRevokedAndOldTokens(ctx context.Context, olderThan int) (int, error)
+	DeleteAllUsersTokens(ctx context.Context, userID int) (int, error)
 }
 
 type EmailTokenRepository interface {
@@ -47,17 +58,109 @@ type EmailTokenRepository interface {
 	Create(ctx context.Context, data *dto.EmailTokenCreate) error
 	Use(ctx context.Context, tokenID string) error
 	DeleteUsedAndOldTokens(ctx context.Context, olderThan int) (int, error)
+	DeleteAllUsersTokens(ctx context.Context, userID int) (int, error)
 }
 
 type NotificationRepository interface {
 	SendVerificationEmail(ctx context.Context, email string, token string) error
 	SendResetPasswordEmail(ctx context.Context, email string, token string) error
-	SendAutoRegisterEmail(ctx context.Context, email string) error
+	SendAutoRegisterEmail(ctx context.Context, email string, token string) error
 	SendInvintationInProject(ctx context.Context, data *dto.NotificationProjectInvite) error
+	// SendEmailChangeEmail sends confirmation link to the new user email address.
+	SendEmailChangeEmail(ctx context.Context, email string, token string) error
+	// SendEmailChangeNotice notifies the old user email address about requested change and provides cancel link.
+	SendEmailChangeNotice(ctx context.Context, data *dto.NotificationEmailChange) error
+}
+
+// InAppNotificationRepository stores notifications shown in the application inbox.
+type InAppNotificationRepository interface {
+	Create(ctx context.Context, data []*dto.NotificationCreate) error
+	// GetList returns user notifications ordered from newest to oldest.
+	GetList(ctx context.Context, data *dto.NotificationList) ([]*dto.Notification, error)
+	// Count returns total and unread number of user notifications.
+	Count(ctx context.Context, userID int) (total int, unread int, err error)
+	// MarkRead marks user notification as read, already read notification is not changed.
+	MarkRead(ctx context.Context, userID int, ID int) error
+	// MarkAllRead marks all user notifications as read and returns the number of affected notifications.
+	MarkAllRead(ctx context.Context, userID int) (int, error)
+}
+
+// ActivityRepository stores the append-only audit log of project changes.
+type ActivityRepository interface {
+	Create(ctx context.Context, data []*dto.ActivityCreate) error
+	// GetList returns entries matching the filters, the latest one goes first.
+	GetList(ctx context.Context, data *dto.ActivityList) ([]*dto.Activity, error)
+	Count(ctx context.Context, data *dto.ActivityList) (int, error)
+}
+
+// NotificationPreferenceRepository stores user choice of notification channels and digest frequency.
+type NotificationPreferenceRepository interface {
+	// Get returns digest frequency and stored preferences of the user.
+	Get(ctx context.Context, userID int) (*dto.NotificationPreferences, error)
+	// Update sets digest frequency and creates or replaces given preferences, other preferences are kept.
+	Update(ctx context.Context, data *dto.NotificationPreferences) error
+	// GetEnabled filters users that have the channel enabled for the notification type.
+	GetEnabled(ctx context.Context, userIDs []int, t dto.NotificationType, channel dto.NotificationChannel) ([]int, error)
+	// GetDigests returns digest frequency of every given user.
+	GetDigests(ctx context.Context, userIDs []int) (map[int]dto.DigestFrequency, error)
+}
+
+// NotificationDigestRepository stores notifications collected for digest emails.
+type NotificationDigestRepository interface {
+	Create(ctx context.Context, data []*dto.NotificationCreate) error
+	// ClaimPending returns events of users with one of the given digest frequencies
+	// ordered by user, project and creation time. Events are claimed until the given time,
+	// so concurrent runs skip them, events not deleted by then are claimed again.
+	ClaimPending(ctx context.Context, frequencies []dto.DigestFrequency, until time.Time) ([]*dto.DigestEvent, error)
+	// Delete removes events already sent in the digest.
+	Delete(ctx context.Context, IDs []int) error
+}
+
+// WebhookRepository stores project webhooks and the log of their deliveries.
+type WebhookRepository interface {
+	Create(ctx context.Context, data *dto.WebhookCreate) (*dto.Webhook, error)
+	GetList(ctx context.Context, projectID int) ([]*dto.Webhook, error)
+	GetByID(ctx context.Context, projectID int, ID int) (*dto.Webhook, error)
+	Delete(ctx context.Context, projectID int, ID int) error
+	// GetSubscribed returns project webhooks subscribed to the event type.
+	GetSubscribed(ctx context.Context, projectID int, eventType string) ([]*dto.Webhook, error)
+
+	CreateDelivery(ctx context.Context, data *dto.WebhookDeliveryCreate) (*dto.WebhookDelivery, error)
+	// ClaimPendingDeliveries returns deliveries ready for the next attempt with webhook URL and secret.
+	// Their next attempt is postponed until the given time, so other workers skip them while they are sent
+	// and they are picked up again if the attempt is not saved by then.
+	ClaimPendingDeliveries(ctx context.Context, limit int, until time.Time) ([]*dto.WebhookDelivery, error)
+	// SaveAttempt increments delivery attempts and saves the attempt result.
+	SaveAttempt(ctx context.Context, data *dto.WebhookAttempt) error
+	// GetDeliveries returns the delivery log of the webhook from newest to oldest.
+	GetDeliveries(ctx context.Context, data *dto.WebhookDeliveryList) ([]*dto.WebhookDelivery, error)
+}
+
+// OutboxRepository stores outgoing emails, messages are created in the same transaction
+// as the business data and delivered later by the background worker.
+type OutboxRepository interface {
+	Create(ctx context.Context, data *dto.OutboxMessageCreate) error
+	// ClaimPending returns up to limit pending messages ready for delivery and postpones their next attempt until the given time,
+	// so concurrent workers skip them while they are sent outside of a transaction.
+	ClaimPending(ctx context.Context, limit int, until time.Time) ([]*dto.OutboxMessage, error)
+	GetByEventID(ctx context.Context, eventID string) (*dto.OutboxMessage, error)
+	MarkSent(ctx context.Context, ID int) error
+	// MarkFailed stores delivery error and schedules the next attempt,
+	// nil nextAttemptAt moves the message to the dead state.
+	MarkFailed(ctx context.Context, ID int, lastError string, nextAttemptAt *time.Time) error
+	// DeleteSentAndOld removes messages sent more than olderThan days ago, dead messages are kept.
+	DeleteSentAndOld(ctx context.Context, olderThan int) (int, error)
 }
 
 type FileRepository interface {
 	Create(ctx context.Context, file *dto.FileCreate) error
+	GetByOwner(ctx context.Context, ownerID int) ([]*dto.File, error)
+	// SoftDeleteByOwner marks all owner files as deleted and returns the number of affected files.
+	SoftDeleteByOwner(ctx context.Context, ownerID int) (int, error)
+}
+
+type TaskRepository interface {
+	GetByAuthor(ctx context.Context, authorID int) ([]*dto.Task, error)
 }
 
 // ProjectRepository defines methods for managing projects and their members.
@@ -84,4 +187,12 @@ type ProjectRepository interface {
 	// Returns repo.ErrNotFound if the user is not a member, nil if the user is a member,
 	// or another repo error if a query error occurs.
 	IsMember(ctx context.Context, projectID int, memberID int) error
+
+	// TransferOwnership passes every project owned by ownerID to another project member.
+	// Projects without other members are marked as deleted.
+	// Returns the number of transferred and deleted projects.
+	TransferOwnership(ctx context.Context, ownerID int) (transferred int, deleted int, err error)
+
+	// RemoveMemberFromAll removes the user from all projects.
+	RemoveMemberFromAll(ctx context.Context, memberID int) error
 }
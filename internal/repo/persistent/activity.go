@@ -0,0 +1,108 @@
+package persistent
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"task-trail/internal/usecase/dto"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PgActivityRepository struct {
+	PgRepostitory
+}
+
+func NewActivityRepo(db *pgxpool.Pool) *PgActivityRepository {
+	return &PgActivityRepository{PgRepostitory{pg: db}}
+}
+
+// activityFilter matches entries of the project, zero values of filters are ignored
+const activityFilter = `
+	project_id = $1
+	AND ($2::INTEGER = 0 OR actor_id = $2)
+	AND ($3::VARCHAR = '' OR entity_type = $3)
+	AND ($4::INTEGER = 0 OR entity_id = $4)
+`
+
+func (r *PgActivityRepository) Create(ctx context.Context, data []*dto.ActivityCreate) error {
+	if len(data) == 0 {
+		return nil
+	}
+	items := make([]string, 0, len(data))
+	values := make([]any, 0, len(data)*7)
+	for i, a := range data {
+		diff := a.Diff
+		if diff == nil {
+			diff = map[string]any{}
+		}
+		var requestID *string
+		if a.RequestID != "" {
+			requestID = &a.RequestID
+		}
+		items = append(items, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d, $%d)", i*7+1, i*7+2, i*7+3, i*7+4, i*7+5, i*7+6, i*7+7))
+		values = append(values, a.ProjectID, a.ActorID, a.Action, a.EntityType, a.EntityID, diff, requestID)
+	}
+	query := fmt.Sprintf(
+		"INSERT INTO project_activity (project_id, actor_id, action, entity_type, entity_id, diff, request_id) VALUES %s;",
+		strings.Join(items, ","),
+	)
+	if _, err := r.getDb(ctx).Exec(ctx, query, values...); err != nil {
+		return r.handleError(err)
+	}
+	return nil
+}
+
+func (r *PgActivityRepository) GetList(ctx context.Context, data *dto.ActivityList) ([]*dto.Activity, error) {
+	query := `
+		SELECT id, project_id, actor_id, action, entity_type, entity_id, diff, request_id, created_at
+		FROM project_activity
+		WHERE` + activityFilter + `
+		ORDER BY created_at DESC, id DESC
+		LIMIT $5 OFFSET $6
+	`
+	rows, err := r.getDb(ctx).Query(
+		ctx,
+		query,
+		data.ProjectID,
+		data.ActorID,
+		data.EntityType,
+		data.EntityID,
+		data.Limit,
+		data.Offset,
+	)
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+	retVal, err := ScanRows(rows, func(row pgx.Rows) (*dto.Activity, error) {
+		var a dto.Activity
+		if err := row.Scan(
+			&a.ID,
+			&a.ProjectID,
+			&a.ActorID,
+			&a.Action,
+			&a.EntityType,
+			&a.EntityID,
+			&a.Diff,
+			&a.RequestID,
+			&a.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		return &a, nil
+	})
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+	return retVal, nil
+}
+
+func (r *PgActivityRepository) Count(ctx context.Context, data *dto.ActivityList) (int, error) {
+	query := "SELECT COUNT(id) FROM project_activity WHERE" + activityFilter
+	var total int
+	if err := r.getDb(ctx).QueryRow(ctx, query, data.ProjectID, data.ActorID, data.EntityType, data.EntityID).Scan(&total); err != nil {
+		return 0, r.handleError(err)
+	}
+	return total, nil
+}
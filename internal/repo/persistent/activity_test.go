@@ -0,0 +1,81 @@
+//go:build integration
+
+package persistent
+
+import (
+	"task-trail/internal/repo"
+	"task-trail/internal/usecase/dto"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestActivity(t *testing.T) {
+	cleanDB(t)
+	ctx := t.Context()
+	ownerID := mustAddUser(t, testEmail)
+	memberID := mustAddUser(t, testEmail1)
+	pID := mustAddProject(t, ownerID)
+
+	t.Run("create", func(t *testing.T) {
+		err := activityRepo.Create(ctx, []*dto.ActivityCreate{
+			{
+				ProjectID:  pID,
+				ActorID:    ownerID,
+				Action:     dto.ActivityProjectCreated,
+				EntityType: dto.ActivityEntityProject,
+				EntityID:   pID,
+				Diff:       map[string]any{"name": "TestProject"},
+				RequestID:  testEventID,
+			},
+			{
+				ProjectID:  pID,
+				ActorID:    ownerID,
+				Action:     dto.ActivityMemberAdded,
+				EntityType: dto.ActivityEntityMember,
+				EntityID:   memberID,
+				Diff:       map[string]any{"email": testEmail1},
+			},
+		})
+		require.NoError(t, err)
+	})
+	t.Run("project not found", func(t *testing.T) {
+		err := activityRepo.Create(ctx, []*dto.ActivityCreate{{ProjectID: 100, ActorID: ownerID, Action: dto.ActivityProjectCreated, EntityType: dto.ActivityEntityProject, EntityID: 100}})
+		require.ErrorIs(t, err, repo.ErrNotFound)
+	})
+	t.Run("get list", func(t *testing.T) {
+		data := &dto.ActivityList{ProjectID: pID, Limit: 10}
+		items, err := activityRepo.GetList(ctx, data)
+		require.NoError(t, err)
+		require.Len(t, items, 2)
+		require.Equal(t, dto.ActivityMemberAdded, items[0].Action)
+		require.Equal(t, testEmail1, items[0].Diff["email"])
+		require.Nil(t, items[0].RequestID)
+		require.Equal(t, testEventID, *items[1].RequestID)
+		total, err := activityRepo.Count(ctx, data)
+		require.NoError(t, err)
+		require.Equal(t, 2, total)
+	})
+	t.Run("filters", func(t *testing.T) {
+		data := &dto.ActivityList{ProjectID: pID, EntityType: dto.ActivityEntityMember, EntityID: memberID, Limit: 10}
+		items, err := activityRepo.GetList(ctx, data)
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		data = &dto.ActivityList{ProjectID: pID, ActorID: memberID, Limit: 10}
+		items, err = activityRepo.GetList(ctx, data)
+		require.NoError(t, err)
+		require.Empty(t, items)
+		total, err := activityRepo.Count(ctx, data)
+		require.NoError(t, err)
+		require.Equal(t, 0, total)
+	})
+	t.Run("entries are append-only", func(t *testing.T) {
+		_, err := pg.Pool.Exec(ctx, "UPDATE project_activity SET action = 'changed'")
+		require.Error(t, err)
+	})
+	t.Run("internal db error", func(t *testing.T) {
+		items, err := activityRepo.GetList(getBadContext(t), &dto.ActivityList{ProjectID: pID, Limit: 10})
+		require.Nil(t, items)
+		require.ErrorIs(t, err, repo.ErrInternal)
+	})
+}
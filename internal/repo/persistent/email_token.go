@@ -19,7 +19,7 @@ func NewEmailTokenRepo(db *pgxpool.Pool) *PgEmailTokenRepository {
 
 func (r *PgEmailTokenRepository) GetByID(ctx context.Context, tokenID string) (*dto.EmailToken, error) {
 	query := `
-		SELECT id, user_id, purpose, created_at, expired_at, used_at   
+		SELECT id, user_id, purpose, created_at, expired_at, used_at, payload
 		FROM email_tokens
 		WHERE id = $1
 		FOR UPDATE
@@ -27,7 +27,7 @@ func (r *PgEmailTokenRepository) GetByID(ctx context.Context, tokenID string) (*
 	var t dto.EmailToken
 	if err := r.getDb(ctx).
 		QueryRow(ctx, query, tokenID).
-		Scan(&t.ID, &t.UserID, &t.Purpose, &t.CreatedAt, &t.ExpiredAt, &t.UsedAt); err != nil {
+		Scan(&t.ID, &t.UserID, &t.Purpose, &t.CreatedAt, &t.ExpiredAt, &t.UsedAt, &t.Payload); err != nil {
 		return nil, r.handleError(err)
 	}
 	return &t, nil
@@ -35,11 +35,11 @@ func (r *PgEmailTokenRepository) GetByID(ctx context.Context, tokenID string) (*
 func (r *PgEmailTokenRepository) Create(ctx context.Context, token *dto.EmailTokenCreate) error {
 	query := `
 		INSERT INTO email_tokens
-		(id, user_id, expired_at, purpose)
-		VALUES ($1, $2, $3, $4)
+		(id, user_id, expired_at, purpose, payload)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''))
 		`
 	if _, err := r.getDb(ctx).
-		Exec(ctx, query, token.ID, token.UserID, token.ExpiredAt, token.Purpose); err != nil {
+		Exec(ctx, query, token.ID, token.UserID, token.ExpiredAt, token.Purpose, token.Payload); err != nil {
 		return r.handleError(err)
 	}
 	return nil
@@ -76,3 +76,12 @@ func (r *PgEmailTokenRepository) DeleteUsedAndOldTokens(ctx context.Context, old
 	}
 	return int(tag.RowsAffected()), nil
 }
+
+func (r *PgEmailTokenRepository) DeleteAllUsersTokens(ctx context.Context, userID int) (int, error) {
+	query := `DELETE FROM email_tokens WHERE user_id = $1`
+	tag, err := r.getDb(ctx).Exec(ctx, query, userID)
+	if err != nil {
+		return 0, r.handleError(err)
+	}
+	return int(tag.RowsAffected()), nil
+}
@@ -62,6 +62,22 @@ func TestEmailTokenGetByID(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, testTokenID, token.ID)
 		require.Equal(t, dto.PurposeVerification, token.Purpose)
+		require.Nil(t, token.Payload)
+	})
+	t.Run("successfully get email change token with payload", func(t *testing.T) {
+		err := emailTokenRepo.Create(ctx, &dto.EmailTokenCreate{
+			ID:        testTokenID2,
+			ExpiredAt: time.Now().Add(time.Minute * 10),
+			UserID:    1,
+			Purpose:   dto.PurposeEmailChange,
+			Payload:   testEmail1,
+		})
+		require.NoError(t, err)
+		token, err := emailTokenRepo.GetByID(ctx, testTokenID2)
+		require.NoError(t, err)
+		require.Equal(t, dto.PurposeEmailChange, token.Purpose)
+		require.NotNil(t, token.Payload)
+		require.Equal(t, testEmail1, *token.Payload)
 	})
 	t.Run("token not found", func(t *testing.T) {
 		token, err := emailTokenRepo.GetByID(ctx, testTokenID1)
@@ -3,7 +3,9 @@ package persistent
 import (
 	"context"
 	"task-trail/internal/usecase/dto"
+	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -26,3 +28,46 @@ func (r *PgFileRepository) Create(ctx context.Context, file *dto.FileCreate) err
 	}
 	return nil
 }
+
+func (r *PgFileRepository) GetByOwner(ctx context.Context, ownerID int) ([]*dto.File, error) {
+	query := `
+		SELECT id, original_name, mime_type, owner_id, created_at, soft_deleted_at, deleted_at
+		FROM files
+		WHERE owner_id = $1
+		ORDER BY created_at`
+	rows, err := r.getDb(ctx).Query(ctx, query, ownerID)
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+	items, err := ScanRows(rows, func(row pgx.Rows) (*dto.File, error) {
+		var item dto.File
+		if err := row.Scan(
+			&item.ID,
+			&item.OriginalName,
+			&item.MimeType,
+			&item.OwnerID,
+			&item.CreatedAt,
+			&item.SoftDeletedAt,
+			&item.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		return &item, nil
+	})
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+	return items, nil
+}
+
+func (r *PgFileRepository) SoftDeleteByOwner(ctx context.Context, ownerID int) (int, error) {
+	query := `
+		UPDATE files
+		SET soft_deleted_at = $1
+		WHERE owner_id = $2 AND soft_deleted_at IS NULL`
+	tag, err := r.getDb(ctx).Exec(ctx, query, time.Now(), ownerID)
+	if err != nil {
+		return 0, r.handleError(err)
+	}
+	return int(tag.RowsAffected()), nil
+}
@@ -26,13 +26,22 @@ var userRepo *PgUserRepository
 var tokenRepo *PgRefreshTokenRepository
 var emailTokenRepo *PgEmailTokenRepository
 var projectRepo *PgProjectRepository
+var outboxRepo *PgOutboxRepository
+var notificationRepo *PgNotificationRepository
+var notificationPreferenceRepo *PgNotificationPreferenceRepository
+var notificationDigestRepo *PgNotificationDigestRepository
+var webhookRepo *PgWebhookRepository
+var activityRepo *PgActivityRepository
 
 func TestMain(m *testing.M) {
 	cfg, err := config.New()
 	if err != nil {
 		panic(err)
 	}
-	logger := slogger.New(cfg.App.Debug, true)
+	logger, err := slogger.New(slogger.Format(cfg.Log.Format), slogger.Level(cfg.Log.Level))
+	if err != nil {
+		panic(err)
+	}
 	cfg.PG.ConnString += "_test"
 
 	dsn := cfg.PG.ConnString[:strings.LastIndex(cfg.PG.ConnString, "/")+1] + "postgres"
@@ -60,6 +69,12 @@ func TestMain(m *testing.M) {
 	tokenRepo = NewRefreshTokenRepo(pg.Pool)
 	emailTokenRepo = NewEmailTokenRepo(pg.Pool)
 	projectRepo = NewProjectRepo(pg.Pool)
+	outboxRepo = NewOutboxRepo(pg.Pool)
+	notificationRepo = NewNotificationRepo(pg.Pool)
+	notificationPreferenceRepo = NewNotificationPreferenceRepo(pg.Pool)
+	notificationDigestRepo = NewNotificationDigestRepo(pg.Pool)
+	webhookRepo = NewWebhookRepo(pg.Pool)
+	activityRepo = NewActivityRepo(pg.Pool)
 	os.Exit(m.Run())
 }
 
@@ -88,7 +103,14 @@ func cleanDB(t *testing.T) {
 		project_users,
 		projects,
 		files,
-		tasks
+		tasks,
+		email_outbox,
+		notifications,
+		notification_preferences,
+		notification_digest_events,
+		project_webhooks,
+		webhook_deliveries,
+		project_activity
 		RESTART IDENTITY CASCADE;
 	`)
 	require.NoError(t, err)
@@ -0,0 +1,121 @@
+package persistent
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"task-trail/internal/repo"
+	"task-trail/internal/usecase/dto"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PgNotificationRepository struct {
+	PgRepostitory
+}
+
+func NewNotificationRepo(db *pgxpool.Pool) *PgNotificationRepository {
+	return &PgNotificationRepository{PgRepostitory{pg: db}}
+}
+
+func (r *PgNotificationRepository) Create(ctx context.Context, data []*dto.NotificationCreate) error {
+	if len(data) == 0 {
+		return nil
+	}
+	items := make([]string, 0, len(data))
+	values := make([]any, 0, len(data)*5)
+	for i, n := range data {
+		payload := n.Payload
+		if payload == nil {
+			payload = map[string]string{}
+		}
+		items = append(items, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d)", i*5+1, i*5+2, i*5+3, i*5+4, i*5+5))
+		values = append(values, n.UserID, n.Type, n.ProjectID, n.TaskID, payload)
+	}
+	query := fmt.Sprintf(
+		"INSERT INTO notifications (user_id, type, project_id, task_id, payload) VALUES %s;",
+		strings.Join(items, ","),
+	)
+	if _, err := r.getDb(ctx).Exec(ctx, query, values...); err != nil {
+		return r.handleError(err)
+	}
+	return nil
+}
+
+func (r *PgNotificationRepository) GetList(ctx context.Context, data *dto.NotificationList) ([]*dto.Notification, error) {
+	query := `
+		SELECT id, user_id, type, project_id, task_id, payload, read_at, created_at
+		FROM notifications
+		WHERE user_id = $1 AND ($2 = FALSE OR read_at IS NULL)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3 OFFSET $4
+	`
+	rows, err := r.getDb(ctx).Query(ctx, query, data.UserID, data.OnlyUnread, data.Limit, data.Offset)
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+	retVal, err := ScanRows(rows, func(row pgx.Rows) (*dto.Notification, error) {
+		var n dto.Notification
+		if err := row.Scan(
+			&n.ID,
+			&n.UserID,
+			&n.Type,
+			&n.ProjectID,
+			&n.TaskID,
+			&n.Payload,
+			&n.ReadAt,
+			&n.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		return &n, nil
+	})
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+	return retVal, nil
+}
+
+func (r *PgNotificationRepository) Count(ctx context.Context, userID int) (int, int, error) {
+	query := `
+		SELECT COUNT(id), COUNT(id) FILTER (WHERE read_at IS NULL)
+		FROM notifications
+		WHERE user_id = $1
+	`
+	var total, unread int
+	if err := r.getDb(ctx).QueryRow(ctx, query, userID).Scan(&total, &unread); err != nil {
+		return 0, 0, r.handleError(err)
+	}
+	return total, unread, nil
+}
+
+func (r *PgNotificationRepository) MarkRead(ctx context.Context, userID int, ID int) error {
+	query := `
+		UPDATE notifications
+		SET read_at = COALESCE(read_at, $1)
+		WHERE id = $2 AND user_id = $3
+	`
+	tag, err := r.getDb(ctx).Exec(ctx, query, time.Now(), ID, userID)
+	if err != nil {
+		return r.handleError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+func (r *PgNotificationRepository) MarkAllRead(ctx context.Context, userID int) (int, error) {
+	query := `
+		UPDATE notifications
+		SET read_at = $1
+		WHERE user_id = $2 AND read_at IS NULL
+	`
+	tag, err := r.getDb(ctx).Exec(ctx, query, time.Now(), userID)
+	if err != nil {
+		return 0, r.handleError(err)
+	}
+	return int(tag.RowsAffected()), nil
+}
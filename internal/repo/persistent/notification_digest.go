@@ -0,0 +1,100 @@
+package persistent
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"task-trail/internal/usecase/dto"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PgNotificationDigestRepository struct {
+	PgRepostitory
+}
+
+func NewNotificationDigestRepo(db *pgxpool.Pool) *PgNotificationDigestRepository {
+	return &PgNotificationDigestRepository{PgRepostitory{pg: db}}
+}
+
+func (r *PgNotificationDigestRepository) Create(ctx context.Context, data []*dto.NotificationCreate) error {
+	if len(data) == 0 {
+		return nil
+	}
+	items := make([]string, 0, len(data))
+	values := make([]any, 0, len(data)*5)
+	for i, n := range data {
+		payload := n.Payload
+		if payload == nil {
+			payload = map[string]string{}
+		}
+		items = append(items, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d)", i*5+1, i*5+2, i*5+3, i*5+4, i*5+5))
+		values = append(values, n.UserID, n.Type, n.ProjectID, n.TaskID, payload)
+	}
+	query := fmt.Sprintf(
+		"INSERT INTO notification_digest_events (user_id, type, project_id, task_id, payload) VALUES %s;",
+		strings.Join(items, ","),
+	)
+	if _, err := r.getDb(ctx).Exec(ctx, query, values...); err != nil {
+		return r.handleError(err)
+	}
+	return nil
+}
+
+func (r *PgNotificationDigestRepository) ClaimPending(ctx context.Context, frequencies []dto.DigestFrequency, until time.Time) ([]*dto.DigestEvent, error) {
+	query := `
+		WITH e AS (
+			UPDATE notification_digest_events
+			SET claimed_until = $3
+			WHERE id IN (
+				SELECT e.id
+				FROM notification_digest_events e
+				JOIN users u ON u.id = e.user_id
+				WHERE
+					u.notification_digest = ANY($1)
+					AND u.deleted_at IS NULL
+					AND (e.claimed_until IS NULL OR e.claimed_until <= $2)
+				FOR UPDATE OF e SKIP LOCKED
+			)
+			RETURNING *
+		)
+		SELECT e.id, e.user_id, u.email, COALESCE(u.language, ''), e.type, e.project_id, e.task_id, e.payload, e.created_at
+		FROM e
+		JOIN users u ON u.id = e.user_id
+		ORDER BY e.user_id, e.project_id NULLS LAST, e.created_at, e.id
+	`
+	rows, err := r.getDb(ctx).Query(ctx, query, frequencies, time.Now(), until)
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+	retVal, err := ScanRows(rows, func(row pgx.Rows) (*dto.DigestEvent, error) {
+		var e dto.DigestEvent
+		if err := row.Scan(
+			&e.ID,
+			&e.UserID,
+			&e.Email,
+			&e.Language,
+			&e.Type,
+			&e.ProjectID,
+			&e.TaskID,
+			&e.Payload,
+			&e.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		return &e, nil
+	})
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+	return retVal, nil
+}
+
+func (r *PgNotificationDigestRepository) Delete(ctx context.Context, IDs []int) error {
+	if _, err := r.getDb(ctx).Exec(ctx, "DELETE FROM notification_digest_events WHERE id = ANY($1)", IDs); err != nil {
+		return r.handleError(err)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+//go:build integration
+
+package persistent
+
+import (
+	"task-trail/internal/repo"
+	"task-trail/internal/usecase/dto"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationDigest(t *testing.T) {
+	cleanDB(t)
+	ctx := t.Context()
+	uID := mustAddUser(t, testEmail)
+	otherID := mustAddUser(t, testEmail1)
+	err := notificationPreferenceRepo.Update(ctx, &dto.NotificationPreferences{UserID: uID, Digest: dto.DigestDaily})
+	require.NoError(t, err)
+	err = notificationPreferenceRepo.Update(ctx, &dto.NotificationPreferences{UserID: otherID, Digest: dto.DigestWeekly})
+	require.NoError(t, err)
+	projectID := 2
+	otherProjectID := 1
+
+	t.Run("create", func(t *testing.T) {
+		err := notificationDigestRepo.Create(ctx, []*dto.NotificationCreate{
+			{UserID: uID, Type: dto.NotificationTypeProjectInvite, ProjectID: &projectID, Payload: map[string]string{"projectName": "project"}},
+			{UserID: otherID, Type: dto.NotificationTypeProjectInvite, ProjectID: &projectID},
+			{UserID: uID, Type: dto.NotificationTypeProjectInvite, ProjectID: &otherProjectID},
+		})
+		require.NoError(t, err)
+		require.NoError(t, notificationDigestRepo.Create(ctx, nil))
+	})
+	t.Run("claim pending", func(t *testing.T) {
+		events, err := notificationDigestRepo.ClaimPending(ctx, []dto.DigestFrequency{dto.DigestDaily, dto.DigestNone}, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+		// ordered by project
+		require.Equal(t, 3, events[0].ID)
+		require.Equal(t, 1, events[1].ID)
+		require.Equal(t, testEmail, events[1].Email)
+		require.Empty(t, events[1].Language)
+		require.Equal(t, map[string]string{"projectName": "project"}, events[1].Payload)
+	})
+	t.Run("claimed events are skipped", func(t *testing.T) {
+		events, err := notificationDigestRepo.ClaimPending(ctx, []dto.DigestFrequency{dto.DigestDaily}, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		require.Empty(t, events)
+	})
+	t.Run("expired claim", func(t *testing.T) {
+		events, err := notificationDigestRepo.ClaimPending(ctx, []dto.DigestFrequency{dto.DigestWeekly}, time.Now())
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		events, err = notificationDigestRepo.ClaimPending(ctx, []dto.DigestFrequency{dto.DigestWeekly}, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+	})
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, notificationDigestRepo.Delete(ctx, []int{2}))
+		events, err := notificationDigestRepo.ClaimPending(ctx, []dto.DigestFrequency{dto.DigestWeekly}, time.Now())
+		require.NoError(t, err)
+		require.Empty(t, events)
+	})
+	t.Run("get digests", func(t *testing.T) {
+		digests, err := notificationPreferenceRepo.GetDigests(ctx, []int{uID, otherID})
+		require.NoError(t, err)
+		require.Equal(t, map[int]dto.DigestFrequency{uID: dto.DigestDaily, otherID: dto.DigestWeekly}, digests)
+	})
+	t.Run("user not found", func(t *testing.T) {
+		err := notificationDigestRepo.Create(ctx, []*dto.NotificationCreate{{UserID: 100, Type: dto.NotificationTypeProjectInvite}})
+		require.ErrorIs(t, err, repo.ErrNotFound)
+	})
+	t.Run("internal db error", func(t *testing.T) {
+		events, err := notificationDigestRepo.ClaimPending(getBadContext(t), []dto.DigestFrequency{dto.DigestDaily}, time.Now())
+		require.Nil(t, events)
+		require.ErrorIs(t, err, repo.ErrInternal)
+	})
+}
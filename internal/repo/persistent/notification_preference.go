@@ -0,0 +1,132 @@
+package persistent
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"task-trail/internal/repo"
+	"task-trail/internal/usecase/dto"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PgNotificationPreferenceRepository struct {
+	PgRepostitory
+}
+
+func NewNotificationPreferenceRepo(db *pgxpool.Pool) *PgNotificationPreferenceRepository {
+	return &PgNotificationPreferenceRepository{PgRepostitory{pg: db}}
+}
+
+func (r *PgNotificationPreferenceRepository) Get(ctx context.Context, userID int) (*dto.NotificationPreferences, error) {
+	retVal := &dto.NotificationPreferences{UserID: userID}
+	query := "SELECT notification_digest FROM users WHERE id = $1"
+	if err := r.getDb(ctx).QueryRow(ctx, query, userID).Scan(&retVal.Digest); err != nil {
+		return nil, r.handleError(err)
+	}
+	query = `
+		SELECT type, channel, enabled
+		FROM notification_preferences
+		WHERE user_id = $1
+		ORDER BY type, channel
+	`
+	rows, err := r.getDb(ctx).Query(ctx, query, userID)
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+	retVal.Items, err = ScanRows(rows, func(row pgx.Rows) (*dto.NotificationPreference, error) {
+		var p dto.NotificationPreference
+		if err := row.Scan(&p.Type, &p.Channel, &p.Enabled); err != nil {
+			return nil, err
+		}
+		return &p, nil
+	})
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+	return retVal, nil
+}
+
+func (r *PgNotificationPreferenceRepository) Update(ctx context.Context, data *dto.NotificationPreferences) error {
+	tag, err := r.getDb(ctx).Exec(ctx, "UPDATE users SET notification_digest = $1 WHERE id = $2", data.Digest, data.UserID)
+	if err != nil {
+		return r.handleError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return repo.ErrNotFound
+	}
+	if len(data.Items) == 0 {
+		return nil
+	}
+	items := make([]string, 0, len(data.Items))
+	values := make([]any, 0, len(data.Items)*3+1)
+	values = append(values, data.UserID)
+	for i, p := range data.Items {
+		items = append(items, fmt.Sprintf("($1, $%d, $%d, $%d)", i*3+2, i*3+3, i*3+4))
+		values = append(values, p.Type, p.Channel, p.Enabled)
+	}
+	query := fmt.Sprintf(`
+		INSERT INTO notification_preferences (user_id, type, channel, enabled)
+		VALUES %s
+		ON CONFLICT (user_id, type, channel) DO UPDATE SET enabled = EXCLUDED.enabled;
+	`, strings.Join(items, ","))
+	if _, err := r.getDb(ctx).Exec(ctx, query, values...); err != nil {
+		return r.handleError(err)
+	}
+	return nil
+}
+
+func (r *PgNotificationPreferenceRepository) GetEnabled(
+	ctx context.Context,
+	userIDs []int,
+	t dto.NotificationType,
+	channel dto.NotificationChannel,
+) ([]int, error) {
+	query := `
+		SELECT u.id
+		FROM unnest($1::INTEGER[]) AS u(id)
+		LEFT JOIN notification_preferences p ON p.user_id = u.id AND p.type = $2 AND p.channel = $3
+		WHERE COALESCE(p.enabled, TRUE)
+	`
+	rows, err := r.getDb(ctx).Query(ctx, query, userIDs, t, channel)
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+	defer rows.Close()
+
+	retVal := make([]int, 0, len(userIDs))
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, r.handleError(err)
+		}
+		retVal = append(retVal, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.handleError(err)
+	}
+	return retVal, nil
+}
+
+func (r *PgNotificationPreferenceRepository) GetDigests(ctx context.Context, userIDs []int) (map[int]dto.DigestFrequency, error) {
+	rows, err := r.getDb(ctx).Query(ctx, "SELECT id, notification_digest FROM users WHERE id = ANY($1)", userIDs)
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+	defer rows.Close()
+
+	retVal := make(map[int]dto.DigestFrequency, len(userIDs))
+	for rows.Next() {
+		var id int
+		var digest dto.DigestFrequency
+		if err := rows.Scan(&id, &digest); err != nil {
+			return nil, r.handleError(err)
+		}
+		retVal[id] = digest
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.handleError(err)
+	}
+	return retVal, nil
+}
@@ -0,0 +1,70 @@
+//go:build integration
+
+package persistent
+
+import (
+	"task-trail/internal/repo"
+	"task-trail/internal/usecase/dto"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationPreferences(t *testing.T) {
+	cleanDB(t)
+	ctx := t.Context()
+	uID := mustAddUser(t, testEmail)
+	otherID := mustAddUser(t, testEmail1)
+
+	t.Run("defaults", func(t *testing.T) {
+		p, err := notificationPreferenceRepo.Get(ctx, uID)
+		require.NoError(t, err)
+		require.Equal(t, dto.DigestNone, p.Digest)
+		require.Empty(t, p.Items)
+	})
+	t.Run("update", func(t *testing.T) {
+		err := notificationPreferenceRepo.Update(ctx, &dto.NotificationPreferences{
+			UserID: uID,
+			Digest: dto.DigestDaily,
+			Items: []*dto.NotificationPreference{
+				{Type: dto.NotificationTypeProjectInvite, Channel: dto.NotificationChannelEmail, Enabled: true},
+				{Type: dto.NotificationTypeProjectInvite, Channel: dto.NotificationChannelInApp, Enabled: false},
+			},
+		})
+		require.NoError(t, err)
+		// existing preference is replaced, others are kept
+		err = notificationPreferenceRepo.Update(ctx, &dto.NotificationPreferences{
+			UserID: uID,
+			Digest: dto.DigestDaily,
+			Items: []*dto.NotificationPreference{
+				{Type: dto.NotificationTypeProjectInvite, Channel: dto.NotificationChannelEmail, Enabled: false},
+			},
+		})
+		require.NoError(t, err)
+		p, err := notificationPreferenceRepo.Get(ctx, uID)
+		require.NoError(t, err)
+		require.Equal(t, dto.DigestDaily, p.Digest)
+		require.Equal(t, []*dto.NotificationPreference{
+			{Type: dto.NotificationTypeProjectInvite, Channel: dto.NotificationChannelEmail, Enabled: false},
+			{Type: dto.NotificationTypeProjectInvite, Channel: dto.NotificationChannelInApp, Enabled: false},
+		}, p.Items)
+	})
+	t.Run("get enabled", func(t *testing.T) {
+		IDs, err := notificationPreferenceRepo.GetEnabled(ctx, []int{uID, otherID}, dto.NotificationTypeProjectInvite, dto.NotificationChannelEmail)
+		require.NoError(t, err)
+		require.Equal(t, []int{otherID}, IDs)
+		IDs, err = notificationPreferenceRepo.GetEnabled(ctx, []int{uID, otherID}, dto.NotificationTypeProjectInvite, dto.NotificationChannelInApp)
+		require.NoError(t, err)
+		require.Equal(t, []int{otherID}, IDs)
+	})
+	t.Run("user not found", func(t *testing.T) {
+		_, err := notificationPreferenceRepo.Get(ctx, 100)
+		require.ErrorIs(t, err, repo.ErrNotFound)
+		err = notificationPreferenceRepo.Update(ctx, &dto.NotificationPreferences{UserID: 100, Digest: dto.DigestNone})
+		require.ErrorIs(t, err, repo.ErrNotFound)
+	})
+	t.Run("internal db error", func(t *testing.T) {
+		_, err := notificationPreferenceRepo.GetEnabled(getBadContext(t), []int{uID}, dto.NotificationTypeProjectInvite, dto.NotificationChannelEmail)
+		require.ErrorIs(t, err, repo.ErrInternal)
+	})
+}
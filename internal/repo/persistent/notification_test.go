@@ -0,0 +1,96 @@
+//go:build integration
+
+package persistent
+
+import (
+	"task-trail/internal/repo"
+	"task-trail/internal/usecase/dto"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationCreate(t *testing.T) {
+	cleanDB(t)
+	uID := mustAddUser(t, testEmail)
+	projectID := 1
+	t.Run("success", func(t *testing.T) {
+		err := notificationRepo.Create(t.Context(), []*dto.NotificationCreate{
+			{UserID: uID, Type: dto.NotificationTypeProjectInvite, ProjectID: &projectID, Payload: map[string]string{"projectName": "test"}},
+			{UserID: uID, Type: dto.NotificationTypeProjectInvite},
+		})
+		require.NoError(t, err)
+		items, err := notificationRepo.GetList(t.Context(), &dto.NotificationList{UserID: uID, Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, items, 2)
+		require.Equal(t, dto.NotificationTypeProjectInvite, items[0].Type)
+		require.Empty(t, items[0].Payload)
+		require.Nil(t, items[0].ProjectID)
+		require.Equal(t, dto.NotificationTypeProjectInvite, items[1].Type)
+		require.Equal(t, map[string]string{"projectName": "test"}, items[1].Payload)
+		require.Equal(t, projectID, *items[1].ProjectID)
+		require.Nil(t, items[1].ReadAt)
+	})
+	t.Run("empty list", func(t *testing.T) {
+		require.NoError(t, notificationRepo.Create(t.Context(), nil))
+	})
+	t.Run("user not found", func(t *testing.T) {
+		err := notificationRepo.Create(t.Context(), []*dto.NotificationCreate{{UserID: 100, Type: dto.NotificationTypeProjectInvite}})
+		require.ErrorIs(t, err, repo.ErrNotFound)
+	})
+	t.Run("internal db error", func(t *testing.T) {
+		err := notificationRepo.Create(getBadContext(t), []*dto.NotificationCreate{{UserID: uID, Type: dto.NotificationTypeProjectInvite}})
+		require.ErrorIs(t, err, repo.ErrInternal)
+	})
+}
+
+func TestNotificationRead(t *testing.T) {
+	cleanDB(t)
+	ctx := t.Context()
+	uID := mustAddUser(t, testEmail)
+	otherID := mustAddUser(t, testEmail1)
+	err := notificationRepo.Create(ctx, []*dto.NotificationCreate{
+		{UserID: uID, Type: dto.NotificationTypeProjectInvite},
+		{UserID: uID, Type: dto.NotificationTypeProjectInvite},
+		{UserID: uID, Type: dto.NotificationTypeProjectInvite},
+		{UserID: otherID, Type: dto.NotificationTypeProjectInvite},
+	})
+	require.NoError(t, err)
+
+	t.Run("mark read", func(t *testing.T) {
+		require.NoError(t, notificationRepo.MarkRead(ctx, uID, 1))
+		// repeated call keeps notification read
+		require.NoError(t, notificationRepo.MarkRead(ctx, uID, 1))
+		total, unread, err := notificationRepo.Count(ctx, uID)
+		require.NoError(t, err)
+		require.Equal(t, 3, total)
+		require.Equal(t, 2, unread)
+		items, err := notificationRepo.GetList(ctx, &dto.NotificationList{UserID: uID, OnlyUnread: true, Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, items, 2)
+	})
+	t.Run("notification of another user", func(t *testing.T) {
+		require.ErrorIs(t, notificationRepo.MarkRead(ctx, uID, 4), repo.ErrNotFound)
+	})
+	t.Run("pagination", func(t *testing.T) {
+		items, err := notificationRepo.GetList(ctx, &dto.NotificationList{UserID: uID, Limit: 1, Offset: 1})
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		require.Equal(t, 2, items[0].ID)
+	})
+	t.Run("mark all read", func(t *testing.T) {
+		marked, err := notificationRepo.MarkAllRead(ctx, uID)
+		require.NoError(t, err)
+		require.Equal(t, 2, marked)
+		_, unread, err := notificationRepo.Count(ctx, uID)
+		require.NoError(t, err)
+		require.Equal(t, 0, unread)
+		_, unread, err = notificationRepo.Count(ctx, otherID)
+		require.NoError(t, err)
+		require.Equal(t, 1, unread)
+	})
+	t.Run("internal db error", func(t *testing.T) {
+		_, _, err := notificationRepo.Count(getBadContext(t), uID)
+		require.ErrorIs(t, err, repo.ErrInternal)
+	})
+}
@@ -0,0 +1,148 @@
+package persistent
+
+import (
+	"context"
+	"task-trail/internal/repo"
+	"task-trail/internal/usecase/dto"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const messageColumns = `
+	id, event_id, request_id, recipients, subject, text_body, html_body,
+	status, attempts, last_error, next_attempt_at, created_at, sent_at
+`
+
+func messageFields(m *dto.OutboxMessage) []any {
+	return []any{
+		&m.ID,
+		&m.EventID,
+		&m.RequestID,
+		&m.Recipients,
+		&m.Subject,
+		&m.Text,
+		&m.HTML,
+		&m.Status,
+		&m.Attempts,
+		&m.LastError,
+		&m.NextAttemptAt,
+		&m.CreatedAt,
+		&m.SentAt,
+	}
+}
+
+type PgOutboxRepository struct {
+	PgRepostitory
+}
+
+func NewOutboxRepo(db *pgxpool.Pool) *PgOutboxRepository {
+	return &PgOutboxRepository{PgRepostitory{pg: db}}
+}
+
+func (r *PgOutboxRepository) Create(ctx context.Context, data *dto.OutboxMessageCreate) error {
+	query := `
+		INSERT INTO email_outbox
+		(event_id, request_id, recipients, subject, text_body, html_body)
+		VALUES ($1, NULLIF($2, ''), $3, $4, $5, $6)
+	`
+	if _, err := r.getDb(ctx).
+		Exec(ctx, query, data.EventID, data.RequestID, data.Recipients, data.Subject, data.Text, data.HTML); err != nil {
+		return r.handleError(err)
+	}
+	return nil
+}
+
+func (r *PgOutboxRepository) ClaimPending(ctx context.Context, limit int, until time.Time) ([]*dto.OutboxMessage, error) {
+	query := `
+		WITH m AS (
+			UPDATE email_outbox
+			SET next_attempt_at = $3
+			WHERE id IN (
+				SELECT id
+				FROM email_outbox
+				WHERE status = 'pending' AND next_attempt_at <= $1
+				ORDER BY next_attempt_at, id
+				LIMIT $2
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING *
+		)
+		SELECT ` + messageColumns + `
+		FROM m
+		ORDER BY id
+	`
+	rows, err := r.getDb(ctx).Query(ctx, query, time.Now(), limit, until)
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+	retVal, err := ScanRows(rows, func(row pgx.Rows) (*dto.OutboxMessage, error) {
+		var m dto.OutboxMessage
+		if err := row.Scan(messageFields(&m)...); err != nil {
+			return nil, err
+		}
+		return &m, nil
+	})
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+	return retVal, nil
+}
+
+func (r *PgOutboxRepository) GetByEventID(ctx context.Context, eventID string) (*dto.OutboxMessage, error) {
+	query := `SELECT ` + messageColumns + ` FROM email_outbox WHERE event_id = $1`
+	var m dto.OutboxMessage
+	if err := r.getDb(ctx).QueryRow(ctx, query, eventID).Scan(messageFields(&m)...); err != nil {
+		return nil, r.handleError(err)
+	}
+	return &m, nil
+}
+
+func (r *PgOutboxRepository) MarkSent(ctx context.Context, ID int) error {
+	query := `
+		UPDATE email_outbox
+		SET status = 'sent', attempts = attempts + 1, sent_at = $1, last_error = NULL
+		WHERE id = $2 AND status = 'pending'
+	`
+	tag, err := r.getDb(ctx).Exec(ctx, query, time.Now(), ID)
+	if err != nil {
+		return r.handleError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+func (r *PgOutboxRepository) MarkFailed(ctx context.Context, ID int, lastError string, nextAttemptAt *time.Time) error {
+	query := `
+		UPDATE email_outbox
+		SET
+			status = CASE WHEN $1::timestamptz IS NULL THEN 'dead' ELSE 'pending' END,
+			attempts = attempts + 1,
+			last_error = $2,
+			next_attempt_at = COALESCE($1, next_attempt_at)
+		WHERE id = $3 AND status = 'pending'
+	`
+	tag, err := r.getDb(ctx).Exec(ctx, query, nextAttemptAt, lastError, ID)
+	if err != nil {
+		return r.handleError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+func (r *PgOutboxRepository) DeleteSentAndOld(ctx context.Context, olderThan int) (int, error) {
+	query := `
+		DELETE FROM email_outbox
+		WHERE status = 'sent' AND sent_at < NOW() - make_interval(days => $1)
+	`
+	tag, err := r.getDb(ctx).Exec(ctx, query, olderThan)
+	if err != nil {
+		return 0, r.handleError(err)
+	}
+	return int(tag.RowsAffected()), nil
+}
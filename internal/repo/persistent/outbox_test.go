@@ -0,0 +1,162 @@
+//go:build integration
+
+package persistent
+
+import (
+	"context"
+	"task-trail/internal/repo"
+	"task-trail/internal/usecase/dto"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testEventID = "8f1c9f36-5c1e-4a43-9a4d-1b0f6c3f4f11"
+const testEventID1 = "8f1c9f36-5c1e-4a43-9a4d-1b0f6c3f4f12"
+
+func mustAddOutboxMessage(t *testing.T, eventID string) {
+	err := outboxRepo.Create(t.Context(), &dto.OutboxMessageCreate{
+		EventID:    eventID,
+		Recipients: []string{testEmail, testEmail1},
+		Subject:    "subject",
+		Text:       "text",
+		HTML:       "<p>text</p>",
+	})
+	require.NoError(t, err)
+}
+
+func TestOutboxCreate(t *testing.T) {
+	cleanDB(t)
+	t.Run("success", func(t *testing.T) {
+		mustAddOutboxMessage(t, testEventID)
+		messages, err := outboxRepo.ClaimPending(t.Context(), 10, time.Now())
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+		m := messages[0]
+		require.Equal(t, testEventID, m.EventID)
+		require.Equal(t, []string{testEmail, testEmail1}, m.Recipients)
+		require.Equal(t, "<p>text</p>", m.HTML)
+		require.Equal(t, dto.OutboxPending, m.Status)
+		require.Equal(t, 0, m.Attempts)
+		require.Nil(t, m.RequestID)
+	})
+	t.Run("request id is kept", func(t *testing.T) {
+		cleanDB(t)
+		err := outboxRepo.Create(t.Context(), &dto.OutboxMessageCreate{
+			EventID:    testEventID,
+			RequestID:  "req-1",
+			Recipients: []string{testEmail},
+			Subject:    "subject",
+			Text:       "text",
+		})
+		require.NoError(t, err)
+		messages, err := outboxRepo.ClaimPending(t.Context(), 10, time.Now())
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+		require.NotNil(t, messages[0].RequestID)
+		require.Equal(t, "req-1", *messages[0].RequestID)
+	})
+	t.Run("event already exists", func(t *testing.T) {
+		err := outboxRepo.Create(t.Context(), &dto.OutboxMessageCreate{EventID: testEventID, Recipients: []string{testEmail}})
+		require.ErrorIs(t, err, repo.ErrConflict)
+	})
+	t.Run("rolled back with transaction", func(t *testing.T) {
+		err := txManager.DoWithTx(t.Context(), func(ctx context.Context) error {
+			if err := outboxRepo.Create(ctx, &dto.OutboxMessageCreate{EventID: testEventID1, Recipients: []string{testEmail}}); err != nil {
+				return err
+			}
+			return repo.ErrInternal
+		})
+		require.ErrorIs(t, err, repo.ErrInternal)
+		messages, err := outboxRepo.ClaimPending(t.Context(), 10, time.Now())
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+	})
+	t.Run("internal db error", func(t *testing.T) {
+		err := outboxRepo.Create(getBadContext(t), &dto.OutboxMessageCreate{EventID: testEventID1})
+		require.ErrorIs(t, err, repo.ErrInternal)
+	})
+}
+
+func TestOutboxDelivery(t *testing.T) {
+	cleanDB(t)
+	ctx := t.Context()
+	mustAddOutboxMessage(t, testEventID)
+	mustAddOutboxMessage(t, testEventID1)
+
+	t.Run("pending messages are claimed", func(t *testing.T) {
+		until := time.Now().Add(time.Hour)
+		messages, err := outboxRepo.ClaimPending(ctx, 1, until)
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+		require.Equal(t, 1, messages[0].ID)
+		require.WithinDuration(t, until, messages[0].NextAttemptAt, time.Millisecond)
+		// next run skips the claimed message
+		other, err := outboxRepo.ClaimPending(ctx, 10, until)
+		require.NoError(t, err)
+		require.Len(t, other, 1)
+		require.Equal(t, 2, other[0].ID)
+		none, err := outboxRepo.ClaimPending(ctx, 10, until)
+		require.NoError(t, err)
+		require.Empty(t, none)
+	})
+	t.Run("mark sent", func(t *testing.T) {
+		require.NoError(t, outboxRepo.MarkSent(ctx, 1))
+		require.ErrorIs(t, outboxRepo.MarkSent(ctx, 1), repo.ErrNotFound)
+	})
+	t.Run("mark failed with retry", func(t *testing.T) {
+		next := time.Now().Add(-time.Minute)
+		require.NoError(t, outboxRepo.MarkFailed(ctx, 2, "smtp unavailable", &next))
+		messages, err := outboxRepo.ClaimPending(ctx, 10, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+		require.Equal(t, 2, messages[0].ID)
+		require.Equal(t, 1, messages[0].Attempts)
+	})
+	t.Run("mark failed without retry", func(t *testing.T) {
+		require.NoError(t, outboxRepo.MarkFailed(ctx, 2, "smtp unavailable", nil))
+		var status string
+		var attempts int
+		var lastError string
+		err := pg.Pool.QueryRow(ctx, "SELECT status, attempts, last_error FROM email_outbox WHERE id = 2").
+			Scan(&status, &attempts, &lastError)
+		require.NoError(t, err)
+		require.Equal(t, string(dto.OutboxDead), status)
+		require.Equal(t, 2, attempts)
+		require.Equal(t, "smtp unavailable", lastError)
+		require.ErrorIs(t, outboxRepo.MarkFailed(ctx, 2, "smtp unavailable", nil), repo.ErrNotFound)
+	})
+	t.Run("get by event id", func(t *testing.T) {
+		m, err := outboxRepo.GetByEventID(ctx, testEventID)
+		require.NoError(t, err)
+		require.Equal(t, 1, m.ID)
+		require.Equal(t, dto.OutboxSent, m.Status)
+		require.Equal(t, 1, m.Attempts)
+		require.NotNil(t, m.SentAt)
+		m, err = outboxRepo.GetByEventID(ctx, testEventID1)
+		require.NoError(t, err)
+		require.Equal(t, dto.OutboxDead, m.Status)
+		require.Equal(t, "smtp unavailable", *m.LastError)
+		_, err = outboxRepo.GetByEventID(ctx, "unknown")
+		require.ErrorIs(t, err, repo.ErrNotFound)
+	})
+	t.Run("delete sent and old", func(t *testing.T) {
+		deleted, err := outboxRepo.DeleteSentAndOld(ctx, 7)
+		require.NoError(t, err)
+		require.Equal(t, 0, deleted)
+		_, err = pg.Pool.Exec(ctx, "UPDATE email_outbox SET sent_at = NOW() - INTERVAL '8 days' WHERE id = 1")
+		require.NoError(t, err)
+		deleted, err = outboxRepo.DeleteSentAndOld(ctx, 7)
+		require.NoError(t, err)
+		require.Equal(t, 1, deleted)
+	})
+	t.Run("internal db error", func(t *testing.T) {
+		messages, err := outboxRepo.ClaimPending(getBadContext(t), 10, time.Now())
+		require.Nil(t, messages)
+		require.ErrorIs(t, err, repo.ErrInternal)
+		m, err := outboxRepo.GetByEventID(getBadContext(t), testEventID)
+		require.Nil(t, m)
+		require.ErrorIs(t, err, repo.ErrInternal)
+	})
+}
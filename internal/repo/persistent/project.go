@@ -6,6 +6,7 @@ import (
 	"strings"
 	"task-trail/internal/repo"
 	"task-trail/internal/usecase/dto"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -201,3 +202,46 @@ func (r *PgProjectRepository) IsMember(ctx context.Context, projectID int, membe
 	}
 	return nil
 }
+
+func (r *PgProjectRepository) TransferOwnership(ctx context.Context, ownerID int) (int, int, error) {
+	// the member with the lowest id is the oldest registered user and becomes the new owner
+	query := `
+		UPDATE projects AS P
+		SET
+			owner_id = (
+				SELECT MIN(PU.user_id)
+				FROM project_users AS PU
+				WHERE PU.project_id = P.id AND PU.user_id != $1
+			),
+			updated_at = $2
+		WHERE
+			P.owner_id = $1
+			AND P.deleted_at IS NULL
+			AND EXISTS (
+				SELECT 1
+				FROM project_users AS PU
+				WHERE PU.project_id = P.id AND PU.user_id != $1
+			)`
+	now := time.Now()
+	transferred, err := r.getDb(ctx).Exec(ctx, query, ownerID, now)
+	if err != nil {
+		return 0, 0, r.handleError(err)
+	}
+	query = `
+		UPDATE projects
+		SET deleted_at = $2, updated_at = $2
+		WHERE owner_id = $1 AND deleted_at IS NULL`
+	deleted, err := r.getDb(ctx).Exec(ctx, query, ownerID, now)
+	if err != nil {
+		return 0, 0, r.handleError(err)
+	}
+	return int(transferred.RowsAffected()), int(deleted.RowsAffected()), nil
+}
+
+func (r *PgProjectRepository) RemoveMemberFromAll(ctx context.Context, memberID int) error {
+	query := `DELETE FROM project_users WHERE user_id = $1`
+	if _, err := r.getDb(ctx).Exec(ctx, query, memberID); err != nil {
+		return r.handleError(err)
+	}
+	return nil
+}
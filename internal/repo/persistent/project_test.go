@@ -120,3 +120,50 @@ func TestProjectGetList(t *testing.T) {
 		require.ErrorIs(t, err, repo.ErrInternal)
 	})
 }
+
+func TestProjectTransferOwnership(t *testing.T) {
+	cleanDB(t)
+	ownerID := mustAddUser(t, testEmail)
+	memberID := mustAddUser(t, testEmail1)
+	sharedID := mustAddProject(t, ownerID)
+	mustAddMembers(t, sharedID, []int{memberID})
+	_ = mustAddProject(t, ownerID) // project without other members
+
+	t.Run("success", func(t *testing.T) {
+		transferred, deleted, err := projectRepo.TransferOwnership(t.Context(), ownerID)
+		require.NoError(t, err)
+		require.Equal(t, 1, transferred)
+		require.Equal(t, 1, deleted)
+		p, err := projectRepo.GetOwned(t.Context(), sharedID, memberID)
+		require.NoError(t, err)
+		require.Equal(t, memberID, p.OwnerID)
+	})
+	t.Run("nothing to transfer", func(t *testing.T) {
+		transferred, deleted, err := projectRepo.TransferOwnership(t.Context(), ownerID)
+		require.NoError(t, err)
+		require.Equal(t, 0, transferred)
+		require.Equal(t, 0, deleted)
+	})
+	t.Run("database internal error", func(t *testing.T) {
+		_, _, err := projectRepo.TransferOwnership(getBadContext(t), ownerID)
+		require.ErrorIs(t, err, repo.ErrInternal)
+	})
+}
+
+func TestProjectRemoveMemberFromAll(t *testing.T) {
+	cleanDB(t)
+	initProject(t)
+	memberID := mustAddUser(t, testEmail1)
+	mustAddMembers(t, 1, []int{memberID})
+
+	t.Run("success", func(t *testing.T) {
+		err := projectRepo.RemoveMemberFromAll(t.Context(), memberID)
+		require.NoError(t, err)
+		err = projectRepo.IsMember(t.Context(), 1, memberID)
+		require.ErrorIs(t, err, repo.ErrNotFound)
+	})
+	t.Run("database internal error", func(t *testing.T) {
+		err := projectRepo.RemoveMemberFromAll(getBadContext(t), memberID)
+		require.ErrorIs(t, err, repo.ErrInternal)
+	})
+}
@@ -93,3 +93,12 @@ func (r *PgRefreshTokenRepository) DeleteRevokedAndOldTokens(ctx context.Context
 	}
 	return int(tag.RowsAffected()), nil
 }
+
+func (r *PgRefreshTokenRepository) DeleteAllUsersTokens(ctx context.Context, userID int) (int, error) {
+	query := `DELETE FROM refresh_tokens WHERE user_id = $1`
+	tag, err := r.getDb(ctx).Exec(ctx, query, userID)
+	if err != nil {
+		return 0, r.handleError(err)
+	}
+	return int(tag.RowsAffected()), nil
+}
@@ -0,0 +1,47 @@
+package persistent
+
+import (
+	"context"
+	"task-trail/internal/usecase/dto"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PgTaskRepository struct {
+	PgRepostitory
+}
+
+func NewTaskRepo(db *pgxpool.Pool) *PgTaskRepository {
+	return &PgTaskRepository{PgRepostitory{pg: db}}
+}
+
+func (r *PgTaskRepository) GetByAuthor(ctx context.Context, authorID int) ([]*dto.Task, error) {
+	query := `
+		SELECT id, COALESCE(project_id, 0), name, COALESCE(description, ''), created_at, author_id
+		FROM tasks
+		WHERE author_id = $1 AND deleted_at IS NULL
+		ORDER BY created_at`
+	rows, err := r.getDb(ctx).Query(ctx, query, authorID)
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+	items, err := ScanRows(rows, func(row pgx.Rows) (*dto.Task, error) {
+		var item dto.Task
+		if err := row.Scan(
+			&item.ID,
+			&item.ProjectID,
+			&item.Name,
+			&item.Description,
+			&item.CreatedAt,
+			&item.AuthorID,
+		); err != nil {
+			return nil, err
+		}
+		return &item, nil
+	})
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+	return items, nil
+}
@@ -2,12 +2,16 @@ package persistent
 
 import (
 	"context"
+	"task-trail/internal/pkg/tracing"
 	"task-trail/internal/repo"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel"
 )
 
+var tracer = otel.Tracer("task-trail/internal/repo/persistent")
+
 type PgTxManager struct {
 	db *pgxpool.Pool
 }
@@ -16,7 +20,11 @@ func NewPgTxManager(db *pgxpool.Pool) *PgTxManager {
 	return &PgTxManager{db: db}
 }
 
-func (u *PgTxManager) DoWithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+// DoWithTx runs fn in the transaction, queries of fn are traced as children of the transaction span.
+func (u *PgTxManager) DoWithTx(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	ctx, span := tracer.Start(ctx, "PgTxManager.DoWithTx")
+	defer func() { tracing.End(span, err) }()
+
 	tx, err := u.db.BeginTx(ctx, pgx.TxOptions{})
 	if err != nil {
 		return err
@@ -30,7 +38,6 @@ func (u *PgTxManager) DoWithTx(ctx context.Context, fn func(ctx context.Context)
 		return err
 	}
 	return tx.Commit(ctx)
-
 }
 
 type txKey struct{}
@@ -8,6 +8,7 @@ import (
 	"task-trail/internal/usecase/dto"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -21,13 +22,13 @@ func NewUserRepo(db *pgxpool.Pool) *PgUserRepository {
 
 func (r *PgUserRepository) Create(ctx context.Context, dto *dto.UserCreate) (int, error) {
 	substring := `
-		(email, password_hash) 
-		VALUES ($1, $2)`
-	args := []any{dto.Email, dto.PasswordHash}
+		(email, password_hash, is_activated) 
+		VALUES ($1, $2, $3)`
+	args := []any{dto.Email, dto.PasswordHash, !dto.IsInvited}
 	if dto.IsVerified {
 		substring = `
-			(email, password_hash, verified_at) 
-			VALUES ($1, $2, $3)`
+			(email, password_hash, is_activated, verified_at) 
+			VALUES ($1, $2, $3, $4)`
 		args = append(args, time.Now())
 	}
 	var id int
@@ -65,7 +66,7 @@ func (r *PgUserRepository) GetByID(ctx context.Context, ID int) (*dto.User, erro
 func (r *PgUserRepository) getOne(ctx context.Context, fieldName string, value any) (*dto.User, error) {
 	var user dto.User
 	query := fmt.Sprintf(`
-		SELECT id, email, password_hash, verified_at, username, avatar_id 
+		SELECT id, email, password_hash, verified_at, username, avatar_id, created_at, deletion_requested_at, is_activated, language
 		FROM users 
 		WHERE %s = $1
 		`,
@@ -73,7 +74,18 @@ func (r *PgUserRepository) getOne(ctx context.Context, fieldName string, value a
 	)
 	if err := r.getDb(ctx).
 		QueryRow(ctx, query, value).
-		Scan(&user.ID, &user.Email, &user.PasswordHash, &user.VerifiedAt, &user.Username, &user.AvatarID); err != nil {
+		Scan(
+			&user.ID,
+			&user.Email,
+			&user.PasswordHash,
+			&user.VerifiedAt,
+			&user.Username,
+			&user.AvatarID,
+			&user.CreatedAt,
+			&user.DeletionRequestedAt,
+			&user.IsActivated,
+			&user.Language,
+		); err != nil {
 		return nil, r.handleError(err)
 	}
 	return &user, nil
@@ -95,6 +107,12 @@ func (r *PgUserRepository) Update(ctx context.Context, dto *dto.UserUpdate) erro
 	if dto.Username != "" {
 		kwargs["username"] = dto.Username
 	}
+	if dto.Activated {
+		kwargs["is_activated"] = true
+	}
+	if dto.Language != "" {
+		kwargs["language"] = dto.Language
+	}
 	if len(kwargs) == 0 {
 		return nil
 	}
@@ -147,3 +165,92 @@ func (r *PgUserRepository) GetIdsByEmails(ctx context.Context, emails []string)
 	}
 	return retVal, nil
 }
+
+func (r *PgUserRepository) GetLanguagesByEmails(ctx context.Context, emails []string) (map[string]string, error) {
+	query := `SELECT email, language FROM users WHERE email = ANY($1) AND language IS NOT NULL`
+	rows, err := r.getDb(ctx).Query(ctx, query, emails)
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+	defer rows.Close()
+
+	retVal := make(map[string]string, len(emails))
+	for rows.Next() {
+		var email, language string
+		if err := rows.Scan(&email, &language); err != nil {
+			return nil, r.handleError(err)
+		}
+		retVal[email] = language
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.handleError(err)
+	}
+	return retVal, nil
+}
+
+func (r *PgUserRepository) SetDeletionRequestedAt(ctx context.Context, ID int, requestedAt *time.Time) error {
+	query := `
+		UPDATE users
+		SET deletion_requested_at = $1
+		WHERE id = $2 AND deleted_at IS NULL`
+	tag, err := r.getDb(ctx).Exec(ctx, query, requestedAt, ID)
+	if err != nil {
+		return r.handleError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+func (r *PgUserRepository) GetScheduledForDeletion(ctx context.Context, olderThan int) ([]int, error) {
+	query := `
+		SELECT id
+		FROM users
+		WHERE
+			deleted_at IS NULL
+			AND deletion_requested_at < NOW() - make_interval(days => $1)
+	`
+	rows, err := r.getDb(ctx).Query(ctx, query, olderThan)
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+	ids, err := ScanRows(rows, func(row pgx.Rows) (*int, error) {
+		var id int
+		if err := row.Scan(&id); err != nil {
+			return nil, err
+		}
+		return &id, nil
+	})
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+	retVal := make([]int, len(ids))
+	for i, id := range ids {
+		retVal[i] = *id
+	}
+	return retVal, nil
+}
+
+func (r *PgUserRepository) Anonymize(ctx context.Context, ID int) error {
+	// password hash "!" can't be produced by any hashing algorithm, so login is impossible
+	query := `
+		UPDATE users
+		SET
+			email = 'deleted-' || id || '@deleted.invalid',
+			password_hash = '!',
+			username = NULL,
+			avatar_id = NULL,
+			verified_at = NULL,
+			deletion_requested_at = NULL,
+			deleted_at = $1
+		WHERE id = $2 AND deleted_at IS NULL`
+	tag, err := r.getDb(ctx).Exec(ctx, query, time.Now(), ID)
+	if err != nil {
+		return r.handleError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
@@ -211,3 +211,125 @@ func TestUserUpdateByID(t *testing.T) {
 	})
 
 }
+
+func TestUserActivation(t *testing.T) {
+	ctx := t.Context()
+	cleanDB(t)
+	t.Run("regular user is activated", func(t *testing.T) {
+		id, err := userRepo.Create(ctx, &basicUser)
+		require.NoError(t, err)
+		user, err := userRepo.GetByID(ctx, id)
+		require.NoError(t, err)
+		require.True(t, user.IsActivated)
+	})
+	t.Run("invited user is not activated", func(t *testing.T) {
+		id, err := userRepo.Create(ctx, &dto.UserCreate{Email: testEmail1, PasswordHash: " ", IsVerified: true, IsInvited: true})
+		require.NoError(t, err)
+		user, err := userRepo.GetByID(ctx, id)
+		require.NoError(t, err)
+		require.False(t, user.IsActivated)
+		require.NotNil(t, user.VerifiedAt)
+
+		err = userRepo.Update(ctx, &dto.UserUpdate{ID: id, PasswordHash: "123", Activated: true})
+		require.NoError(t, err)
+		user, err = userRepo.GetByID(ctx, id)
+		require.NoError(t, err)
+		require.True(t, user.IsActivated)
+		require.Equal(t, "123", user.PasswordHash)
+	})
+}
+
+func TestUserGetLanguagesByEmails(t *testing.T) {
+	ctx := t.Context()
+	cleanDB(t)
+	_, err := userRepo.Create(ctx, &basicUser)
+	require.NoError(t, err)
+	id, err := userRepo.Create(ctx, &dto.UserCreate{Email: testEmail1, PasswordHash: "123"})
+	require.NoError(t, err)
+	require.NoError(t, userRepo.Update(ctx, &dto.UserUpdate{ID: id, Language: "ru"}))
+
+	t.Run("success", func(t *testing.T) {
+		languages, err := userRepo.GetLanguagesByEmails(ctx, []string{testEmail, testEmail1, "unknown@test.test"})
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{testEmail1: "ru"}, languages)
+	})
+	t.Run("language is nil until the user chooses one", func(t *testing.T) {
+		user, err := userRepo.GetByEmail(ctx, testEmail)
+		require.NoError(t, err)
+		require.Nil(t, user.Language)
+		user, err = userRepo.GetByID(ctx, id)
+		require.NoError(t, err)
+		require.Equal(t, "ru", *user.Language)
+	})
+	t.Run("internal db error", func(t *testing.T) {
+		languages, err := userRepo.GetLanguagesByEmails(getBadContext(t), []string{testEmail})
+		require.Nil(t, languages)
+		require.ErrorIs(t, err, repo.ErrInternal)
+	})
+}
+
+func TestUserScheduledDeletion(t *testing.T) {
+	ctx := t.Context()
+	cleanDB(t)
+	id := mustAddUser(t, testEmail)
+	_ = mustAddUser(t, testEmail1)
+
+	t.Run("schedule deletion", func(t *testing.T) {
+		requestedAt := time.Now().AddDate(0, 0, -10)
+		err := userRepo.SetDeletionRequestedAt(ctx, id, &requestedAt)
+		require.NoError(t, err)
+		user, err := userRepo.GetByID(ctx, id)
+		require.NoError(t, err)
+		require.NotNil(t, user.DeletionRequestedAt)
+	})
+	t.Run("get scheduled for deletion", func(t *testing.T) {
+		ids, err := userRepo.GetScheduledForDeletion(ctx, 7)
+		require.NoError(t, err)
+		require.Equal(t, []int{id}, ids)
+		ids, err = userRepo.GetScheduledForDeletion(ctx, 30)
+		require.NoError(t, err)
+		require.Empty(t, ids)
+	})
+	t.Run("cancel deletion", func(t *testing.T) {
+		err := userRepo.SetDeletionRequestedAt(ctx, id, nil)
+		require.NoError(t, err)
+		user, err := userRepo.GetByID(ctx, id)
+		require.NoError(t, err)
+		require.Nil(t, user.DeletionRequestedAt)
+	})
+	t.Run("user not found", func(t *testing.T) {
+		err := userRepo.SetDeletionRequestedAt(ctx, 3, nil)
+		require.ErrorIs(t, err, repo.ErrNotFound)
+	})
+	t.Run("internal db error", func(t *testing.T) {
+		_, err := userRepo.GetScheduledForDeletion(getBadContext(t), 7)
+		require.ErrorIs(t, err, repo.ErrInternal)
+	})
+}
+
+func TestUserAnonymize(t *testing.T) {
+	ctx := t.Context()
+	cleanDB(t)
+	id := mustAddUser(t, testEmail)
+
+	t.Run("success", func(t *testing.T) {
+		err := userRepo.Anonymize(ctx, id)
+		require.NoError(t, err)
+		user, err := userRepo.GetByID(ctx, id)
+		require.NoError(t, err)
+		require.NotEqual(t, testEmail, user.Email)
+		require.Nil(t, user.Username)
+		require.Nil(t, user.VerifiedAt)
+		isTaken, err := userRepo.EmailIsTaken(ctx, testEmail)
+		require.NoError(t, err)
+		require.False(t, isTaken)
+	})
+	t.Run("already deleted", func(t *testing.T) {
+		err := userRepo.Anonymize(ctx, id)
+		require.ErrorIs(t, err, repo.ErrNotFound)
+	})
+	t.Run("internal db error", func(t *testing.T) {
+		err := userRepo.Anonymize(getBadContext(t), id)
+		require.ErrorIs(t, err, repo.ErrInternal)
+	})
+}
@@ -0,0 +1,228 @@
+package persistent
+
+import (
+	"context"
+	"task-trail/internal/repo"
+	"task-trail/internal/usecase/dto"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PgWebhookRepository struct {
+	PgRepostitory
+}
+
+func NewWebhookRepo(db *pgxpool.Pool) *PgWebhookRepository {
+	return &PgWebhookRepository{PgRepostitory{pg: db}}
+}
+
+const webhookColumns = "id, project_id, url, secret, events, created_at"
+
+func scanWebhook(row pgx.Row) (*dto.Webhook, error) {
+	var w dto.Webhook
+	if err := row.Scan(&w.ID, &w.ProjectID, &w.URL, &w.Secret, &w.Events, &w.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+func (r *PgWebhookRepository) Create(ctx context.Context, data *dto.WebhookCreate) (*dto.Webhook, error) {
+	events := data.Events
+	if events == nil {
+		events = []string{}
+	}
+	query := `
+		INSERT INTO project_webhooks (project_id, url, secret, events)
+		VALUES ($1, $2, $3, $4)
+		RETURNING ` + webhookColumns
+	retVal, err := scanWebhook(r.getDb(ctx).QueryRow(ctx, query, data.ProjectID, data.URL, data.Secret, events))
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+	return retVal, nil
+}
+
+func (r *PgWebhookRepository) GetList(ctx context.Context, projectID int) ([]*dto.Webhook, error) {
+	query := "SELECT " + webhookColumns + " FROM project_webhooks WHERE project_id = $1 ORDER BY id"
+	return r.getWebhooks(ctx, query, projectID)
+}
+
+func (r *PgWebhookRepository) GetByID(ctx context.Context, projectID int, ID int) (*dto.Webhook, error) {
+	query := "SELECT " + webhookColumns + " FROM project_webhooks WHERE project_id = $1 AND id = $2"
+	retVal, err := scanWebhook(r.getDb(ctx).QueryRow(ctx, query, projectID, ID))
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+	return retVal, nil
+}
+
+func (r *PgWebhookRepository) Delete(ctx context.Context, projectID int, ID int) error {
+	tag, err := r.getDb(ctx).Exec(ctx, "DELETE FROM project_webhooks WHERE project_id = $1 AND id = $2", projectID, ID)
+	if err != nil {
+		return r.handleError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+func (r *PgWebhookRepository) GetSubscribed(ctx context.Context, projectID int, eventType string) ([]*dto.Webhook, error) {
+	query := `
+		SELECT ` + webhookColumns + `
+		FROM project_webhooks
+		WHERE project_id = $1 AND (cardinality(events) = 0 OR $2 = ANY(events))
+		ORDER BY id
+	`
+	return r.getWebhooks(ctx, query, projectID, eventType)
+}
+
+func (r *PgWebhookRepository) getWebhooks(ctx context.Context, query string, args ...any) ([]*dto.Webhook, error) {
+	rows, err := r.getDb(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+	retVal, err := ScanRows(rows, func(row pgx.Rows) (*dto.Webhook, error) {
+		return scanWebhook(row)
+	})
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+	return retVal, nil
+}
+
+const deliveryColumns = `
+	d.id, d.webhook_id, d.event_id, d.event_type, d.payload, d.status, d.attempts,
+	d.response_code, d.last_error, d.next_attempt_at, d.created_at, d.delivered_at
+`
+
+func deliveryFields(d *dto.WebhookDelivery) []any {
+	return []any{
+		&d.ID,
+		&d.WebhookID,
+		&d.EventID,
+		&d.EventType,
+		&d.Payload,
+		&d.Status,
+		&d.Attempts,
+		&d.ResponseCode,
+		&d.LastError,
+		&d.NextAttemptAt,
+		&d.CreatedAt,
+		&d.DeliveredAt,
+	}
+}
+
+func (r *PgWebhookRepository) CreateDelivery(ctx context.Context, data *dto.WebhookDeliveryCreate) (*dto.WebhookDelivery, error) {
+	query := `
+		WITH d AS (
+			INSERT INTO webhook_deliveries (webhook_id, event_id, event_type, payload, next_attempt_at)
+			VALUES ($1, $2, $3, $4, COALESCE($5, CURRENT_TIMESTAMP))
+			RETURNING *
+		)
+		SELECT ` + deliveryColumns + `, w.url, w.secret
+		FROM d JOIN project_webhooks w ON w.id = d.webhook_id
+	`
+	var d dto.WebhookDelivery
+	err := r.getDb(ctx).
+		QueryRow(ctx, query, data.WebhookID, data.EventID, data.EventType, data.Payload, data.NextAttemptAt).
+		Scan(append(deliveryFields(&d), &d.URL, &d.Secret)...)
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+	return &d, nil
+}
+
+func (r *PgWebhookRepository) ClaimPendingDeliveries(ctx context.Context, limit int, until time.Time) ([]*dto.WebhookDelivery, error) {
+	query := `
+		WITH d AS (
+			UPDATE webhook_deliveries
+			SET next_attempt_at = $3
+			WHERE id IN (
+				SELECT id
+				FROM webhook_deliveries
+				WHERE status = 'pending' AND next_attempt_at <= $1
+				ORDER BY next_attempt_at, id
+				LIMIT $2
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING *
+		)
+		SELECT ` + deliveryColumns + `, w.url, w.secret
+		FROM d JOIN project_webhooks w ON w.id = d.webhook_id
+		ORDER BY d.id
+	`
+	rows, err := r.getDb(ctx).Query(ctx, query, time.Now(), limit, until)
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+	retVal, err := ScanRows(rows, func(row pgx.Rows) (*dto.WebhookDelivery, error) {
+		var d dto.WebhookDelivery
+		if err := row.Scan(append(deliveryFields(&d), &d.URL, &d.Secret)...); err != nil {
+			return nil, err
+		}
+		return &d, nil
+	})
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+	return retVal, nil
+}
+
+func (r *PgWebhookRepository) SaveAttempt(ctx context.Context, data *dto.WebhookAttempt) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET
+			status = $1,
+			attempts = attempts + 1,
+			response_code = $2,
+			last_error = $3,
+			next_attempt_at = COALESCE($4, next_attempt_at),
+			delivered_at = CASE WHEN $1 = 'delivered' THEN $5::timestamptz END
+		WHERE id = $6 AND status = 'pending'
+	`
+	tag, err := r.getDb(ctx).Exec(
+		ctx,
+		query,
+		data.Status,
+		data.ResponseCode,
+		data.LastError,
+		data.NextAttemptAt,
+		time.Now(),
+		data.DeliveryID,
+	)
+	if err != nil {
+		return r.handleError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return repo.ErrNotFound
+	}
+	return nil
+}
+
+func (r *PgWebhookRepository) GetDeliveries(ctx context.Context, data *dto.WebhookDeliveryList) ([]*dto.WebhookDelivery, error) {
+	query := `
+		SELECT ` + deliveryColumns + `
+		FROM webhook_deliveries d
+		WHERE d.webhook_id = $1
+		ORDER BY d.created_at DESC, d.id DESC
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.getDb(ctx).Query(ctx, query, data.WebhookID, data.Limit, data.Offset)
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+	retVal, err := ScanRows(rows, func(row pgx.Rows) (*dto.WebhookDelivery, error) {
+		var d dto.WebhookDelivery
+		if err := row.Scan(deliveryFields(&d)...); err != nil {
+			return nil, err
+		}
+		return &d, nil
+	})
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+	return retVal, nil
+}
@@ -0,0 +1,158 @@
+//go:build integration
+
+package persistent
+
+import (
+	"task-trail/internal/repo"
+	"task-trail/internal/usecase/dto"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhooks(t *testing.T) {
+	cleanDB(t)
+	ctx := t.Context()
+	uID := mustAddUser(t, testEmail)
+	pID := mustAddProject(t, uID)
+
+	t.Run("create", func(t *testing.T) {
+		w, err := webhookRepo.Create(ctx, &dto.WebhookCreate{ProjectID: pID, URL: "https://test.test/all", Secret: "secret"})
+		require.NoError(t, err)
+		require.Equal(t, 1, w.ID)
+		require.Empty(t, w.Events)
+		w, err = webhookRepo.Create(ctx, &dto.WebhookCreate{
+			ProjectID: pID,
+			URL:       "https://test.test/tasks",
+			Secret:    "secret",
+			Events:    []string{"project.members_added"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"project.members_added"}, w.Events)
+	})
+	t.Run("project not found", func(t *testing.T) {
+		_, err := webhookRepo.Create(ctx, &dto.WebhookCreate{ProjectID: 100, URL: "https://test.test", Secret: "secret"})
+		require.ErrorIs(t, err, repo.ErrNotFound)
+	})
+	t.Run("get subscribed", func(t *testing.T) {
+		hooks, err := webhookRepo.GetSubscribed(ctx, pID, "project.members_added")
+		require.NoError(t, err)
+		require.Len(t, hooks, 2)
+		hooks, err = webhookRepo.GetSubscribed(ctx, pID, "project.members_added")
+		require.NoError(t, err)
+		require.Len(t, hooks, 1)
+		require.Equal(t, "https://test.test/all", hooks[0].URL)
+	})
+	t.Run("get", func(t *testing.T) {
+		hooks, err := webhookRepo.GetList(ctx, pID)
+		require.NoError(t, err)
+		require.Len(t, hooks, 2)
+		w, err := webhookRepo.GetByID(ctx, pID, 2)
+		require.NoError(t, err)
+		require.Equal(t, "secret", w.Secret)
+		_, err = webhookRepo.GetByID(ctx, pID+1, 2)
+		require.ErrorIs(t, err, repo.ErrNotFound)
+	})
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, webhookRepo.Delete(ctx, pID, 2))
+		require.ErrorIs(t, webhookRepo.Delete(ctx, pID, 2), repo.ErrNotFound)
+	})
+	t.Run("internal db error", func(t *testing.T) {
+		hooks, err := webhookRepo.GetList(getBadContext(t), pID)
+		require.Nil(t, hooks)
+		require.ErrorIs(t, err, repo.ErrInternal)
+	})
+}
+
+func TestWebhookDeliveries(t *testing.T) {
+	cleanDB(t)
+	ctx := t.Context()
+	uID := mustAddUser(t, testEmail)
+	pID := mustAddProject(t, uID)
+	w, err := webhookRepo.Create(ctx, &dto.WebhookCreate{ProjectID: pID, URL: "https://test.test", Secret: "secret"})
+	require.NoError(t, err)
+
+	t.Run("create delivery", func(t *testing.T) {
+		d, err := webhookRepo.CreateDelivery(ctx, &dto.WebhookDeliveryCreate{
+			WebhookID: w.ID,
+			EventID:   testEventID,
+			EventType: "project.members_added",
+			Payload:   `{"type":"project.members_added"}`,
+		})
+		require.NoError(t, err)
+		require.Equal(t, dto.WebhookDeliveryPending, d.Status)
+		require.Equal(t, w.URL, d.URL)
+		require.Equal(t, w.Secret, d.Secret)
+		_, err = webhookRepo.CreateDelivery(ctx, &dto.WebhookDeliveryCreate{WebhookID: w.ID, EventID: testEventID1, EventType: "project.members_added", Payload: "{}"})
+		require.NoError(t, err)
+		_, err = webhookRepo.CreateDelivery(ctx, &dto.WebhookDeliveryCreate{WebhookID: w.ID, EventID: testEventID, EventType: "project.members_added", Payload: "{}"})
+		require.ErrorIs(t, err, repo.ErrConflict)
+	})
+	t.Run("claimed deliveries are postponed", func(t *testing.T) {
+		until := time.Now().Add(time.Minute)
+		deliveries, err := webhookRepo.ClaimPendingDeliveries(ctx, 1, until)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		require.Equal(t, `{"type":"project.members_added"}`, deliveries[0].Payload)
+		require.Equal(t, w.URL, deliveries[0].URL)
+		require.WithinDuration(t, until, deliveries[0].NextAttemptAt, time.Millisecond)
+		other, err := webhookRepo.ClaimPendingDeliveries(ctx, 10, until)
+		require.NoError(t, err)
+		require.Len(t, other, 1)
+		require.NotEqual(t, deliveries[0].ID, other[0].ID)
+		none, err := webhookRepo.ClaimPendingDeliveries(ctx, 10, until)
+		require.NoError(t, err)
+		require.Empty(t, none)
+	})
+	t.Run("save attempt", func(t *testing.T) {
+		code := 200
+		require.NoError(t, webhookRepo.SaveAttempt(ctx, &dto.WebhookAttempt{DeliveryID: 1, Status: dto.WebhookDeliveryDelivered, ResponseCode: &code}))
+		require.ErrorIs(t, webhookRepo.SaveAttempt(ctx, &dto.WebhookAttempt{DeliveryID: 1, Status: dto.WebhookDeliveryDelivered}), repo.ErrNotFound)
+		failed := 500
+		msg := "unexpected response status: 500"
+		next := time.Now().Add(time.Hour)
+		err := webhookRepo.SaveAttempt(ctx, &dto.WebhookAttempt{
+			DeliveryID:    2,
+			Status:        dto.WebhookDeliveryPending,
+			ResponseCode:  &failed,
+			LastError:     &msg,
+			NextAttemptAt: &next,
+		})
+		require.NoError(t, err)
+		deliveries, err := webhookRepo.ClaimPendingDeliveries(ctx, 10, time.Now())
+		require.NoError(t, err)
+		require.Empty(t, deliveries)
+	})
+	t.Run("get deliveries", func(t *testing.T) {
+		deliveries, err := webhookRepo.GetDeliveries(ctx, &dto.WebhookDeliveryList{WebhookID: w.ID, Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, deliveries, 2)
+		require.Equal(t, 2, deliveries[0].ID)
+		require.Equal(t, dto.WebhookDeliveryPending, deliveries[0].Status)
+		require.Equal(t, 500, *deliveries[0].ResponseCode)
+		require.Equal(t, 1, deliveries[0].Attempts)
+		require.Equal(t, dto.WebhookDeliveryDelivered, deliveries[1].Status)
+		require.NotNil(t, deliveries[1].DeliveredAt)
+	})
+	t.Run("delivery created as claimed", func(t *testing.T) {
+		until := time.Now().Add(time.Hour)
+		d, err := webhookRepo.CreateDelivery(ctx, &dto.WebhookDeliveryCreate{
+			WebhookID:     w.ID,
+			EventID:       "8f1c9f36-5c1e-4a43-9a4d-1b0f6c3f4f13",
+			EventType:     "webhook.test",
+			Payload:       "{}",
+			NextAttemptAt: &until,
+		})
+		require.NoError(t, err)
+		require.WithinDuration(t, until, d.NextAttemptAt, time.Millisecond)
+		deliveries, err := webhookRepo.ClaimPendingDeliveries(ctx, 10, time.Now())
+		require.NoError(t, err)
+		require.Empty(t, deliveries)
+	})
+	t.Run("internal db error", func(t *testing.T) {
+		deliveries, err := webhookRepo.ClaimPendingDeliveries(getBadContext(t), 10, time.Now())
+		require.Nil(t, deliveries)
+		require.ErrorIs(t, err, repo.ErrInternal)
+	})
+}
@@ -2,44 +2,70 @@ package tasks
 
 import (
 	"context"
-	"os"
 	"task-trail/internal/pkg/logger"
+	"task-trail/internal/pkg/ratelimit"
 	"task-trail/internal/repo"
-
-	"github.com/robfig/cron/v3"
+	"task-trail/internal/usecase"
+	"time"
 )
 
-func CleanupRefreshTokens(r repo.RefreshTokenRepository, l logger.Logger) {
-	startNewTask("0 3 * * *", l, "cleanup refresh tokens", func() {
-		deleted, err := r.DeleteRevokedAndOldTokens(context.Background(), 7)
+func CleanupRefreshTokens(s *Scheduler, r repo.RefreshTokenRepository, l logger.Logger) {
+	s.add("0 3 * * *", "cleanup refresh tokens", func(ctx context.Context) error {
+		deleted, err := r.DeleteRevokedAndOldTokens(ctx, 7)
 		if err != nil {
-			l.Error("failed to delete old and revoked refresh tokens", "error", err)
-			return
+			l.ErrorContext(ctx, "failed to delete old and revoked refresh tokens", "error", err)
+			return err
 		}
-		l.Info("complete delete old and revoked refresh tokens", "deleted_tokens", deleted)
+		l.InfoContext(ctx, "complete delete old and revoked refresh tokens", "deleted_tokens", deleted)
+		return nil
+	})
+}
 
+func CleanupEmailTokens(s *Scheduler, r repo.EmailTokenRepository, l logger.Logger) {
+	s.add("30 3 * * *", "cleanup email tokens", func(ctx context.Context) error {
+		deleted, err := r.DeleteUsedAndOldTokens(ctx, 7)
+		if err != nil {
+			l.ErrorContext(ctx, "failed to delete old and used email tokens", "error", err)
+			return err
+		}
+		l.InfoContext(ctx, "complete delete old and used email tokens", "deleted_tokens", deleted)
+		return nil
 	})
 }
 
-func CleanupEmailTokens(r repo.EmailTokenRepository, l logger.Logger) {
-	startNewTask("30 3 * * *", l, "cleanup email tokens", func() {
-		deleted, err := r.DeleteUsedAndOldTokens(context.Background(), 7)
+func DeleteScheduledUsers(s *Scheduler, uc usecase.User, l logger.Logger) {
+	s.add("0 4 * * *", "delete scheduled users", func(ctx context.Context) error {
+		deleted, err := uc.DeleteScheduled(ctx)
 		if err != nil {
-			l.Error("failed to delete old and used email tokens", "error", err)
-			return
+			l.ErrorContext(ctx, "failed to delete users scheduled for deletion", "error", err, "deleted_users", deleted)
+			return err
 		}
-		l.Info("complete delete old and used email tokens", "deleted_tokens", deleted)
+		l.InfoContext(ctx, "complete delete users scheduled for deletion", "deleted_users", deleted)
+		return nil
+	})
+}
 
+func CleanupOutbox(s *Scheduler, r repo.OutboxRepository, l logger.Logger) {
+	s.add("0 5 * * *", "cleanup email outbox", func(ctx context.Context) error {
+		deleted, err := r.DeleteSentAndOld(ctx, 7)
+		if err != nil {
+			l.ErrorContext(ctx, "failed to delete old sent emails", "error", err)
+			return err
+		}
+		l.InfoContext(ctx, "complete delete old sent emails", "deleted_emails", deleted)
+		return nil
 	})
 }
 
-func startNewTask(spec string, l logger.Logger, name string, f func()) {
-	c := cron.New()
-	_, err := c.AddFunc(spec, f)
-	if err != nil {
-		l.Error("cron task start failed", "error", err.Error())
-		os.Exit(1)
-	}
-	c.Start()
-	l.Info("cron task successfully started", "task name", name, "spec", spec)
+// CleanupRateLimits deletes buckets unused for idle time, idle must be at least the longest limit period.
+func CleanupRateLimits(s *Scheduler, store ratelimit.Store, idle time.Duration, l logger.Logger) {
+	s.add("*/10 * * * *", "cleanup rate limits", func(ctx context.Context) error {
+		deleted, err := store.Cleanup(ctx, idle)
+		if err != nil {
+			l.ErrorContext(ctx, "failed to delete idle rate limit buckets", "error", err)
+			return err
+		}
+		l.DebugContext(ctx, "complete delete idle rate limit buckets", "deleted_buckets", deleted)
+		return nil
+	})
 }
@@ -0,0 +1,28 @@
+package tasks
+
+import (
+	"context"
+	"task-trail/internal/pkg/logger"
+	"task-trail/internal/usecase"
+	"task-trail/internal/usecase/dto"
+)
+
+func SendDigests(s *Scheduler, uc usecase.Digest, dailySpec string, weeklySpec string, l logger.Logger) {
+	s.add(dailySpec, "send daily digests", sendDigests(uc, dto.DigestDaily, l))
+	s.add(weeklySpec, "send weekly digests", sendDigests(uc, dto.DigestWeekly, l))
+}
+
+func sendDigests(uc usecase.Digest, frequency dto.DigestFrequency, l logger.Logger) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		res, err := uc.Send(ctx, frequency)
+		if err != nil {
+			l.ErrorContext(ctx, "failed to send digests", "error", err, "frequency", frequency)
+			return err
+		}
+		if res.Failed > 0 {
+			l.ErrorContext(ctx, "digests are not sent and kept for the next run", "failed_digests", res.Failed, "frequency", frequency)
+		}
+		l.InfoContext(ctx, "complete send digests", "sent_digests", res.Sent, "frequency", frequency)
+		return nil
+	}
+}
@@ -0,0 +1,28 @@
+package tasks
+
+import (
+	"context"
+	"task-trail/internal/pkg/logger"
+	"task-trail/internal/usecase"
+)
+
+func DeliverOutbox(s *Scheduler, uc usecase.Outbox, spec string, l logger.Logger) {
+	s.add(spec, "deliver email outbox", func(ctx context.Context) error {
+		res, err := uc.Deliver(ctx)
+		if err != nil && res == nil {
+			l.ErrorContext(ctx, "failed to deliver emails from outbox", "error", err)
+			return err
+		}
+		if err != nil {
+			// messages with unsaved results stay claimed and are sent again after the claim expires
+			l.ErrorContext(ctx, "failed to save results of outbox delivery", "error", err)
+		}
+		if res.Dead > 0 {
+			l.ErrorContext(ctx, "emails moved to dead state after all delivery attempts", "dead_emails", res.Dead)
+		}
+		if res.Sent > 0 || res.Retried > 0 {
+			l.InfoContext(ctx, "complete deliver emails from outbox", "sent_emails", res.Sent, "retried_emails", res.Retried)
+		}
+		return err
+	})
+}
@@ -0,0 +1,76 @@
+package tasks
+
+import (
+	"context"
+	"os"
+	"task-trail/internal/pkg/logger"
+	"task-trail/internal/pkg/metrics"
+	"task-trail/internal/pkg/tracing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("task-trail/internal/tasks")
+
+// cancelTimeout bounds waiting for the tasks to return after they are cancelled,
+// they use the database which is closed right after the scheduler.
+const cancelTimeout = time.Second * 5
+
+// Scheduler runs all background tasks with the single cron, so they are stopped together on shutdown.
+type Scheduler struct {
+	cron    *cron.Cron
+	logger  logger.Logger
+	metrics *metrics.Metrics
+	// ctx is passed to tasks, it is cancelled when running tasks do not complete before the shutdown deadline
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func NewScheduler(l logger.Logger, m *metrics.Metrics) *Scheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Scheduler{cron: cron.New(), logger: l, metrics: m, ctx: ctx, cancel: cancel}
+}
+
+func (s *Scheduler) Start() {
+	s.cron.Start()
+	s.logger.Info("cron scheduler started", "tasks", len(s.cron.Entries()))
+}
+
+// Stop stops scheduling of new runs and waits for running tasks, they are cancelled if ctx is done first
+// and given cancelTimeout to return.
+func (s *Scheduler) Stop(ctx context.Context) error {
+	defer s.cancel()
+	stopped := s.cron.Stop()
+	select {
+	case <-stopped.Done():
+		return nil
+	case <-ctx.Done():
+	}
+	s.cancel()
+	select {
+	case <-stopped.Done():
+	case <-time.After(cancelTimeout):
+		s.logger.Warn("cron tasks did not return after cancellation", "timeout", cancelTimeout.String())
+	}
+	return ctx.Err()
+}
+
+// add schedules the task, its error is already logged by the task and only counted here.
+func (s *Scheduler) add(spec string, name string, f func(ctx context.Context) error) {
+	_, err := s.cron.AddFunc(spec, func() {
+		// every run is the root span of its own trace
+		ctx, span := tracer.Start(s.ctx, name, trace.WithNewRoot())
+		start := time.Now()
+		err := f(ctx)
+		s.metrics.ObserveTask(name, err, time.Since(start))
+		tracing.End(span, err)
+	})
+	if err != nil {
+		s.logger.Error("cron task start failed", "error", err.Error(), "task name", name)
+		os.Exit(1)
+	}
+	s.logger.Info("cron task successfully added", "task name", name, "spec", spec)
+}
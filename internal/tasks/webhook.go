@@ -0,0 +1,24 @@
+package tasks
+
+import (
+	"context"
+	"task-trail/internal/pkg/logger"
+	"task-trail/internal/usecase"
+)
+
+func DeliverWebhooks(s *Scheduler, uc usecase.Webhook, spec string, l logger.Logger) {
+	s.add(spec, "deliver webhooks", func(ctx context.Context) error {
+		res, err := uc.Deliver(ctx)
+		if err != nil {
+			l.ErrorContext(ctx, "failed to deliver webhooks", "error", err)
+			return err
+		}
+		if res.Failed > 0 {
+			l.WarnContext(ctx, "webhook deliveries failed after all attempts", "failed_deliveries", res.Failed)
+		}
+		if res.Delivered > 0 || res.Retried > 0 {
+			l.InfoContext(ctx, "complete deliver webhooks", "delivered", res.Delivered, "retried", res.Retried)
+		}
+		return nil
+	})
+}
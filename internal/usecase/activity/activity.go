@@ -0,0 +1,46 @@
+package activity
+
+import (
+	"context"
+	"errors"
+	"task-trail/internal/customerrors"
+	"task-trail/internal/repo"
+	"task-trail/internal/usecase/dto"
+)
+
+type UseCase struct {
+	activityRepo repo.ActivityRepository
+	projectRepo  repo.ProjectRepository
+	errHandler   customerrors.ErrorHandler
+}
+
+func New(
+	activityRepo repo.ActivityRepository,
+	projectRepo repo.ProjectRepository,
+	errHandler customerrors.ErrorHandler,
+) *UseCase {
+	return &UseCase{
+		activityRepo: activityRepo,
+		projectRepo:  projectRepo,
+		errHandler:   errHandler,
+	}
+}
+
+// GetList returns the activity log page of the project, available for project members.
+func (u *UseCase) GetList(ctx context.Context, data *dto.ActivityList) (*dto.ActivityPage, error) {
+	if err := u.projectRepo.IsMember(ctx, data.ProjectID, data.UserID); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, u.errHandler.NotFound(err, "project not found", "projectID", data.ProjectID, "userID", data.UserID)
+		}
+		return nil, u.errHandler.InternalTrouble(err, "failed to verify user membership", "projectID", data.ProjectID, "userID", data.UserID)
+	}
+	items, err := u.activityRepo.GetList(ctx, data)
+	if err != nil {
+		return nil, u.errHandler.InternalTrouble(err, "failed to get project activity", "projectID", data.ProjectID)
+	}
+	total, err := u.activityRepo.Count(ctx, data)
+	if err != nil {
+		return nil, u.errHandler.InternalTrouble(err, "failed to count project activity", "projectID", data.ProjectID)
+	}
+	return &dto.ActivityPage{Items: items, Total: total}, nil
+}
@@ -0,0 +1,134 @@
+package activity_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"task-trail/internal/customerrors"
+	"task-trail/internal/repo"
+	"task-trail/internal/usecase/activity"
+	"task-trail/internal/usecase/dto"
+	"task-trail/test/mocks"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+type testDeps struct {
+	activityRepo mocks.MockActivityRepository
+	projectRepo  mocks.MockProjectRepository
+}
+
+func MockUseCase(ctrl *gomock.Controller) (*activity.UseCase, *testDeps) {
+	activityRepo := mocks.NewMockActivityRepository(ctrl)
+	projectRepo := mocks.NewMockProjectRepository(ctrl)
+	uc := activity.New(activityRepo, projectRepo, customerrors.NewErrHander())
+	return uc, &testDeps{activityRepo: *activityRepo, projectRepo: *projectRepo}
+}
+
+func TestUseCaseGetList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	data := &dto.ActivityList{ProjectID: 1, UserID: 2, EntityType: dto.ActivityEntityMember, Limit: 20}
+	items := []*dto.Activity{{ID: 1, ProjectID: 1, Action: dto.ActivityMemberAdded, EntityType: dto.ActivityEntityMember, EntityID: 3}}
+
+	tests := []struct {
+		name        string
+		uc          func(ctrl *gomock.Controller) *activity.UseCase
+		want        *dto.ActivityPage
+		wantErr     bool
+		wantErrType customerrors.ErrType
+		wantErrMsg  string
+	}{
+		{
+			name: "success",
+			uc: func(ctrl *gomock.Controller) *activity.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				deps.projectRepo.EXPECT().IsMember(ctx, 1, 2).Return(nil)
+				deps.activityRepo.EXPECT().GetList(ctx, data).Return(items, nil)
+				deps.activityRepo.EXPECT().Count(ctx, data).Return(5, nil)
+				return uc
+			},
+			want: &dto.ActivityPage{Items: items, Total: 5},
+		},
+		{
+			name: "user is not a project member",
+			uc: func(ctrl *gomock.Controller) *activity.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				deps.projectRepo.EXPECT().IsMember(ctx, 1, 2).Return(repo.ErrNotFound)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.NotFoundErr,
+			wantErrMsg:  "project not found",
+		},
+		{
+			name: "failed to verify membership",
+			uc: func(ctrl *gomock.Controller) *activity.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				deps.projectRepo.EXPECT().IsMember(ctx, 1, 2).Return(repo.ErrInternal)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.InternalErr,
+			wantErrMsg:  "failed to verify user membership",
+		},
+		{
+			name: "failed to get activity",
+			uc: func(ctrl *gomock.Controller) *activity.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				deps.projectRepo.EXPECT().IsMember(ctx, 1, 2).Return(nil)
+				deps.activityRepo.EXPECT().GetList(ctx, data).Return(nil, repo.ErrInternal)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.InternalErr,
+			wantErrMsg:  "failed to get project activity",
+		},
+		{
+			name: "failed to count activity",
+			uc: func(ctrl *gomock.Controller) *activity.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				deps.projectRepo.EXPECT().IsMember(ctx, 1, 2).Return(nil)
+				deps.activityRepo.EXPECT().GetList(ctx, data).Return(items, nil)
+				deps.activityRepo.EXPECT().Count(ctx, data).Return(0, repo.ErrInternal)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.InternalErr,
+			wantErrMsg:  "failed to count project activity",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := tt.uc(ctrl)
+			got, err := u.GetList(ctx, data)
+			if tt.wantErr {
+				var e *customerrors.Err
+				if err == nil {
+					t.Errorf("expected error but got nil")
+					return
+				}
+				if !errors.As(err, &e) {
+					t.Errorf("expected custom error type, got %T", err)
+					return
+				}
+				if e.Type != tt.wantErrType {
+					t.Errorf("unexpected error type: got %d, want %d", e.Type, tt.wantErrType)
+				}
+				if e.Msg != tt.wantErrMsg {
+					t.Errorf("unexpected error msg: got %s, want %s", e.Msg, tt.wantErrMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
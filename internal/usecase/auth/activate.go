@@ -0,0 +1,41 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"task-trail/internal/repo"
+	"task-trail/internal/usecase/dto"
+)
+
+// Activate sets password and username of the auto-registered user, after that user can log in.
+func (u *UseCase) Activate(ctx context.Context, data *dto.Activation) error {
+	f := func(ctx context.Context) error {
+		token, err := u.getEmailTokenWithPurpose(ctx, data.TokenID, dto.PurposeActivation)
+		if err != nil {
+			return err
+		}
+		user, err := u.userRepo.GetByID(ctx, token.UserID)
+		if err != nil {
+			if errors.Is(err, repo.ErrNotFound) {
+				return u.errHandler.BadRequest(err, "user not found", "userID", token.UserID)
+			}
+			return u.errHandler.InternalTrouble(err, "failed to get user", "userID", token.UserID)
+		}
+		if user.IsActivated {
+			return u.errHandler.BadRequest(nil, "user already activated", "userID", user.ID)
+		}
+		if err := u.validatePassword(data.Password, user.Email, "password", "userID", user.ID); err != nil {
+			return err
+		}
+		h, err := u.passwordSvc.HashPassword(data.Password)
+		if err != nil {
+			return u.errHandler.InternalTrouble(err, "failed to hash password", "userID", user.ID)
+		}
+		update := &dto.UserUpdate{ID: user.ID, PasswordHash: h, Username: data.Username, Activated: true}
+		if err := u.updateUser(ctx, update); err != nil {
+			return err
+		}
+		return u.useEmailToken(ctx, data.TokenID)
+	}
+	return u.txManager.DoWithTx(ctx, f)
+}
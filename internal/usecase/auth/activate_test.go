@@ -0,0 +1,146 @@
+package auth_test
+
+import (
+	"context"
+	"task-trail/internal/customerrors"
+	"task-trail/internal/repo"
+	"task-trail/internal/usecase/auth"
+	"task-trail/internal/usecase/dto"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestUseCaseActivate(t *testing.T) {
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type args struct {
+		ctx  context.Context
+		data *dto.Activation
+	}
+
+	ctx := context.Background()
+	a := args{ctx: ctx,
+		data: &dto.Activation{TokenID: "activation", Password: "NewPassword1", Username: "user"},
+	}
+	token := &dto.EmailToken{
+		ID:        "activation",
+		UserID:    1,
+		Purpose:   dto.PurposeActivation,
+		ExpiredAt: time.Now().Add(time.Hour),
+	}
+	invited := &dto.User{ID: 1, Email: testEmail, PasswordHash: " "}
+	tests := []struct {
+		name        string
+		uc          func(ctrl *gomock.Controller) *auth.UseCase
+		args        args
+		wantErr     bool
+		wantErrType customerrors.ErrType
+		wantErrMsg  string
+	}{
+		{
+			name: "success",
+			args: a,
+			uc: func(ctrl *gomock.Controller) *auth.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				mockTx(ctx, deps.txManager)
+				deps.etRepo.EXPECT().GetByID(ctx, "activation").Return(token, nil)
+				deps.userRepo.EXPECT().GetByID(ctx, 1).Return(invited, nil)
+				mockPwdPolicy(deps.passwordPolicy, false)
+				mockHashPwd(deps.passwordSvc, false)
+				deps.userRepo.EXPECT().Update(ctx, &dto.UserUpdate{ID: 1, PasswordHash: "hashedPassword", Username: "user", Activated: true}).Return(nil)
+				deps.etRepo.EXPECT().Use(ctx, "activation").Return(nil)
+				return uc
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid email token purpose",
+			args: a,
+			uc: func(ctrl *gomock.Controller) *auth.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				mockTx(ctx, deps.txManager)
+				deps.etRepo.EXPECT().GetByID(ctx, "activation").Return(&dto.EmailToken{
+					ID:        "activation",
+					UserID:    1,
+					Purpose:   dto.PurposeReset,
+					ExpiredAt: time.Now().Add(time.Hour),
+				}, nil)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.ValidationErr,
+			wantErrMsg:  "invalid email token purpose",
+		},
+		{
+			name: "failed to get user",
+			args: a,
+			uc: func(ctrl *gomock.Controller) *auth.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				mockTx(ctx, deps.txManager)
+				deps.etRepo.EXPECT().GetByID(ctx, "activation").Return(token, nil)
+				deps.userRepo.EXPECT().GetByID(ctx, 1).Return(nil, repo.ErrInternal)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.InternalErr,
+			wantErrMsg:  "failed to get user",
+		},
+		{
+			name: "user already activated",
+			args: a,
+			uc: func(ctrl *gomock.Controller) *auth.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				mockTx(ctx, deps.txManager)
+				deps.etRepo.EXPECT().GetByID(ctx, "activation").Return(token, nil)
+				deps.userRepo.EXPECT().GetByID(ctx, 1).Return(&dto.User{ID: 1, Email: testEmail, IsActivated: true}, nil)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.ValidationErr,
+			wantErrMsg:  "user already activated",
+		},
+		{
+			name: "password does not meet requirements",
+			args: a,
+			uc: func(ctrl *gomock.Controller) *auth.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				mockTx(ctx, deps.txManager)
+				deps.etRepo.EXPECT().GetByID(ctx, "activation").Return(token, nil)
+				deps.userRepo.EXPECT().GetByID(ctx, 1).Return(invited, nil)
+				mockPwdPolicy(deps.passwordPolicy, true)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.ValidationErr,
+			wantErrMsg:  "password does not meet requirements",
+		},
+		{
+			name: "failed to update user",
+			args: a,
+			uc: func(ctrl *gomock.Controller) *auth.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				mockTx(ctx, deps.txManager)
+				deps.etRepo.EXPECT().GetByID(ctx, "activation").Return(token, nil)
+				deps.userRepo.EXPECT().GetByID(ctx, 1).Return(invited, nil)
+				mockPwdPolicy(deps.passwordPolicy, false)
+				mockHashPwd(deps.passwordSvc, false)
+				deps.userRepo.EXPECT().Update(ctx, gomock.Any()).Return(repo.ErrInternal)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.InternalErr,
+			wantErrMsg:  "failed to update user",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := tt.uc(ctrl)
+			err := u.Activate(tt.args.ctx, tt.args.data)
+			checkErr(t, err, tt.wantErr, tt.wantErrType, tt.wantErrMsg)
+		})
+	}
+}
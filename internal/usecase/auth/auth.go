@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	"task-trail/internal/customerrors"
+	"task-trail/internal/pkg/logger"
 	"task-trail/internal/pkg/password"
 	"task-trail/internal/pkg/token"
 	"task-trail/internal/pkg/uuid"
@@ -13,6 +14,9 @@ import (
 	"time"
 )
 
+// invitation may stay unread for a while, so activation token lives longer than others
+const activationTokenLifetime = time.Hour * 24 * 7
+
 type UseCase struct {
 	errHandler       customerrors.ErrorHandler
 	txManager        repo.TxManager
@@ -21,8 +25,10 @@ type UseCase struct {
 	etRepo           repo.EmailTokenRepository
 	notificationRepo repo.NotificationRepository
 	passwordSvc      password.Service
+	passwordPolicy   password.Policy
 	tokenSvc         token.Service
 	uuid             uuid.Generator
+	logger           logger.Logger
 }
 
 func New(
@@ -33,8 +39,10 @@ func New(
 	etRepo repo.EmailTokenRepository,
 	notificationRepo repo.NotificationRepository,
 	passwordSvc password.Service,
+	passwordPolicy password.Policy,
 	tokenSvc token.Service,
 	uuid uuid.Generator,
+	logger logger.Logger,
 ) *UseCase {
 	return &UseCase{
 		errHandler:       errHandler,
@@ -44,8 +52,10 @@ func New(
 		etRepo:           etRepo,
 		notificationRepo: notificationRepo,
 		passwordSvc:      passwordSvc,
+		passwordPolicy:   passwordPolicy,
 		tokenSvc:         tokenSvc,
 		uuid:             uuid,
+		logger:           logger,
 	}
 }
 
@@ -103,11 +113,25 @@ func (u *UseCase) generateAuthTokens(userID int) (*dto.AccessTokenRes, *dto.Refr
 }
 
 func (u *UseCase) createEmailToken(ctx context.Context, userID int, purpose dto.EmailTokenPurpose) (string, error) {
+	return u.createEmailTokenWithPayload(ctx, userID, purpose, "")
+}
+
+func (u *UseCase) createEmailTokenWithPayload(
+	ctx context.Context,
+	userID int,
+	purpose dto.EmailTokenPurpose,
+	payload string,
+) (string, error) {
+	lifetime := time.Minute * 10
+	if purpose == dto.PurposeActivation {
+		lifetime = activationTokenLifetime
+	}
 	et := &dto.EmailTokenCreate{
 		ID:        u.uuid.Generate(),
-		ExpiredAt: time.Now().Add(time.Minute * 10),
+		ExpiredAt: time.Now().Add(lifetime),
 		UserID:    userID,
 		Purpose:   purpose,
+		Payload:   payload,
 	}
 	if err := u.etRepo.Create(ctx, et); err != nil {
 		if errors.Is(err, repo.ErrConflict) {
@@ -149,6 +173,23 @@ func (u *UseCase) getEmailToken(ctx context.Context, tokenID string) (*dto.Email
 
 }
 
+// getEmailTokenWithPurpose returns valid token issued for the given purpose,
+// so token sent for one flow can't be used in another one.
+func (u *UseCase) getEmailTokenWithPurpose(
+	ctx context.Context,
+	tokenID string,
+	purpose dto.EmailTokenPurpose,
+) (*dto.EmailToken, error) {
+	token, err := u.getEmailToken(ctx, tokenID)
+	if err != nil {
+		return nil, err
+	}
+	if token.Purpose != purpose {
+		return nil, u.errHandler.BadRequest(nil, "invalid email token purpose", "tokenID", tokenID, "purpose", token.Purpose)
+	}
+	return token, nil
+}
+
 func (u *UseCase) updateUser(ctx context.Context, dto *dto.UserUpdate) error {
 	if err := u.userRepo.Update(ctx, dto); err != nil {
 		if errors.Is(err, repo.ErrNotFound) {
@@ -159,6 +200,28 @@ func (u *UseCase) updateUser(ctx context.Context, dto *dto.UserUpdate) error {
 	return nil
 }
 
+// rehashPassword replaces user password hash with the one created by the current algorithm.
+func (u *UseCase) rehashPassword(ctx context.Context, userID int, pwd string) error {
+	h, err := u.passwordSvc.HashPassword(pwd)
+	if err != nil {
+		return u.errHandler.InternalTrouble(err, "failed to hash password", "userID", userID)
+	}
+	return u.updateUser(ctx, &dto.UserUpdate{ID: userID, PasswordHash: h})
+}
+
+// validatePassword checks password against the password policy,
+// field is the request field name used in the response metadata with the list of violations.
+func (u *UseCase) validatePassword(pwd string, email string, field string, args ...any) error {
+	if err := u.passwordPolicy.Validate(pwd, email); err != nil {
+		var policyErr *password.PolicyError
+		if !errors.As(err, &policyErr) {
+			return u.errHandler.InternalTrouble(err, "failed to validate password", args...)
+		}
+		return u.errHandler.InvalidFields(err, "password does not meet requirements", map[string]any{field: policyErr.Violations}, args...)
+	}
+	return nil
+}
+
 func (u *UseCase) getUserByEmail(ctx context.Context, email string) (*dto.User, error) {
 	user, err := u.userRepo.GetByEmail(ctx, email)
 	if err != nil {
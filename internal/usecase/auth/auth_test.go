@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"task-trail/internal/customerrors"
+	"task-trail/internal/pkg/password"
 	"task-trail/internal/usecase/auth"
 	"task-trail/test/mocks"
 
@@ -20,9 +21,11 @@ type testDeps struct {
 	txManager        mocks.MockTxManager
 	notificationRepo mocks.MockNotificationRepository
 	passwordSvc      mocks.MockPasswordService
+	passwordPolicy   mocks.MockPasswordPolicy
 	tokenSvc         mocks.MockTokenService
 	errHandler       customerrors.ErrorHandler
 	uuid             mocks.MockGenerator
+	logger           mocks.MockLogger
 }
 
 func MockUseCase(ctrl *gomock.Controller) (*auth.UseCase, *testDeps) {
@@ -33,10 +36,12 @@ func MockUseCase(ctrl *gomock.Controller) (*auth.UseCase, *testDeps) {
 	notificationRepo := mocks.NewMockNotificationRepository(ctrl)
 	tokenSvc := mocks.NewMockTokenService(ctrl)
 	passwordSvc := mocks.NewMockPasswordService(ctrl)
+	passwordPolicy := mocks.NewMockPasswordPolicy(ctrl)
 	errHandler := customerrors.NewErrHander()
 	uuid := mocks.NewMockGenerator(ctrl)
+	logger := mocks.NewMockLogger(ctrl)
 
-	uc := auth.New(errHandler, txManager, userRepo, rtRepo, etRepo, notificationRepo, passwordSvc, tokenSvc, uuid)
+	uc := auth.New(errHandler, txManager, userRepo, rtRepo, etRepo, notificationRepo, passwordSvc, passwordPolicy, tokenSvc, uuid, logger)
 	deps := &testDeps{
 		rtRepo:           *rtRepo,
 		etRepo:           *etRepo,
@@ -45,8 +50,10 @@ func MockUseCase(ctrl *gomock.Controller) (*auth.UseCase, *testDeps) {
 		notificationRepo: *notificationRepo,
 		tokenSvc:         *tokenSvc,
 		passwordSvc:      *passwordSvc,
+		passwordPolicy:   *passwordPolicy,
 		errHandler:       errHandler,
 		uuid:             *uuid,
+		logger:           *logger,
 	}
 	return uc, deps
 }
@@ -68,3 +75,11 @@ func mockHashPwd(s mocks.MockPasswordService, failed bool) {
 	}
 
 }
+
+func mockPwdPolicy(s mocks.MockPasswordPolicy, failed bool) {
+	if failed {
+		s.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(&password.PolicyError{Violations: []string{"must contain a digit"}})
+	} else {
+		s.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(nil)
+	}
+}
@@ -10,21 +10,28 @@ import (
 func (u *UseCase) AutoRegister(ctx context.Context, email string) error {
 
 	f := func(ctx context.Context) error {
-		// create user
-		user := &dto.UserCreate{Email: email, PasswordHash: " ", IsVerified: true}
-		_, err := u.userRepo.Create(ctx, user)
+		// create user, password is set on activation
+		user := &dto.UserCreate{Email: email, PasswordHash: " ", IsVerified: true, IsInvited: true}
+		userID, err := u.userRepo.Create(ctx, user)
 		if err != nil {
 			if errors.Is(err, repo.ErrConflict) {
 				return u.errHandler.Conflict(err, "email already taken", "email", email)
 			}
 			return u.errHandler.InternalTrouble(err, "failed to create new user", "email", email)
 		}
-
-		if err := u.notificationRepo.SendAutoRegisterEmail(ctx, email); err != nil {
-			return u.errHandler.InternalTrouble(err, "failed to send registration email", "email", email)
-		}
-		return nil
+		return u.sendActivationEmail(ctx, userID, email)
 	}
 
 	return u.txManager.DoWithTx(ctx, f)
 }
+
+func (u *UseCase) sendActivationEmail(ctx context.Context, userID int, email string) error {
+	tokenID, err := u.createEmailToken(ctx, userID, dto.PurposeActivation)
+	if err != nil {
+		return err
+	}
+	if err := u.notificationRepo.SendAutoRegisterEmail(ctx, email, tokenID); err != nil {
+		return u.errHandler.InternalTrouble(err, "failed to send registration email", "email", email)
+	}
+	return nil
+}
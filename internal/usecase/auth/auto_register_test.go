@@ -7,6 +7,7 @@ import (
 	"task-trail/internal/customerrors"
 	"task-trail/internal/repo"
 	"task-trail/internal/usecase/auth"
+	"task-trail/internal/usecase/dto"
 	"testing"
 
 	"go.uber.org/mock/gomock"
@@ -43,8 +44,10 @@ func TestUseCaseAutoRegister(t *testing.T) {
 			uc: func(ctrl *gomock.Controller) *auth.UseCase {
 				uc, deps := MockUseCase(ctrl)
 				mockTx(ctx, deps.txManager)
-				deps.userRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(1, nil)
-				deps.notificationRepo.EXPECT().SendAutoRegisterEmail(gomock.Any(), gomock.Any()).Return(nil)
+				deps.userRepo.EXPECT().Create(gomock.Any(), &dto.UserCreate{Email: testEmail, PasswordHash: " ", IsVerified: true, IsInvited: true}).Return(1, nil)
+				deps.uuid.EXPECT().Generate().Return("activation")
+				deps.etRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+				deps.notificationRepo.EXPECT().SendAutoRegisterEmail(gomock.Any(), testEmail, "activation").Return(nil)
 				return uc
 			},
 			wantErr: false,
@@ -82,13 +85,30 @@ func TestUseCaseAutoRegister(t *testing.T) {
 				uc, deps := MockUseCase(ctrl)
 				mockTx(ctx, deps.txManager)
 				deps.userRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(1, nil)
-				deps.notificationRepo.EXPECT().SendAutoRegisterEmail(gomock.Any(), testEmail).Return(fmt.Errorf("failed send notification"))
+				deps.uuid.EXPECT().Generate().Return("activation")
+				deps.etRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+				deps.notificationRepo.EXPECT().SendAutoRegisterEmail(gomock.Any(), testEmail, "activation").Return(fmt.Errorf("failed send notification"))
 				return uc
 			},
 			wantErr:     true,
 			wantErrType: customerrors.InternalErr,
 			wantErrMsg:  "failed to send registration email",
 		},
+		{
+			name: "failed to create email token",
+			args: a,
+			uc: func(ctrl *gomock.Controller) *auth.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				mockTx(ctx, deps.txManager)
+				deps.userRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(1, nil)
+				deps.uuid.EXPECT().Generate().Return("activation")
+				deps.etRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(repo.ErrInternal)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.InternalErr,
+			wantErrMsg:  "failed to create email token",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,114 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"task-trail/internal/repo"
+	"task-trail/internal/usecase/dto"
+)
+
+// RequestEmailChange sends confirmation token to the new email address
+// and notice with cancel link to the current one. Email is not changed until confirmation.
+func (u *UseCase) RequestEmailChange(ctx context.Context, data *dto.EmailChange) error {
+	f := func(ctx context.Context) error {
+		user, err := u.userRepo.GetByID(ctx, data.UserID)
+		if err != nil {
+			if errors.Is(err, repo.ErrNotFound) {
+				return u.errHandler.BadRequest(err, "user not found", "userID", data.UserID)
+			}
+			return u.errHandler.InternalTrouble(err, "failed to get user", "userID", data.UserID)
+		}
+		if err := u.passwordSvc.ComparePassword(data.Password, user.PasswordHash); err != nil {
+			return u.errHandler.BadRequest(err, "incorrect password", "userID", data.UserID)
+		}
+		if user.Email == data.NewEmail {
+			return u.errHandler.BadRequest(nil, "new email is equal to current", "userID", data.UserID)
+		}
+		if err := u.checkEmailIsFree(ctx, data.NewEmail); err != nil {
+			return err
+		}
+
+		confirmID, err := u.createEmailTokenWithPayload(ctx, user.ID, dto.PurposeEmailChange, data.NewEmail)
+		if err != nil {
+			return err
+		}
+		cancelID, err := u.createEmailTokenWithPayload(ctx, user.ID, dto.PurposeEmailChangeCancel, confirmID)
+		if err != nil {
+			return err
+		}
+
+		if err := u.notificationRepo.SendEmailChangeEmail(ctx, data.NewEmail, confirmID); err != nil {
+			return u.errHandler.InternalTrouble(err, "failed to send email change confirmation", "userID", user.ID)
+		}
+		notice := &dto.NotificationEmailChange{Recipient: user.Email, NewEmail: data.NewEmail, TokenID: cancelID}
+		if err := u.notificationRepo.SendEmailChangeNotice(ctx, notice); err != nil {
+			return u.errHandler.InternalTrouble(err, "failed to send email change notice", "userID", user.ID)
+		}
+		return nil
+	}
+	return u.txManager.DoWithTx(ctx, f)
+}
+
+// ConfirmEmailChange applies the new email stored in the confirmation token
+// and revokes all user refresh tokens.
+func (u *UseCase) ConfirmEmailChange(ctx context.Context, tokenID string) error {
+	f := func(ctx context.Context) error {
+		token, err := u.getEmailTokenWithPurpose(ctx, tokenID, dto.PurposeEmailChange)
+		if err != nil {
+			return err
+		}
+		if token.Payload == nil {
+			return u.errHandler.InternalTrouble(nil, "email change token without new email", "tokenID", tokenID)
+		}
+		newEmail := *token.Payload
+		if err := u.checkEmailIsFree(ctx, newEmail); err != nil {
+			return err
+		}
+		if err := u.userRepo.Update(ctx, &dto.UserUpdate{ID: token.UserID, Email: newEmail}); err != nil {
+			if errors.Is(err, repo.ErrConflict) {
+				return u.errHandler.Conflict(err, "email already taken", "email", newEmail)
+			}
+			if errors.Is(err, repo.ErrNotFound) {
+				return u.errHandler.BadRequest(err, "user not found", "userID", token.UserID)
+			}
+			return u.errHandler.InternalTrouble(err, "failed to update user", "userID", token.UserID)
+		}
+		if err := u.useEmailToken(ctx, tokenID); err != nil {
+			return err
+		}
+		if _, err := u.rtRepo.RevokeAllUsersTokens(ctx, token.UserID); err != nil {
+			return u.errHandler.InternalTrouble(err, "failed to revoke all users refresh tokens", "userID", token.UserID)
+		}
+		return nil
+	}
+	return u.txManager.DoWithTx(ctx, f)
+}
+
+// CancelEmailChange invalidates pending email change confirmation token.
+func (u *UseCase) CancelEmailChange(ctx context.Context, tokenID string) error {
+	f := func(ctx context.Context) error {
+		token, err := u.getEmailTokenWithPurpose(ctx, tokenID, dto.PurposeEmailChangeCancel)
+		if err != nil {
+			return err
+		}
+		if token.Payload == nil {
+			return u.errHandler.InternalTrouble(nil, "email change cancel token without confirmation token", "tokenID", tokenID)
+		}
+		if err := u.useEmailToken(ctx, *token.Payload); err != nil {
+			return err
+		}
+		return u.useEmailToken(ctx, tokenID)
+	}
+	return u.txManager.DoWithTx(ctx, f)
+}
+
+func (u *UseCase) checkEmailIsFree(ctx context.Context, email string) error {
+	isTaken, err := u.userRepo.EmailIsTaken(ctx, email)
+	if err != nil {
+		return u.errHandler.InternalTrouble(err, "failed to check email", "email", email)
+	}
+	if isTaken {
+		return u.errHandler.Conflict(nil, "email already taken", "email", email)
+	}
+	return nil
+}
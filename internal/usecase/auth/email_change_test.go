@@ -0,0 +1,391 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"task-trail/internal/customerrors"
+	"task-trail/internal/repo"
+	"task-trail/internal/usecase/auth"
+	"task-trail/internal/usecase/dto"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+const testNewEmail = "new@test.test"
+
+func checkErr(t *testing.T, err error, wantErr bool, wantErrType customerrors.ErrType, wantErrMsg string) {
+	t.Helper()
+	if !wantErr {
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		return
+	}
+	var e *customerrors.Err
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+	if !errors.As(err, &e) {
+		t.Errorf("expected custom error type, got %T", err)
+		return
+	}
+	if e.Type != wantErrType {
+		t.Errorf("unexpected error type: got %d, want %d", e.Type, wantErrType)
+	}
+	if e.Msg != wantErrMsg {
+		t.Errorf("unexpected error msg: got %s, want %s", e.Msg, wantErrMsg)
+	}
+}
+
+func TestUseCaseRequestEmailChange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	data := &dto.EmailChange{UserID: 1, NewEmail: testNewEmail, Password: testPwd}
+	user := &dto.User{ID: 1, Email: testEmail, PasswordHash: testPwd}
+
+	tests := []struct {
+		name        string
+		uc          func(ctrl *gomock.Controller) *auth.UseCase
+		data        *dto.EmailChange
+		wantErr     bool
+		wantErrType customerrors.ErrType
+		wantErrMsg  string
+	}{
+		{
+			name: "success",
+			data: data,
+			uc: func(ctrl *gomock.Controller) *auth.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				mockTx(ctx, deps.txManager)
+				deps.userRepo.EXPECT().GetByID(ctx, 1).Return(user, nil)
+				deps.passwordSvc.EXPECT().ComparePassword(testPwd, testPwd).Return(nil)
+				deps.userRepo.EXPECT().EmailIsTaken(ctx, testNewEmail).Return(false, nil)
+				deps.uuid.EXPECT().Generate().Return("confirm")
+				deps.uuid.EXPECT().Generate().Return("cancel")
+				deps.etRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+					func(_ context.Context, et *dto.EmailTokenCreate) error {
+						if et.Purpose != dto.PurposeEmailChange || et.Payload != testNewEmail {
+							t.Errorf("unexpected confirmation token: %+v", et)
+						}
+						return nil
+					},
+				)
+				deps.etRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+					func(_ context.Context, et *dto.EmailTokenCreate) error {
+						if et.Purpose != dto.PurposeEmailChangeCancel || et.Payload != "confirm" {
+							t.Errorf("unexpected cancel token: %+v", et)
+						}
+						return nil
+					},
+				)
+				deps.notificationRepo.EXPECT().SendEmailChangeEmail(ctx, testNewEmail, "confirm").Return(nil)
+				deps.notificationRepo.EXPECT().SendEmailChangeNotice(
+					ctx,
+					&dto.NotificationEmailChange{Recipient: testEmail, NewEmail: testNewEmail, TokenID: "cancel"},
+				).Return(nil)
+				return uc
+			},
+		},
+		{
+			name: "user not found",
+			data: data,
+			uc: func(ctrl *gomock.Controller) *auth.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				mockTx(ctx, deps.txManager)
+				deps.userRepo.EXPECT().GetByID(ctx, 1).Return(nil, repo.ErrNotFound)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.ValidationErr,
+			wantErrMsg:  "user not found",
+		},
+		{
+			name: "failed to get user",
+			data: data,
+			uc: func(ctrl *gomock.Controller) *auth.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				mockTx(ctx, deps.txManager)
+				deps.userRepo.EXPECT().GetByID(ctx, 1).Return(nil, repo.ErrInternal)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.InternalErr,
+			wantErrMsg:  "failed to get user",
+		},
+		{
+			name: "incorrect password",
+			data: data,
+			uc: func(ctrl *gomock.Controller) *auth.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				mockTx(ctx, deps.txManager)
+				deps.userRepo.EXPECT().GetByID(ctx, 1).Return(user, nil)
+				deps.passwordSvc.EXPECT().ComparePassword(testPwd, testPwd).Return(fmt.Errorf("invalid pwd"))
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.ValidationErr,
+			wantErrMsg:  "incorrect password",
+		},
+		{
+			name: "new email is equal to current",
+			data: &dto.EmailChange{UserID: 1, NewEmail: testEmail, Password: testPwd},
+			uc: func(ctrl *gomock.Controller) *auth.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				mockTx(ctx, deps.txManager)
+				deps.userRepo.EXPECT().GetByID(ctx, 1).Return(user, nil)
+				deps.passwordSvc.EXPECT().ComparePassword(testPwd, testPwd).Return(nil)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.ValidationErr,
+			wantErrMsg:  "new email is equal to current",
+		},
+		{
+			name: "email already taken",
+			data: data,
+			uc: func(ctrl *gomock.Controller) *auth.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				mockTx(ctx, deps.txManager)
+				deps.userRepo.EXPECT().GetByID(ctx, 1).Return(user, nil)
+				deps.passwordSvc.EXPECT().ComparePassword(testPwd, testPwd).Return(nil)
+				deps.userRepo.EXPECT().EmailIsTaken(ctx, testNewEmail).Return(true, nil)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.ConflictErr,
+			wantErrMsg:  "email already taken",
+		},
+		{
+			name: "failed to send email change confirmation",
+			data: data,
+			uc: func(ctrl *gomock.Controller) *auth.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				mockTx(ctx, deps.txManager)
+				deps.userRepo.EXPECT().GetByID(ctx, 1).Return(user, nil)
+				deps.passwordSvc.EXPECT().ComparePassword(testPwd, testPwd).Return(nil)
+				deps.userRepo.EXPECT().EmailIsTaken(ctx, testNewEmail).Return(false, nil)
+				deps.uuid.EXPECT().Generate().Return("confirm").Times(2)
+				deps.etRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil).Times(2)
+				deps.notificationRepo.EXPECT().SendEmailChangeEmail(ctx, testNewEmail, "confirm").Return(fmt.Errorf("smtp error"))
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.InternalErr,
+			wantErrMsg:  "failed to send email change confirmation",
+		},
+		{
+			name: "failed to send email change notice",
+			data: data,
+			uc: func(ctrl *gomock.Controller) *auth.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				mockTx(ctx, deps.txManager)
+				deps.userRepo.EXPECT().GetByID(ctx, 1).Return(user, nil)
+				deps.passwordSvc.EXPECT().ComparePassword(testPwd, testPwd).Return(nil)
+				deps.userRepo.EXPECT().EmailIsTaken(ctx, testNewEmail).Return(false, nil)
+				deps.uuid.EXPECT().Generate().Return("confirm").Times(2)
+				deps.etRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil).Times(2)
+				deps.notificationRepo.EXPECT().SendEmailChangeEmail(ctx, testNewEmail, "confirm").Return(nil)
+				deps.notificationRepo.EXPECT().SendEmailChangeNotice(ctx, gomock.Any()).Return(fmt.Errorf("smtp error"))
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.InternalErr,
+			wantErrMsg:  "failed to send email change notice",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := tt.uc(ctrl)
+			err := u.RequestEmailChange(ctx, tt.data)
+			checkErr(t, err, tt.wantErr, tt.wantErrType, tt.wantErrMsg)
+		})
+	}
+}
+
+func TestUseCaseConfirmEmailChange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	newEmail := testNewEmail
+	validToken := dto.EmailToken{
+		ID:        "123",
+		ExpiredAt: time.Now().Add(time.Minute * 10),
+		UserID:    1,
+		Purpose:   dto.PurposeEmailChange,
+		Payload:   &newEmail,
+	}
+
+	tests := []struct {
+		name        string
+		uc          func(ctrl *gomock.Controller) *auth.UseCase
+		wantErr     bool
+		wantErrType customerrors.ErrType
+		wantErrMsg  string
+	}{
+		{
+			name: "success",
+			uc: func(ctrl *gomock.Controller) *auth.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				mockTx(ctx, deps.txManager)
+				deps.etRepo.EXPECT().GetByID(ctx, "123").Return(&validToken, nil)
+				deps.userRepo.EXPECT().EmailIsTaken(ctx, testNewEmail).Return(false, nil)
+				deps.userRepo.EXPECT().Update(ctx, &dto.UserUpdate{ID: 1, Email: testNewEmail}).Return(nil)
+				deps.etRepo.EXPECT().Use(ctx, "123").Return(nil)
+				deps.rtRepo.EXPECT().RevokeAllUsersTokens(ctx, 1).Return(2, nil)
+				return uc
+			},
+		},
+		{
+			name: "invalid email token purpose",
+			uc: func(ctrl *gomock.Controller) *auth.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				mockTx(ctx, deps.txManager)
+				token := validToken
+				token.Purpose = dto.PurposeReset
+				deps.etRepo.EXPECT().GetByID(ctx, "123").Return(&token, nil)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.ValidationErr,
+			wantErrMsg:  "invalid email token purpose",
+		},
+		{
+			name: "email token is expired",
+			uc: func(ctrl *gomock.Controller) *auth.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				mockTx(ctx, deps.txManager)
+				token := validToken
+				token.ExpiredAt = time.Now().Add(time.Second * -1)
+				deps.etRepo.EXPECT().GetByID(ctx, "123").Return(&token, nil)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.ValidationErr,
+			wantErrMsg:  "email token is expired",
+		},
+		{
+			name: "email already taken",
+			uc: func(ctrl *gomock.Controller) *auth.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				mockTx(ctx, deps.txManager)
+				deps.etRepo.EXPECT().GetByID(ctx, "123").Return(&validToken, nil)
+				deps.userRepo.EXPECT().EmailIsTaken(ctx, testNewEmail).Return(true, nil)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.ConflictErr,
+			wantErrMsg:  "email already taken",
+		},
+		{
+			name: "failed to update user",
+			uc: func(ctrl *gomock.Controller) *auth.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				mockTx(ctx, deps.txManager)
+				deps.etRepo.EXPECT().GetByID(ctx, "123").Return(&validToken, nil)
+				deps.userRepo.EXPECT().EmailIsTaken(ctx, testNewEmail).Return(false, nil)
+				deps.userRepo.EXPECT().Update(ctx, gomock.Any()).Return(repo.ErrInternal)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.InternalErr,
+			wantErrMsg:  "failed to update user",
+		},
+		{
+			name: "failed to revoke all users refresh tokens",
+			uc: func(ctrl *gomock.Controller) *auth.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				mockTx(ctx, deps.txManager)
+				deps.etRepo.EXPECT().GetByID(ctx, "123").Return(&validToken, nil)
+				deps.userRepo.EXPECT().EmailIsTaken(ctx, testNewEmail).Return(false, nil)
+				deps.userRepo.EXPECT().Update(ctx, gomock.Any()).Return(nil)
+				deps.etRepo.EXPECT().Use(ctx, "123").Return(nil)
+				deps.rtRepo.EXPECT().RevokeAllUsersTokens(ctx, 1).Return(0, repo.ErrInternal)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.InternalErr,
+			wantErrMsg:  "failed to revoke all users refresh tokens",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := tt.uc(ctrl)
+			err := u.ConfirmEmailChange(ctx, "123")
+			checkErr(t, err, tt.wantErr, tt.wantErrType, tt.wantErrMsg)
+		})
+	}
+}
+
+func TestUseCaseCancelEmailChange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	confirmID := "confirm"
+	validToken := dto.EmailToken{
+		ID:        "123",
+		ExpiredAt: time.Now().Add(time.Minute * 10),
+		UserID:    1,
+		Purpose:   dto.PurposeEmailChangeCancel,
+		Payload:   &confirmID,
+	}
+
+	tests := []struct {
+		name        string
+		uc          func(ctrl *gomock.Controller) *auth.UseCase
+		wantErr     bool
+		wantErrType customerrors.ErrType
+		wantErrMsg  string
+	}{
+		{
+			name: "success",
+			uc: func(ctrl *gomock.Controller) *auth.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				mockTx(ctx, deps.txManager)
+				deps.etRepo.EXPECT().GetByID(ctx, "123").Return(&validToken, nil)
+				deps.etRepo.EXPECT().Use(ctx, confirmID).Return(nil)
+				deps.etRepo.EXPECT().Use(ctx, "123").Return(nil)
+				return uc
+			},
+		},
+		{
+			name: "email token not found",
+			uc: func(ctrl *gomock.Controller) *auth.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				mockTx(ctx, deps.txManager)
+				deps.etRepo.EXPECT().GetByID(ctx, "123").Return(nil, repo.ErrNotFound)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.ValidationErr,
+			wantErrMsg:  "email token not found",
+		},
+		{
+			name: "email change already confirmed",
+			uc: func(ctrl *gomock.Controller) *auth.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				mockTx(ctx, deps.txManager)
+				deps.etRepo.EXPECT().GetByID(ctx, "123").Return(&validToken, nil)
+				deps.etRepo.EXPECT().Use(ctx, confirmID).Return(repo.ErrNotFound)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.ValidationErr,
+			wantErrMsg:  "email token not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := tt.uc(ctrl)
+			err := u.CancelEmailChange(ctx, "123")
+			checkErr(t, err, tt.wantErr, tt.wantErrType, tt.wantErrMsg)
+		})
+	}
+}
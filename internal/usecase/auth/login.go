@@ -19,9 +19,19 @@ func (u *UseCase) Login(ctx context.Context, data *dto.Credentials) (*dto.LoginR
 	if user.VerifiedAt == nil {
 		return nil, u.errHandler.InvalidCredentials(nil, "user is unverified", "email", data.Email)
 	}
+	if !user.IsActivated {
+		return nil, u.errHandler.InvalidCredentials(nil, "user is not activated", "email", data.Email)
+	}
 	if err := u.passwordSvc.ComparePassword(data.Password, user.PasswordHash); err != nil {
 		return nil, u.errHandler.InvalidCredentials(err, "user password is invalid", "email", data.Email)
 	}
+	if u.passwordSvc.NeedsRehash(user.PasswordHash) {
+		// password hash was created by the legacy algorithm or with outdated cost,
+		// failed upgrade must not prevent login, it will be retried on the next one
+		if err := u.rehashPassword(ctx, user.ID, data.Password); err != nil {
+			u.logger.WarnContext(ctx, "failed to upgrade password hash", "userID", user.ID, "error", err.Error())
+		}
+	}
 	retVal := &dto.LoginRes{
 		UserID: user.ID,
 	}
@@ -34,7 +34,7 @@ func TestUseCaseLogin(t *testing.T) {
 	}
 	getTestUser := func(verified bool) *dto.User {
 
-		user := &dto.User{ID: 1, Email: testEmail, PasswordHash: testPwd}
+		user := &dto.User{ID: 1, Email: testEmail, PasswordHash: testPwd, IsActivated: true}
 		if verified {
 			t := time.Now()
 			user.VerifiedAt = &t
@@ -73,6 +73,7 @@ func TestUseCaseLogin(t *testing.T) {
 				uc, deps := MockUseCase(ctrl)
 				deps.userRepo.EXPECT().GetByEmail(ctx, gomock.Any()).Return(getTestUser(true), nil)
 				deps.passwordSvc.EXPECT().ComparePassword(gomock.Any(), gomock.Any()).Return(nil)
+				deps.passwordSvc.EXPECT().NeedsRehash(gomock.Any()).Return(false)
 				deps.tokenSvc.EXPECT().GenAccessToken(gomock.Any()).Return(at, nil)
 				deps.tokenSvc.EXPECT().GenRefreshToken(gomock.Any()).Return(rt, nil)
 				deps.rtRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
@@ -117,6 +118,20 @@ func TestUseCaseLogin(t *testing.T) {
 			wantErrType: customerrors.InvalidCredentialsErr,
 			wantErrMsg:  "user is unverified",
 		},
+		{
+			name: "user is not activated",
+			args: a,
+			uc: func(ctrl *gomock.Controller) *auth.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				user := getTestUser(true)
+				user.IsActivated = false
+				deps.userRepo.EXPECT().GetByEmail(ctx, gomock.Any()).Return(user, nil)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.InvalidCredentialsErr,
+			wantErrMsg:  "user is not activated",
+		},
 		{
 			name: "user password is invalid",
 			args: a,
@@ -137,6 +152,7 @@ func TestUseCaseLogin(t *testing.T) {
 				uc, deps := MockUseCase(ctrl)
 				deps.userRepo.EXPECT().GetByEmail(ctx, gomock.Any()).Return(getTestUser(true), nil)
 				deps.passwordSvc.EXPECT().ComparePassword(gomock.Any(), gomock.Any()).Return(nil)
+				deps.passwordSvc.EXPECT().NeedsRehash(gomock.Any()).Return(false)
 				deps.tokenSvc.EXPECT().GenAccessToken(gomock.Any()).Return(nil, fmt.Errorf("Token generation failed"))
 
 				return uc
@@ -152,6 +168,7 @@ func TestUseCaseLogin(t *testing.T) {
 				uc, deps := MockUseCase(ctrl)
 				deps.userRepo.EXPECT().GetByEmail(ctx, gomock.Any()).Return(getTestUser(true), nil)
 				deps.passwordSvc.EXPECT().ComparePassword(gomock.Any(), gomock.Any()).Return(nil)
+				deps.passwordSvc.EXPECT().NeedsRehash(gomock.Any()).Return(false)
 				deps.tokenSvc.EXPECT().GenAccessToken(gomock.Any()).Return(at, nil)
 				deps.tokenSvc.EXPECT().GenRefreshToken(gomock.Any()).Return(nil, fmt.Errorf("failed to generate token"))
 				return uc
@@ -167,6 +184,7 @@ func TestUseCaseLogin(t *testing.T) {
 				uc, deps := MockUseCase(ctrl)
 				deps.userRepo.EXPECT().GetByEmail(ctx, gomock.Any()).Return(getTestUser(true), nil)
 				deps.passwordSvc.EXPECT().ComparePassword(gomock.Any(), gomock.Any()).Return(nil)
+				deps.passwordSvc.EXPECT().NeedsRehash(gomock.Any()).Return(false)
 				deps.tokenSvc.EXPECT().GenAccessToken(gomock.Any()).Return(at, nil)
 				deps.tokenSvc.EXPECT().GenRefreshToken(gomock.Any()).Return(rt, nil)
 				deps.rtRepo.EXPECT().Create(ctx, gomock.Any()).Return(repo.ErrConflict)
@@ -183,6 +201,7 @@ func TestUseCaseLogin(t *testing.T) {
 				uc, deps := MockUseCase(ctrl)
 				deps.userRepo.EXPECT().GetByEmail(ctx, gomock.Any()).Return(getTestUser(true), nil)
 				deps.passwordSvc.EXPECT().ComparePassword(gomock.Any(), gomock.Any()).Return(nil)
+				deps.passwordSvc.EXPECT().NeedsRehash(gomock.Any()).Return(false)
 				deps.tokenSvc.EXPECT().GenAccessToken(gomock.Any()).Return(at, nil)
 				deps.tokenSvc.EXPECT().GenRefreshToken(gomock.Any()).Return(rt, nil)
 				deps.rtRepo.EXPECT().Create(ctx, gomock.Any()).Return(repo.ErrNotFound)
@@ -199,6 +218,7 @@ func TestUseCaseLogin(t *testing.T) {
 				uc, deps := MockUseCase(ctrl)
 				deps.userRepo.EXPECT().GetByEmail(ctx, gomock.Any()).Return(getTestUser(true), nil)
 				deps.passwordSvc.EXPECT().ComparePassword(gomock.Any(), gomock.Any()).Return(nil)
+				deps.passwordSvc.EXPECT().NeedsRehash(gomock.Any()).Return(false)
 				deps.tokenSvc.EXPECT().GenAccessToken(gomock.Any()).Return(at, nil)
 				deps.tokenSvc.EXPECT().GenRefreshToken(gomock.Any()).Return(rt, nil)
 				deps.rtRepo.EXPECT().Create(ctx, gomock.Any()).Return(repo.ErrInternal)
@@ -208,6 +228,43 @@ func TestUseCaseLogin(t *testing.T) {
 			wantErrType: customerrors.InternalErr,
 			wantErrMsg:  "failed to create new refresh token",
 		},
+		{
+			name: "success with password rehash",
+			args: a,
+			uc: func(ctrl *gomock.Controller) *auth.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				deps.userRepo.EXPECT().GetByEmail(ctx, gomock.Any()).Return(getTestUser(true), nil)
+				deps.passwordSvc.EXPECT().ComparePassword(gomock.Any(), gomock.Any()).Return(nil)
+				deps.passwordSvc.EXPECT().NeedsRehash(gomock.Any()).Return(true)
+				mockHashPwd(deps.passwordSvc, false)
+				deps.userRepo.EXPECT().Update(ctx, &dto.UserUpdate{ID: 1, PasswordHash: "hashedPassword"}).Return(nil)
+				deps.tokenSvc.EXPECT().GenAccessToken(gomock.Any()).Return(at, nil)
+				deps.tokenSvc.EXPECT().GenRefreshToken(gomock.Any()).Return(rt, nil)
+				deps.rtRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+				return uc
+			},
+			wantErr: false,
+			want:    w,
+		},
+		{
+			name: "success with failed password rehash",
+			args: a,
+			uc: func(ctrl *gomock.Controller) *auth.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				deps.userRepo.EXPECT().GetByEmail(ctx, gomock.Any()).Return(getTestUser(true), nil)
+				deps.passwordSvc.EXPECT().ComparePassword(gomock.Any(), gomock.Any()).Return(nil)
+				deps.passwordSvc.EXPECT().NeedsRehash(gomock.Any()).Return(true)
+				mockHashPwd(deps.passwordSvc, false)
+				deps.userRepo.EXPECT().Update(ctx, gomock.Any()).Return(repo.ErrInternal)
+				deps.logger.EXPECT().WarnContext(ctx, "failed to upgrade password hash", gomock.Any()).Times(1)
+				deps.tokenSvc.EXPECT().GenAccessToken(gomock.Any()).Return(at, nil)
+				deps.tokenSvc.EXPECT().GenRefreshToken(gomock.Any()).Return(rt, nil)
+				deps.rtRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+				return uc
+			},
+			wantErr: false,
+			want:    w,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -21,6 +21,9 @@ func (u *UseCase) ChangePassword(ctx context.Context, data *dto.PasswordChange)
 	if err := u.passwordSvc.ComparePassword(data.OldPassword, user.PasswordHash); err != nil {
 		return u.errHandler.BadRequest(err, "incorrect old password", "userID", data.UserID)
 	}
+	if err := u.validatePassword(data.NewPassword, user.Email, "newpassword", "userID", data.UserID); err != nil {
+		return err
+	}
 	h, err := u.passwordSvc.HashPassword(data.NewPassword)
 	if err != nil {
 		return u.errHandler.InternalTrouble(err, "failed to hash password", "userID", data.UserID)
@@ -0,0 +1,125 @@
+package auth_test
+
+import (
+	"context"
+	"fmt"
+	"task-trail/internal/customerrors"
+	"task-trail/internal/repo"
+	"task-trail/internal/usecase/auth"
+	"task-trail/internal/usecase/dto"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestUseCaseChangePassword(t *testing.T) {
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type args struct {
+		ctx  context.Context
+		data *dto.PasswordChange
+	}
+
+	ctx := context.Background()
+	a := args{ctx: ctx,
+		data: &dto.PasswordChange{UserID: 1, OldPassword: testPwd, NewPassword: "NewPassword1"},
+	}
+	user := &dto.User{ID: 1, Email: testEmail, PasswordHash: "hashedPassword"}
+	tests := []struct {
+		name        string
+		uc          func(ctrl *gomock.Controller) *auth.UseCase
+		args        args
+		wantErr     bool
+		wantErrType customerrors.ErrType
+		wantErrMsg  string
+	}{
+		{
+			name: "success",
+			args: a,
+			uc: func(ctrl *gomock.Controller) *auth.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				deps.userRepo.EXPECT().GetByID(ctx, 1).Return(user, nil)
+				deps.passwordSvc.EXPECT().ComparePassword(testPwd, user.PasswordHash).Return(nil)
+				deps.passwordPolicy.EXPECT().Validate(a.data.NewPassword, testEmail).Return(nil)
+				mockHashPwd(deps.passwordSvc, false)
+				deps.userRepo.EXPECT().Update(ctx, gomock.Any()).Return(nil)
+				return uc
+			},
+			wantErr: false,
+		},
+		{
+			name: "passwords are equal",
+			args: args{ctx: ctx, data: &dto.PasswordChange{UserID: 1, OldPassword: testPwd, NewPassword: testPwd}},
+			uc: func(ctrl *gomock.Controller) *auth.UseCase {
+				uc, _ := MockUseCase(ctrl)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.ValidationErr,
+			wantErrMsg:  "passwords are equal",
+		},
+		{
+			name: "user not found",
+			args: a,
+			uc: func(ctrl *gomock.Controller) *auth.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				deps.userRepo.EXPECT().GetByID(ctx, 1).Return(nil, repo.ErrNotFound)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.ValidationErr,
+			wantErrMsg:  "user not found",
+		},
+		{
+			name: "incorrect old password",
+			args: a,
+			uc: func(ctrl *gomock.Controller) *auth.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				deps.userRepo.EXPECT().GetByID(ctx, 1).Return(user, nil)
+				deps.passwordSvc.EXPECT().ComparePassword(testPwd, user.PasswordHash).Return(fmt.Errorf("mismatch"))
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.ValidationErr,
+			wantErrMsg:  "incorrect old password",
+		},
+		{
+			name: "password does not meet requirements",
+			args: a,
+			uc: func(ctrl *gomock.Controller) *auth.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				deps.userRepo.EXPECT().GetByID(ctx, 1).Return(user, nil)
+				deps.passwordSvc.EXPECT().ComparePassword(testPwd, user.PasswordHash).Return(nil)
+				mockPwdPolicy(deps.passwordPolicy, true)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.ValidationErr,
+			wantErrMsg:  "password does not meet requirements",
+		},
+		{
+			name: "failed to hash password",
+			args: a,
+			uc: func(ctrl *gomock.Controller) *auth.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				deps.userRepo.EXPECT().GetByID(ctx, 1).Return(user, nil)
+				deps.passwordSvc.EXPECT().ComparePassword(testPwd, user.PasswordHash).Return(nil)
+				mockPwdPolicy(deps.passwordPolicy, false)
+				mockHashPwd(deps.passwordSvc, true)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.InternalErr,
+			wantErrMsg:  "failed to hash password",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := tt.uc(ctrl)
+			err := u.ChangePassword(tt.args.ctx, tt.args.data)
+			checkErr(t, err, tt.wantErr, tt.wantErrType, tt.wantErrMsg)
+		})
+	}
+}
@@ -2,15 +2,27 @@ package auth
 
 import (
 	"context"
+	"errors"
+	"task-trail/internal/repo"
 	"task-trail/internal/usecase/dto"
 )
 
 func (u *UseCase) ResetPassword(ctx context.Context, data *dto.PasswordReset) error {
 	f := func(ctx context.Context) error {
-		token, err := u.getEmailToken(ctx, data.TokenID)
+		token, err := u.getEmailTokenWithPurpose(ctx, data.TokenID, dto.PurposeReset)
 		if err != nil {
 			return err
 		}
+		user, err := u.userRepo.GetByID(ctx, token.UserID)
+		if err != nil {
+			if errors.Is(err, repo.ErrNotFound) {
+				return u.errHandler.BadRequest(err, "user not found", "userID", token.UserID)
+			}
+			return u.errHandler.InternalTrouble(err, "failed to get user", "userID", token.UserID)
+		}
+		if err := u.validatePassword(data.NewPassword, user.Email, "password", "userID", user.ID); err != nil {
+			return err
+		}
 
 		h, err := u.passwordSvc.HashPassword(data.NewPassword)
 		if err != nil {
@@ -14,6 +14,10 @@ func (u *UseCase) SendPasswordResetEmail(ctx context.Context, email string) erro
 		if user.VerifiedAt == nil {
 			return u.errHandler.BadRequest(nil, "user is not verified", "userID", user.ID)
 		}
+		// never activated user has no password to reset, send a new invitation instead
+		if !user.IsActivated {
+			return u.sendActivationEmail(ctx, user.ID, email)
+		}
 		// create email token
 		tokenID, err := u.createEmailToken(ctx, user.ID, dto.PurposeVerification)
 		if err != nil {
@@ -43,7 +43,7 @@ func TestUseCaseSendPasswordResetEmail(t *testing.T) {
 				uc, deps := MockUseCase(ctrl)
 				mockTx(ctx, deps.txManager)
 				deps.uuid.EXPECT().Generate().Return(gomock.Any().String())
-				deps.userRepo.EXPECT().GetByEmail(gomock.Any(), gomock.Any()).Return(&dto.User{ID: 1, Email: testEmail, VerifiedAt: &now}, nil)
+				deps.userRepo.EXPECT().GetByEmail(gomock.Any(), gomock.Any()).Return(&dto.User{ID: 1, Email: testEmail, VerifiedAt: &now, IsActivated: true}, nil)
 				deps.etRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
 				deps.notificationRepo.EXPECT().SendResetPasswordEmail(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 				return uc
@@ -109,7 +109,7 @@ func TestUseCaseSendPasswordResetEmail(t *testing.T) {
 				uc, deps := MockUseCase(ctrl)
 				mockTx(ctx, deps.txManager)
 				deps.uuid.EXPECT().Generate().Return(gomock.Any().String())
-				deps.userRepo.EXPECT().GetByEmail(gomock.Any(), gomock.Any()).Return(&dto.User{ID: 1, Email: testEmail, VerifiedAt: &now}, nil)
+				deps.userRepo.EXPECT().GetByEmail(gomock.Any(), gomock.Any()).Return(&dto.User{ID: 1, Email: testEmail, VerifiedAt: &now, IsActivated: true}, nil)
 				deps.etRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(repo.ErrNotFound)
 				return uc
 			},
@@ -124,7 +124,7 @@ func TestUseCaseSendPasswordResetEmail(t *testing.T) {
 				uc, deps := MockUseCase(ctrl)
 				mockTx(ctx, deps.txManager)
 				deps.uuid.EXPECT().Generate().Return(gomock.Any().String())
-				deps.userRepo.EXPECT().GetByEmail(gomock.Any(), gomock.Any()).Return(&dto.User{ID: 1, Email: testEmail, VerifiedAt: &now}, nil)
+				deps.userRepo.EXPECT().GetByEmail(gomock.Any(), gomock.Any()).Return(&dto.User{ID: 1, Email: testEmail, VerifiedAt: &now, IsActivated: true}, nil)
 				deps.etRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(repo.ErrInternal)
 				return uc
 			},
@@ -139,7 +139,7 @@ func TestUseCaseSendPasswordResetEmail(t *testing.T) {
 				uc, deps := MockUseCase(ctrl)
 				mockTx(ctx, deps.txManager)
 				deps.uuid.EXPECT().Generate().Return(gomock.Any().String())
-				deps.userRepo.EXPECT().GetByEmail(gomock.Any(), gomock.Any()).Return(&dto.User{ID: 1, Email: testEmail, VerifiedAt: &now}, nil)
+				deps.userRepo.EXPECT().GetByEmail(gomock.Any(), gomock.Any()).Return(&dto.User{ID: 1, Email: testEmail, VerifiedAt: &now, IsActivated: true}, nil)
 				deps.etRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(repo.ErrConflict)
 				return uc
 			},
@@ -154,7 +154,7 @@ func TestUseCaseSendPasswordResetEmail(t *testing.T) {
 				uc, deps := MockUseCase(ctrl)
 				mockTx(ctx, deps.txManager)
 				deps.uuid.EXPECT().Generate().Return(gomock.Any().String())
-				deps.userRepo.EXPECT().GetByEmail(gomock.Any(), gomock.Any()).Return(&dto.User{ID: 1, Email: testEmail, VerifiedAt: &now}, nil)
+				deps.userRepo.EXPECT().GetByEmail(gomock.Any(), gomock.Any()).Return(&dto.User{ID: 1, Email: testEmail, VerifiedAt: &now, IsActivated: true}, nil)
 				deps.etRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
 				deps.notificationRepo.EXPECT().SendResetPasswordEmail(gomock.Any(), testEmail, gomock.Any()).Return(fmt.Errorf("failed send notification"))
 				return uc
@@ -163,6 +163,20 @@ func TestUseCaseSendPasswordResetEmail(t *testing.T) {
 			wantErrType: customerrors.InternalErr,
 			wantErrMsg:  "failed to send reset password email",
 		},
+		{
+			name: "user is not activated, activation email sent",
+			args: a,
+			uc: func(ctrl *gomock.Controller) *auth.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				mockTx(ctx, deps.txManager)
+				deps.uuid.EXPECT().Generate().Return("activation")
+				deps.userRepo.EXPECT().GetByEmail(gomock.Any(), gomock.Any()).Return(&dto.User{ID: 1, Email: testEmail, VerifiedAt: &now}, nil)
+				deps.etRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+				deps.notificationRepo.EXPECT().SendAutoRegisterEmail(gomock.Any(), testEmail, "activation").Return(nil)
+				return uc
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -37,6 +37,7 @@ func TestUseCaseResetPassword(t *testing.T) {
 		UserID:    1,
 		Purpose:   dto.PurposeReset,
 	}
+	testUser := &dto.User{ID: 1, Email: testEmail}
 	tests := []struct {
 		name        string
 		uc          func(ctrl *gomock.Controller) *auth.UseCase
@@ -53,6 +54,8 @@ func TestUseCaseResetPassword(t *testing.T) {
 				mockTx(ctx, deps.txManager)
 				mockHashPwd(deps.passwordSvc, false)
 				deps.etRepo.EXPECT().GetByID(ctx, gomock.Any()).Return(&validToken, nil)
+				deps.userRepo.EXPECT().GetByID(ctx, validToken.UserID).Return(testUser, nil)
+				mockPwdPolicy(deps.passwordPolicy, false)
 				deps.userRepo.EXPECT().Update(ctx, gomock.Any()).Return(nil)
 				deps.etRepo.EXPECT().Use(ctx, gomock.Any()).Return(nil)
 				return uc
@@ -67,6 +70,8 @@ func TestUseCaseResetPassword(t *testing.T) {
 				mockTx(ctx, deps.txManager)
 				mockHashPwd(deps.passwordSvc, false)
 				deps.etRepo.EXPECT().GetByID(ctx, gomock.Any()).Return(&validToken, nil)
+				deps.userRepo.EXPECT().GetByID(ctx, validToken.UserID).Return(testUser, nil)
+				mockPwdPolicy(deps.passwordPolicy, false)
 
 				deps.userRepo.EXPECT().Update(ctx, gomock.Any()).Return(nil)
 				deps.etRepo.EXPECT().Use(ctx, gomock.Any()).Return(repo.ErrNotFound)
@@ -84,6 +89,8 @@ func TestUseCaseResetPassword(t *testing.T) {
 				mockTx(ctx, deps.txManager)
 				mockHashPwd(deps.passwordSvc, false)
 				deps.etRepo.EXPECT().GetByID(ctx, gomock.Any()).Return(&validToken, nil)
+				deps.userRepo.EXPECT().GetByID(ctx, validToken.UserID).Return(testUser, nil)
+				mockPwdPolicy(deps.passwordPolicy, false)
 
 				deps.userRepo.EXPECT().Update(ctx, gomock.Any()).Return(nil)
 				deps.etRepo.EXPECT().Use(ctx, gomock.Any()).Return(repo.ErrInternal)
@@ -101,6 +108,8 @@ func TestUseCaseResetPassword(t *testing.T) {
 				mockTx(ctx, deps.txManager)
 				mockHashPwd(deps.passwordSvc, false)
 				deps.etRepo.EXPECT().GetByID(ctx, gomock.Any()).Return(&validToken, nil)
+				deps.userRepo.EXPECT().GetByID(ctx, validToken.UserID).Return(testUser, nil)
+				mockPwdPolicy(deps.passwordPolicy, false)
 				deps.userRepo.EXPECT().Update(ctx, gomock.Any()).Return(repo.ErrNotFound)
 				return uc
 			},
@@ -116,6 +125,8 @@ func TestUseCaseResetPassword(t *testing.T) {
 				mockTx(ctx, deps.txManager)
 				mockHashPwd(deps.passwordSvc, false)
 				deps.etRepo.EXPECT().GetByID(ctx, gomock.Any()).Return(&validToken, nil)
+				deps.userRepo.EXPECT().GetByID(ctx, validToken.UserID).Return(testUser, nil)
+				mockPwdPolicy(deps.passwordPolicy, false)
 				deps.userRepo.EXPECT().Update(ctx, gomock.Any()).Return(repo.ErrInternal)
 				return uc
 			},
@@ -123,6 +134,66 @@ func TestUseCaseResetPassword(t *testing.T) {
 			wantErrType: customerrors.InternalErr,
 			wantErrMsg:  "failed to update user",
 		},
+		{
+			name: "verification token is rejected",
+			args: a,
+			uc: func(ctrl *gomock.Controller) *auth.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				mockTx(ctx, deps.txManager)
+				token := validToken
+				token.Purpose = dto.PurposeVerification
+				deps.etRepo.EXPECT().GetByID(ctx, gomock.Any()).Return(&token, nil)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.ValidationErr,
+			wantErrMsg:  "invalid email token purpose",
+		},
+		{
+			name: "email change token is rejected",
+			args: a,
+			uc: func(ctrl *gomock.Controller) *auth.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				mockTx(ctx, deps.txManager)
+				token := validToken
+				token.Purpose = dto.PurposeEmailChange
+				deps.etRepo.EXPECT().GetByID(ctx, gomock.Any()).Return(&token, nil)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.ValidationErr,
+			wantErrMsg:  "invalid email token purpose",
+		},
+		{
+			name: "email change cancel token is rejected",
+			args: a,
+			uc: func(ctrl *gomock.Controller) *auth.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				mockTx(ctx, deps.txManager)
+				token := validToken
+				token.Purpose = dto.PurposeEmailChangeCancel
+				deps.etRepo.EXPECT().GetByID(ctx, gomock.Any()).Return(&token, nil)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.ValidationErr,
+			wantErrMsg:  "invalid email token purpose",
+		},
+		{
+			name: "activation token can't set password without activation",
+			args: a,
+			uc: func(ctrl *gomock.Controller) *auth.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				mockTx(ctx, deps.txManager)
+				token := validToken
+				token.Purpose = dto.PurposeActivation
+				deps.etRepo.EXPECT().GetByID(ctx, gomock.Any()).Return(&token, nil)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.ValidationErr,
+			wantErrMsg:  "invalid email token purpose",
+		},
 		{
 			name: "email token is expired",
 			args: a,
@@ -187,6 +258,8 @@ func TestUseCaseResetPassword(t *testing.T) {
 				uc, deps := MockUseCase(ctrl)
 				mockTx(ctx, deps.txManager)
 				deps.etRepo.EXPECT().GetByID(ctx, gomock.Any()).Return(&validToken, nil)
+				deps.userRepo.EXPECT().GetByID(ctx, validToken.UserID).Return(testUser, nil)
+				mockPwdPolicy(deps.passwordPolicy, false)
 				mockHashPwd(deps.passwordSvc, true)
 				return uc
 			},
@@ -194,6 +267,35 @@ func TestUseCaseResetPassword(t *testing.T) {
 			wantErrType: customerrors.InternalErr,
 			wantErrMsg:  "failed to hash password",
 		},
+		{
+			name: "failed to get user",
+			args: a,
+			uc: func(ctrl *gomock.Controller) *auth.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				mockTx(ctx, deps.txManager)
+				deps.etRepo.EXPECT().GetByID(ctx, gomock.Any()).Return(&validToken, nil)
+				deps.userRepo.EXPECT().GetByID(ctx, validToken.UserID).Return(nil, repo.ErrInternal)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.InternalErr,
+			wantErrMsg:  "failed to get user",
+		},
+		{
+			name: "password does not meet requirements",
+			args: a,
+			uc: func(ctrl *gomock.Controller) *auth.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				mockTx(ctx, deps.txManager)
+				deps.etRepo.EXPECT().GetByID(ctx, gomock.Any()).Return(&validToken, nil)
+				deps.userRepo.EXPECT().GetByID(ctx, validToken.UserID).Return(testUser, nil)
+				mockPwdPolicy(deps.passwordPolicy, true)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.ValidationErr,
+			wantErrMsg:  "password does not meet requirements",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -8,6 +8,9 @@ import (
 )
 
 func (u *UseCase) Register(ctx context.Context, data *dto.Credentials) error {
+	if err := u.validatePassword(data.Password, data.Email, "password", "email", data.Email); err != nil {
+		return err
+	}
 
 	f := func(ctx context.Context) error {
 		hash, err := u.passwordSvc.HashPassword(data.Password)
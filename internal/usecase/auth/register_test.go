@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
 	"task-trail/internal/customerrors"
+	"task-trail/internal/pkg/password"
 	"task-trail/internal/repo"
 	"task-trail/internal/usecase/auth"
 	"task-trail/internal/usecase/dto"
@@ -44,6 +46,7 @@ func TestUseCaseRegister(t *testing.T) {
 			args: a,
 			uc: func(ctrl *gomock.Controller) *auth.UseCase {
 				uc, deps := MockUseCase(ctrl)
+				mockPwdPolicy(deps.passwordPolicy, false)
 				mockTx(ctx, deps.txManager)
 				mockHashPwd(deps.passwordSvc, false)
 				deps.uuid.EXPECT().Generate().Return(gomock.Any().String())
@@ -59,6 +62,7 @@ func TestUseCaseRegister(t *testing.T) {
 			args: a,
 			uc: func(ctrl *gomock.Controller) *auth.UseCase {
 				uc, deps := MockUseCase(ctrl)
+				mockPwdPolicy(deps.passwordPolicy, false)
 				mockTx(ctx, deps.txManager)
 				mockHashPwd(deps.passwordSvc, false)
 				deps.uuid.EXPECT().Generate().Return(gomock.Any().String())
@@ -76,6 +80,7 @@ func TestUseCaseRegister(t *testing.T) {
 			args: a,
 			uc: func(ctrl *gomock.Controller) *auth.UseCase {
 				uc, deps := MockUseCase(ctrl)
+				mockPwdPolicy(deps.passwordPolicy, false)
 				mockTx(ctx, deps.txManager)
 				mockHashPwd(deps.passwordSvc, false)
 				deps.uuid.EXPECT().Generate().Return(gomock.Any().String())
@@ -92,6 +97,7 @@ func TestUseCaseRegister(t *testing.T) {
 			args: a,
 			uc: func(ctrl *gomock.Controller) *auth.UseCase {
 				uc, deps := MockUseCase(ctrl)
+				mockPwdPolicy(deps.passwordPolicy, false)
 				mockTx(ctx, deps.txManager)
 				mockHashPwd(deps.passwordSvc, false)
 				deps.uuid.EXPECT().Generate().Return(gomock.Any().String())
@@ -108,6 +114,7 @@ func TestUseCaseRegister(t *testing.T) {
 			args: a,
 			uc: func(ctrl *gomock.Controller) *auth.UseCase {
 				uc, deps := MockUseCase(ctrl)
+				mockPwdPolicy(deps.passwordPolicy, false)
 				mockTx(ctx, deps.txManager)
 				mockHashPwd(deps.passwordSvc, false)
 				deps.uuid.EXPECT().Generate().Return(gomock.Any().String())
@@ -125,6 +132,7 @@ func TestUseCaseRegister(t *testing.T) {
 			args: a,
 			uc: func(ctrl *gomock.Controller) *auth.UseCase {
 				uc, deps := MockUseCase(ctrl)
+				mockPwdPolicy(deps.passwordPolicy, false)
 				mockTx(ctx, deps.txManager)
 				mockHashPwd(deps.passwordSvc, false)
 				deps.userRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(0, repo.ErrConflict)
@@ -139,6 +147,7 @@ func TestUseCaseRegister(t *testing.T) {
 			args: a,
 			uc: func(ctrl *gomock.Controller) *auth.UseCase {
 				uc, deps := MockUseCase(ctrl)
+				mockPwdPolicy(deps.passwordPolicy, false)
 				mockTx(ctx, deps.txManager)
 				mockHashPwd(deps.passwordSvc, true)
 				return uc
@@ -152,6 +161,7 @@ func TestUseCaseRegister(t *testing.T) {
 			args: a,
 			uc: func(ctrl *gomock.Controller) *auth.UseCase {
 				uc, deps := MockUseCase(ctrl)
+				mockPwdPolicy(deps.passwordPolicy, false)
 				// transaction mock
 				mockTx(ctx, deps.txManager)
 				// failed to hash password
@@ -163,6 +173,18 @@ func TestUseCaseRegister(t *testing.T) {
 			wantErrType: customerrors.InternalErr,
 			wantErrMsg:  "failed to create new user",
 		},
+		{
+			name: "password does not meet requirements",
+			args: a,
+			uc: func(ctrl *gomock.Controller) *auth.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				mockPwdPolicy(deps.passwordPolicy, true)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.ValidationErr,
+			wantErrMsg:  "password does not meet requirements",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -192,3 +214,24 @@ func TestUseCaseRegister(t *testing.T) {
 		})
 	}
 }
+
+func TestUseCaseRegisterPasswordViolations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, deps := MockUseCase(ctrl)
+	violations := []string{"min length: 8 symbols", "must contain a digit"}
+	deps.passwordPolicy.EXPECT().Validate(testPwd, testEmail).Return(&password.PolicyError{Violations: violations})
+
+	err := uc.Register(context.Background(), &dto.Credentials{Email: testEmail, Password: testPwd})
+	var e *customerrors.Err
+	if !errors.As(err, &e) {
+		t.Fatalf("expected custom error type, got %T", err)
+	}
+	if e.Type != customerrors.ValidationErr {
+		t.Errorf("unexpected error type: got %d, want %d", e.Type, customerrors.ValidationErr)
+	}
+	if !reflect.DeepEqual(e.ResponseData, map[string]any{"password": violations}) {
+		t.Errorf("unexpected response data: got %v, want violations %v", e.ResponseData, violations)
+	}
+}
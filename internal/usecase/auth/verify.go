@@ -9,7 +9,7 @@ import (
 func (u *UseCase) Verify(ctx context.Context, tokenID string) error {
 
 	f := func(ctx context.Context) error {
-		token, err := u.getEmailToken(ctx, tokenID)
+		token, err := u.getEmailTokenWithPurpose(ctx, tokenID, dto.PurposeVerification)
 
 		if err != nil {
 			return err
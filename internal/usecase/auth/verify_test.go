@@ -115,6 +115,66 @@ func TestUseCaseVerify(t *testing.T) {
 			wantErrType: customerrors.InternalErr,
 			wantErrMsg:  "failed to update user",
 		},
+		{
+			name: "password reset token is rejected",
+			args: a,
+			uc: func(ctrl *gomock.Controller) *auth.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				mockTx(ctx, deps.txManager)
+				token := validToken
+				token.Purpose = dto.PurposeReset
+				deps.etRepo.EXPECT().GetByID(ctx, gomock.Any()).Return(&token, nil)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.ValidationErr,
+			wantErrMsg:  "invalid email token purpose",
+		},
+		{
+			name: "email change token is rejected",
+			args: a,
+			uc: func(ctrl *gomock.Controller) *auth.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				mockTx(ctx, deps.txManager)
+				token := validToken
+				token.Purpose = dto.PurposeEmailChange
+				deps.etRepo.EXPECT().GetByID(ctx, gomock.Any()).Return(&token, nil)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.ValidationErr,
+			wantErrMsg:  "invalid email token purpose",
+		},
+		{
+			name: "email change cancel token is rejected",
+			args: a,
+			uc: func(ctrl *gomock.Controller) *auth.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				mockTx(ctx, deps.txManager)
+				token := validToken
+				token.Purpose = dto.PurposeEmailChangeCancel
+				deps.etRepo.EXPECT().GetByID(ctx, gomock.Any()).Return(&token, nil)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.ValidationErr,
+			wantErrMsg:  "invalid email token purpose",
+		},
+		{
+			name: "activation token is rejected",
+			args: a,
+			uc: func(ctrl *gomock.Controller) *auth.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				mockTx(ctx, deps.txManager)
+				token := validToken
+				token.Purpose = dto.PurposeActivation
+				deps.etRepo.EXPECT().GetByID(ctx, gomock.Any()).Return(&token, nil)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.ValidationErr,
+			wantErrMsg:  "invalid email token purpose",
+		},
 		{
 			name: "email token is expired",
 			args: a,
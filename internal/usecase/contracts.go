@@ -2,12 +2,15 @@ package usecase
 
 import (
 	"context"
+	"task-trail/internal/pkg/pubsub"
 	"task-trail/internal/usecase/dto"
+	"time"
 )
 
 // Authentication defines the contract for user authentication and authorization use cases.
 // It provides methods for user login, registration, logout, token refresh, email verification,
-// resending verification emails, sending password reset emails, and resetting passwords.
+// resending verification emails, sending password reset emails, resetting passwords, changing email address
+// and activation of auto-registered users.
 //
 // Implementations of this interface should handle the necessary business logic for each operation,
 // including token management and email communications.
@@ -15,6 +18,7 @@ type Authentication interface {
 	Login(ctx context.Context, data *dto.Credentials) (*dto.LoginRes, error)
 	Register(ctx context.Context, data *dto.Credentials) error
 	AutoRegister(ctx context.Context, email string) error
+	Activate(ctx context.Context, data *dto.Activation) error
 	Logout(ctx context.Context, refreshToken string) error
 	Refresh(ctx context.Context, refreshToken string) (*dto.RefreshRes, error)
 	Verify(ctx context.Context, tokenID string) error
@@ -22,15 +26,23 @@ type Authentication interface {
 	SendPasswordResetEmail(ctx context.Context, email string) error
 	ResetPassword(ctx context.Context, data *dto.PasswordReset) error
 	ChangePassword(ctx context.Context, data *dto.PasswordChange) error
+	RequestEmailChange(ctx context.Context, data *dto.EmailChange) error
+	ConfirmEmailChange(ctx context.Context, tokenID string) error
+	CancelEmailChange(ctx context.Context, tokenID string) error
 }
 
 // User defines the contract for user-related operations in the application.
 // It provides methods for updating a user's avatar, updating user information by ID,
-// and retrieving a user by their ID.
+// retrieving a user by their ID, exporting personal data and account deletion.
 type User interface {
 	UpdateAvatar(ctx context.Context, data *dto.FileUpload) (*dto.UserAvatar, error)
 	UpdateByID(ctx context.Context, data *dto.UserUpdate) (*dto.CurrentUser, error)
 	GetCurrentByID(ctx context.Context, ID int) (*dto.CurrentUser, error)
+	Export(ctx context.Context, ID int) (*dto.UserExport, error)
+	RequestDeletion(ctx context.Context, data *dto.AccountDeletion) (*time.Time, error)
+	CancelDeletion(ctx context.Context, ID int) error
+	// DeleteScheduled returns the number of deleted accounts along with the errors of failed ones.
+	DeleteScheduled(ctx context.Context) (int, error)
 }
 
 // File defines the contract for file storage operations.
@@ -47,3 +59,48 @@ type Project interface {
 	AddMembers(ctx context.Context, data *dto.ProjectAddMembers) error
 	GetCandidates(ctx context.Context, ownerID int, projectID int) ([]*dto.UserSimple, error)
 }
+
+// Outbox defines the contract for delivery of emails stored in the transactional outbox.
+type Outbox interface {
+	// Deliver returns counts of the saved results along with the error when some of them are not saved.
+	Deliver(ctx context.Context) (*dto.OutboxDelivery, error)
+	// GetStatus returns delivery status of the email created for the event, it is kept until the sent email is cleaned up.
+	GetStatus(ctx context.Context, eventID string) (*dto.OutboxMessageStatus, error)
+}
+
+type Digest interface {
+	// Send sends summary emails to users with the given digest frequency.
+	Send(ctx context.Context, frequency dto.DigestFrequency) (*dto.DigestDelivery, error)
+}
+
+// Notification defines the contract for the in-app notification inbox of the current user.
+type Notification interface {
+	GetList(ctx context.Context, data *dto.NotificationList) (*dto.NotificationPage, error)
+	MarkRead(ctx context.Context, userID int, ID int) error
+	MarkAllRead(ctx context.Context, userID int) (int, error)
+	// GetPreferences returns the full matrix of notification types and channels with digest frequency.
+	GetPreferences(ctx context.Context, userID int) (*dto.NotificationPreferences, error)
+	UpdatePreferences(ctx context.Context, data *dto.NotificationPreferences) (*dto.NotificationPreferences, error)
+}
+
+type Realtime interface {
+	// Subscribe returns events of projects the user is a member of, the channel is closed when ctx is done.
+	Subscribe(ctx context.Context, userID int, projectIDs []int) (<-chan pubsub.Event, error)
+}
+
+// Activity defines the contract for reading the project audit log.
+type Activity interface {
+	GetList(ctx context.Context, data *dto.ActivityList) (*dto.ActivityPage, error)
+}
+
+type Webhook interface {
+	pubsub.Publisher
+	Create(ctx context.Context, data *dto.WebhookCreate) (*dto.Webhook, error)
+	GetList(ctx context.Context, projectID int, ownerID int) ([]*dto.Webhook, error)
+	Delete(ctx context.Context, projectID int, ownerID int, ID int) error
+	GetDeliveries(ctx context.Context, data *dto.WebhookDeliveryList) ([]*dto.WebhookDelivery, error)
+	// SendTest sends the test event to the webhook and returns the delivery result.
+	SendTest(ctx context.Context, projectID int, ownerID int, ID int) (*dto.WebhookDelivery, error)
+	// Deliver sends pending deliveries, used by the background worker.
+	Deliver(ctx context.Context) (*dto.WebhookDeliveryStats, error)
+}
@@ -0,0 +1,128 @@
+package digest
+
+import (
+	"context"
+	"strconv"
+	"task-trail/internal/customerrors"
+	"task-trail/internal/pkg/smtp"
+	"task-trail/internal/pkg/smtp/templates"
+	"task-trail/internal/pkg/uuid"
+	"task-trail/internal/repo"
+	"task-trail/internal/usecase/dto"
+	"time"
+)
+
+// claimed events are sent again by the next run if they are not deleted in time,
+// e.g. the worker was stopped, so it must exceed the time of sending all digests
+const claimTimeout = time.Minute * 30
+
+type UseCase struct {
+	digestRepo    repo.NotificationDigestRepository
+	sender        smtp.Sender
+	renderer      *templates.Renderer
+	uuidGenerator uuid.Generator
+	errHandler    customerrors.ErrorHandler
+	projectURL    string
+}
+
+func New(
+	digestRepo repo.NotificationDigestRepository,
+	sender smtp.Sender,
+	renderer *templates.Renderer,
+	uuidGenerator uuid.Generator,
+	errHandler customerrors.ErrorHandler,
+	projectURL string,
+) *UseCase {
+	return &UseCase{
+		digestRepo:    digestRepo,
+		sender:        sender,
+		renderer:      renderer,
+		uuidGenerator: uuidGenerator,
+		errHandler:    errHandler,
+		projectURL:    projectURL,
+	}
+}
+
+type digestData struct {
+	Daily    bool
+	Projects []*projectData
+}
+
+type projectData struct {
+	Name   string
+	URL    string
+	Events []*eventData
+}
+
+type eventData struct {
+	Type string
+}
+
+// Send sends one summary email to every user with collected events.
+// Daily run also flushes events left after the user switched digest off.
+// Events are claimed, so concurrent runs don't send them twice, and kept for the next run if the email is not sent.
+func (u *UseCase) Send(ctx context.Context, frequency dto.DigestFrequency) (*dto.DigestDelivery, error) {
+	frequencies := []dto.DigestFrequency{frequency}
+	if frequency == dto.DigestDaily {
+		frequencies = append(frequencies, dto.DigestNone)
+	}
+	events, err := u.digestRepo.ClaimPending(ctx, frequencies, time.Now().Add(claimTimeout))
+	if err != nil {
+		return nil, u.errHandler.InternalTrouble(err, "failed to get digest events", "frequency", frequency)
+	}
+	retVal := &dto.DigestDelivery{}
+	for len(events) > 0 {
+		// events are ordered by user, so take all events of the first one
+		n := 1
+		for n < len(events) && events[n].UserID == events[0].UserID {
+			n++
+		}
+		userEvents := events[:n]
+		events = events[n:]
+
+		email, err := u.renderer.Render(userEvents[0].Language, templates.Digest, u.newDigestData(frequency, userEvents))
+		if err != nil {
+			return nil, u.errHandler.InternalTrouble(err, "failed to render digest", "userID", userEvents[0].UserID)
+		}
+		msg := smtp.Message{Recipients: []string{userEvents[0].Email}, Subject: email.Subject, Text: email.Text, HTML: email.HTML}
+		if err := u.sender.Send(ctx, msg, u.uuidGenerator.Generate()); err != nil {
+			retVal.Failed++
+			continue
+		}
+		IDs := make([]int, 0, len(userEvents))
+		for _, e := range userEvents {
+			IDs = append(IDs, e.ID)
+		}
+		if err := u.digestRepo.Delete(ctx, IDs); err != nil {
+			return nil, u.errHandler.InternalTrouble(err, "failed to delete sent digest events", "userID", userEvents[0].UserID)
+		}
+		retVal.Sent++
+	}
+	return retVal, nil
+}
+
+// newDigestData groups user events by project, events are expected to be ordered by project.
+func (u *UseCase) newDigestData(frequency dto.DigestFrequency, events []*dto.DigestEvent) *digestData {
+	retVal := &digestData{Daily: frequency != dto.DigestWeekly}
+	var project *projectData
+	var projectID *int
+	for _, e := range events {
+		if project == nil || !sameProject(projectID, e.ProjectID) {
+			project = &projectData{Name: e.Payload["projectName"]}
+			if e.ProjectID != nil {
+				project.URL = u.projectURL + strconv.Itoa(*e.ProjectID)
+			}
+			projectID = e.ProjectID
+			retVal.Projects = append(retVal.Projects, project)
+		}
+		project.Events = append(project.Events, &eventData{Type: string(e.Type)})
+	}
+	return retVal
+}
+
+func sameProject(a *int, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
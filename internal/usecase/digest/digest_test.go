@@ -0,0 +1,191 @@
+package digest_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"task-trail/internal/customerrors"
+	"task-trail/internal/pkg/smtp"
+	"task-trail/internal/pkg/smtp/templates"
+	"task-trail/internal/repo"
+	"task-trail/internal/usecase/digest"
+	"task-trail/internal/usecase/dto"
+	"task-trail/test/mocks"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+const testProjectURL = "https://test.test/projects/"
+
+type testDeps struct {
+	digestRepo    mocks.MockNotificationDigestRepository
+	sender        mocks.MockSmtpSender
+	uuidGenerator mocks.MockGenerator
+}
+
+func MockUseCase(t *testing.T, ctrl *gomock.Controller) (*digest.UseCase, *testDeps) {
+	digestRepo := mocks.NewMockNotificationDigestRepository(ctrl)
+	sender := mocks.NewMockSmtpSender(ctrl)
+	uuidGenerator := mocks.NewMockGenerator(ctrl)
+	renderer, err := templates.New("en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	uc := digest.New(digestRepo, sender, renderer, uuidGenerator, customerrors.NewErrHander(), testProjectURL)
+	return uc, &testDeps{digestRepo: *digestRepo, sender: *sender, uuidGenerator: *uuidGenerator}
+}
+
+// messageContains matches the message sent to the recipient with text containing all given parts.
+func messageContains(recipient string, parts ...string) gomock.Matcher {
+	return gomock.Cond(func(x any) bool {
+		msg, ok := x.(smtp.Message)
+		if !ok || !reflect.DeepEqual(msg.Recipients, []string{recipient}) {
+			return false
+		}
+		for _, p := range parts {
+			if !strings.Contains(msg.Text, p) || !strings.Contains(msg.HTML, p) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func TestUseCaseSend(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	projectID := 1
+	otherProjectID := 2
+	events := []*dto.DigestEvent{
+		{
+			ID: 1, UserID: 1, Email: "first@test.test", Language: "en", Type: dto.NotificationTypeProjectInvite,
+			ProjectID: &projectID, Payload: map[string]string{"projectName": "Alpha"},
+		},
+		{
+			ID: 2, UserID: 1, Email: "first@test.test", Language: "en", Type: dto.NotificationTypeProjectInvite,
+			ProjectID: &otherProjectID, Payload: map[string]string{"projectName": "Beta"},
+		},
+		{
+			ID: 3, UserID: 2, Email: "second@test.test", Language: "ru", Type: dto.NotificationTypeProjectInvite,
+			ProjectID: &projectID, Payload: map[string]string{"projectName": "Alpha"},
+		},
+	}
+	sendErr := fmt.Errorf("smtp unavailable")
+
+	tests := []struct {
+		name        string
+		frequency   dto.DigestFrequency
+		uc          func(ctrl *gomock.Controller) *digest.UseCase
+		want        *dto.DigestDelivery
+		wantErr     bool
+		wantErrType customerrors.ErrType
+		wantErrMsg  string
+	}{
+		{
+			name:      "nothing to send",
+			frequency: dto.DigestWeekly,
+			uc: func(ctrl *gomock.Controller) *digest.UseCase {
+				uc, deps := MockUseCase(t, ctrl)
+				deps.digestRepo.EXPECT().ClaimPending(ctx, []dto.DigestFrequency{dto.DigestWeekly}, gomock.Any()).Return(nil, nil)
+				return uc
+			},
+			want: &dto.DigestDelivery{},
+		},
+		{
+			name:      "one email per user grouped by project",
+			frequency: dto.DigestDaily,
+			uc: func(ctrl *gomock.Controller) *digest.UseCase {
+				uc, deps := MockUseCase(t, ctrl)
+				deps.digestRepo.EXPECT().ClaimPending(ctx, []dto.DigestFrequency{dto.DigestDaily, dto.DigestNone}, gomock.Any()).Return(events, nil)
+				deps.uuidGenerator.EXPECT().Generate().Return("event-1")
+				deps.sender.EXPECT().Send(
+					ctx,
+					messageContains("first@test.test", "Alpha", "Beta", "You have been invited", testProjectURL+"2"),
+					"event-1",
+				).Return(nil)
+				deps.digestRepo.EXPECT().Delete(ctx, []int{1, 2}).Return(nil)
+				deps.uuidGenerator.EXPECT().Generate().Return("event-2")
+				deps.sender.EXPECT().Send(ctx, messageContains("second@test.test", "Вас пригласили в проект"), "event-2").Return(nil)
+				deps.digestRepo.EXPECT().Delete(ctx, []int{3}).Return(nil)
+				return uc
+			},
+			want: &dto.DigestDelivery{Sent: 2},
+		},
+		{
+			name:      "failed digest is kept for the next run",
+			frequency: dto.DigestDaily,
+			uc: func(ctrl *gomock.Controller) *digest.UseCase {
+				uc, deps := MockUseCase(t, ctrl)
+				deps.digestRepo.EXPECT().ClaimPending(ctx, gomock.Any(), gomock.Any()).Return(events, nil)
+				deps.uuidGenerator.EXPECT().Generate().Return("event-1").Times(2)
+				deps.sender.EXPECT().Send(ctx, messageContains("first@test.test"), gomock.Any()).Return(sendErr)
+				deps.sender.EXPECT().Send(ctx, messageContains("second@test.test"), gomock.Any()).Return(nil)
+				deps.digestRepo.EXPECT().Delete(ctx, []int{3}).Return(nil)
+				return uc
+			},
+			want: &dto.DigestDelivery{Sent: 1, Failed: 1},
+		},
+		{
+			name:      "failed to get digest events",
+			frequency: dto.DigestWeekly,
+			uc: func(ctrl *gomock.Controller) *digest.UseCase {
+				uc, deps := MockUseCase(t, ctrl)
+				deps.digestRepo.EXPECT().ClaimPending(ctx, gomock.Any(), gomock.Any()).Return(nil, repo.ErrInternal)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.InternalErr,
+			wantErrMsg:  "failed to get digest events",
+		},
+		{
+			name:      "failed to delete sent events",
+			frequency: dto.DigestWeekly,
+			uc: func(ctrl *gomock.Controller) *digest.UseCase {
+				uc, deps := MockUseCase(t, ctrl)
+				deps.digestRepo.EXPECT().ClaimPending(ctx, gomock.Any(), gomock.Any()).Return(events[2:], nil)
+				deps.uuidGenerator.EXPECT().Generate().Return("event-1")
+				deps.sender.EXPECT().Send(ctx, gomock.Any(), gomock.Any()).Return(nil)
+				deps.digestRepo.EXPECT().Delete(ctx, []int{3}).Return(repo.ErrInternal)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.InternalErr,
+			wantErrMsg:  "failed to delete sent digest events",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := tt.uc(ctrl)
+			got, err := u.Send(ctx, tt.frequency)
+			if tt.wantErr {
+				var e *customerrors.Err
+				if err == nil {
+					t.Errorf("expected error but got nil")
+					return
+				}
+				if !errors.As(err, &e) {
+					t.Errorf("expected custom error type, got %T", err)
+					return
+				}
+				if e.Type != tt.wantErrType {
+					t.Errorf("unexpected error type: got %d, want %d", e.Type, tt.wantErrType)
+				}
+				if e.Msg != tt.wantErrMsg {
+					t.Errorf("unexpected error msg: got %s, want %s", e.Msg, tt.wantErrMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
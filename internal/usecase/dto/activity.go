@@ -0,0 +1,64 @@
+package dto
+
+import "time"
+
+// entity
+
+// activity actions, named as <entity type>.<verb>
+const (
+	ActivityProjectCreated = "project.created"
+	ActivityMemberAdded    = "member.added"
+)
+
+// types of entities changed by activity
+const (
+	ActivityEntityProject = "project"
+	ActivityEntityMember  = "member"
+	ActivityEntityTask    = "task"
+)
+
+// Activity is the audit log entry of the project change.
+type Activity struct {
+	ID        int
+	ProjectID int
+	// ActorID is nil when the actor account is removed
+	ActorID    *int
+	Action     string
+	EntityType string
+	EntityID   int
+	// Diff contains changed fields, created entities contain their initial values
+	Diff      map[string]any
+	RequestID *string
+	CreatedAt time.Time
+}
+
+// request
+
+type ActivityCreate struct {
+	ProjectID  int
+	ActorID    int
+	Action     string
+	EntityType string
+	EntityID   int
+	Diff       map[string]any
+	// RequestID is the id of http request made the change, empty for background jobs
+	RequestID string
+}
+
+type ActivityList struct {
+	ProjectID int
+	UserID    int
+	// filters, zero values are ignored
+	ActorID    int
+	EntityType string
+	EntityID   int
+	Limit      int
+	Offset     int
+}
+
+// response
+
+type ActivityPage struct {
+	Items []*Activity
+	Total int
+}
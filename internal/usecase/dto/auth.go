@@ -11,6 +11,8 @@ type UserCreate struct {
 	Email        string
 	PasswordHash string
 	IsVerified   bool
+	// IsInvited user is created by another user and can't log in until activation
+	IsInvited bool
 }
 
 type PasswordChange struct {
@@ -24,6 +26,12 @@ type PasswordReset struct {
 	NewPassword string
 }
 
+type Activation struct {
+	TokenID  string
+	Password string
+	Username string
+}
+
 // Response
 
 type LoginRes struct {
@@ -0,0 +1,28 @@
+package dto
+
+import "time"
+
+// entity
+
+// DigestEvent is the notification waiting to be included in the digest email.
+type DigestEvent struct {
+	ID        int
+	UserID    int
+	Email     string
+	Language  string
+	Type      NotificationType
+	ProjectID *int
+	TaskID    *int
+	// Payload contains data for rendering, e.g. project and task names
+	Payload   map[string]string
+	CreatedAt time.Time
+}
+
+// response
+
+type DigestDelivery struct {
+	// Sent is the number of sent digest emails
+	Sent int
+	// Failed is the number of digests kept for the next run because of the send error
+	Failed int
+}
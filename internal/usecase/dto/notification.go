@@ -1,7 +1,97 @@
 package dto
 
+import "time"
+
+// entity
+
+type NotificationType string
+
+const (
+	NotificationTypeProjectInvite NotificationType = "project_invite"
+)
+
+// NotificationTypes lists all types the user can configure in preferences.
+var NotificationTypes = []NotificationType{NotificationTypeProjectInvite}
+
+type NotificationChannel string
+
+const (
+	NotificationChannelEmail NotificationChannel = "email"
+	NotificationChannelInApp NotificationChannel = "in_app"
+)
+
+var NotificationChannels = []NotificationChannel{NotificationChannelEmail, NotificationChannelInApp}
+
+// DigestFrequency defines how often notification emails are sent to the user.
+type DigestFrequency string
+
+const (
+	// DigestNone sends an email immediately for every event
+	DigestNone   DigestFrequency = "none"
+	DigestDaily  DigestFrequency = "daily"
+	DigestWeekly DigestFrequency = "weekly"
+)
+
+// Notification is the in-app notification entry.
+type Notification struct {
+	ID        int
+	UserID    int
+	Type      NotificationType
+	ProjectID *int
+	TaskID    *int
+	// Payload contains data for rendering, e.g. project and task names
+	Payload   map[string]string
+	ReadAt    *time.Time
+	CreatedAt time.Time
+}
+
+// NotificationPreference enables or disables the notification type in the channel.
+// Missing preference means the channel is enabled.
+type NotificationPreference struct {
+	Type    NotificationType
+	Channel NotificationChannel
+	Enabled bool
+}
+
+type NotificationPreferences struct {
+	UserID int
+	Digest DigestFrequency
+	Items  []*NotificationPreference
+}
+
+// request
+
 type NotificationProjectInvite struct {
 	Recipients  []string
 	ProjectID   int
 	ProjectName string
 }
+
+type NotificationEmailChange struct {
+	Recipient string
+	NewEmail  string
+	TokenID   string
+}
+
+type NotificationCreate struct {
+	UserID    int
+	Type      NotificationType
+	ProjectID *int
+	TaskID    *int
+	Payload   map[string]string
+}
+
+type NotificationList struct {
+	UserID     int
+	OnlyUnread bool
+	Limit      int
+	Offset     int
+}
+
+// response
+
+type NotificationPage struct {
+	Items  []*Notification
+	Total  int
+	Unread int
+}
@@ -0,0 +1,62 @@
+package dto
+
+import "time"
+
+// entity
+type OutboxStatus string
+
+const (
+	OutboxPending OutboxStatus = "pending"
+	OutboxSent    OutboxStatus = "sent"
+	// OutboxDead message exceeded delivery attempts and won't be sent
+	OutboxDead OutboxStatus = "dead"
+)
+
+type OutboxMessage struct {
+	ID            int
+	EventID       string
+	RequestID     *string
+	Recipients    []string
+	Subject       string
+	Text          string
+	HTML          string
+	Status        OutboxStatus
+	Attempts      int
+	LastError     *string
+	NextAttemptAt time.Time
+	CreatedAt     time.Time
+	SentAt        *time.Time
+}
+
+// request
+
+type OutboxMessageCreate struct {
+	EventID string
+	// RequestID is id of the request which caused the email, empty for background jobs
+	RequestID  string
+	Recipients []string
+	Subject    string
+	Text       string
+	HTML       string
+}
+
+// response
+
+type OutboxDelivery struct {
+	Sent    int
+	Retried int
+	Dead    int
+}
+
+// OutboxMessageStatus is the delivery status of the email created for the event.
+type OutboxMessageStatus struct {
+	EventID  string
+	Status   OutboxStatus
+	Attempts int
+	// LastError is the error of the last failed attempt
+	LastError *string
+	// NextAttemptAt is the time of the next attempt of the pending message
+	NextAttemptAt *time.Time
+	CreatedAt     time.Time
+	SentAt        *time.Time
+}
@@ -8,6 +8,12 @@ type EmailTokenPurpose string
 const (
 	PurposeVerification EmailTokenPurpose = "verify"
 	PurposeReset        EmailTokenPurpose = "reset"
+	// PurposeEmailChange token is sent to the new address, payload holds the new email.
+	PurposeEmailChange EmailTokenPurpose = "email_change"
+	// PurposeEmailChangeCancel token is sent to the old address, payload holds the confirmation token ID.
+	PurposeEmailChangeCancel EmailTokenPurpose = "email_change_cancel"
+	// PurposeActivation token is sent to auto-registered users to set their password.
+	PurposeActivation EmailTokenPurpose = "activation"
 )
 
 type EmailToken struct {
@@ -17,6 +23,7 @@ type EmailToken struct {
 	CreatedAt time.Time
 	ExpiredAt time.Time
 	UsedAt    *time.Time
+	Payload   *string
 }
 
 type RefreshToken struct {
@@ -40,6 +47,7 @@ type EmailTokenCreate struct {
 	UserID    int
 	ExpiredAt time.Time
 	Purpose   EmailTokenPurpose
+	Payload   string
 }
 
 // response
@@ -4,12 +4,17 @@ import "time"
 
 // entity
 type User struct {
-	ID           int
-	Email        string
-	PasswordHash string
-	VerifiedAt   *time.Time
-	AvatarID     *string
-	Username     *string
+	ID                  int
+	Email               string
+	PasswordHash        string
+	VerifiedAt          *time.Time
+	AvatarID            *string
+	Username            *string
+	CreatedAt           time.Time
+	DeletionRequestedAt *time.Time
+	IsActivated         bool
+	// Language is nil until the user chooses one, emails are sent in the default language
+	Language *string
 }
 
 // request
@@ -21,15 +26,30 @@ type UserUpdate struct {
 	AvatarID     string
 	VerifiedAt   time.Time
 	PasswordHash string
+	Activated    bool
+	Language     string
+}
+
+type EmailChange struct {
+	UserID   int
+	NewEmail string
+	Password string
+}
+
+type AccountDeletion struct {
+	UserID   int
+	Password string
 }
 
 // response
 
 type CurrentUser struct {
-	ID        int
-	Email     string
-	Username  *string
-	AvatarURL *string
+	ID                  int
+	Email               string
+	Username            *string
+	AvatarURL           *string
+	Language            *string
+	DeletionScheduledAt *time.Time
 }
 
 type UserSimple struct {
@@ -54,3 +74,21 @@ type UserEmailAndID struct {
 	ID    int
 	Email string
 }
+
+// UserExport contains all user related data for personal data export.
+type UserExport struct {
+	Profile   *UserExportProfile
+	Projects  []*ProjectRes
+	Tasks     []*Task
+	Files     []*File
+	CreatedAt time.Time
+}
+
+type UserExportProfile struct {
+	ID         int
+	Email      string
+	Username   *string
+	AvatarURL  *string
+	VerifiedAt *time.Time
+	CreatedAt  time.Time
+}
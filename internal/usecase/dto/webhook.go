@@ -0,0 +1,88 @@
+package dto
+
+import "time"
+
+// entity
+
+type Webhook struct {
+	ID        int
+	ProjectID int
+	URL       string
+	Secret    string
+	// Events is the list of subscribed event types, empty list subscribes to all events
+	Events    []string
+	CreatedAt time.Time
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered"
+	// WebhookDeliveryFailed delivery exceeded attempts and won't be retried
+	WebhookDeliveryFailed WebhookDeliveryStatus = "failed"
+)
+
+type WebhookDelivery struct {
+	ID            int
+	WebhookID     int
+	EventID       string
+	EventType     string
+	Payload       string
+	Status        WebhookDeliveryStatus
+	Attempts      int
+	ResponseCode  *int
+	LastError     *string
+	NextAttemptAt time.Time
+	CreatedAt     time.Time
+	DeliveredAt   *time.Time
+	// URL and Secret of the webhook, filled only for pending deliveries
+	URL    string
+	Secret string
+}
+
+// request
+
+type WebhookCreate struct {
+	ProjectID int
+	OwnerID   int
+	URL       string
+	// Secret is generated if empty
+	Secret string
+	Events []string
+}
+
+type WebhookDeliveryCreate struct {
+	WebhookID int
+	EventID   string
+	EventType string
+	Payload   string
+	// NextAttemptAt postpones the first attempt by the worker, nil means now
+	NextAttemptAt *time.Time
+}
+
+// WebhookAttempt is the result of the delivery attempt.
+type WebhookAttempt struct {
+	DeliveryID   int
+	Status       WebhookDeliveryStatus
+	ResponseCode *int
+	LastError    *string
+	// NextAttemptAt is the time of the next retry for pending delivery
+	NextAttemptAt *time.Time
+}
+
+type WebhookDeliveryList struct {
+	ProjectID int
+	OwnerID   int
+	WebhookID int
+	Limit     int
+	Offset    int
+}
+
+// response
+
+type WebhookDeliveryStats struct {
+	Delivered int
+	Retried   int
+	Failed    int
+}
@@ -0,0 +1,63 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"task-trail/internal/customerrors"
+	"task-trail/internal/repo"
+	"task-trail/internal/usecase/dto"
+)
+
+type UseCase struct {
+	txManager        repo.TxManager
+	notificationRepo repo.InAppNotificationRepository
+	preferenceRepo   repo.NotificationPreferenceRepository
+	errHandler       customerrors.ErrorHandler
+}
+
+func New(
+	txManager repo.TxManager,
+	notificationRepo repo.InAppNotificationRepository,
+	preferenceRepo repo.NotificationPreferenceRepository,
+	errHandler customerrors.ErrorHandler,
+) *UseCase {
+	return &UseCase{
+		txManager:        txManager,
+		notificationRepo: notificationRepo,
+		preferenceRepo:   preferenceRepo,
+		errHandler:       errHandler,
+	}
+}
+
+func (u *UseCase) GetList(ctx context.Context, data *dto.NotificationList) (*dto.NotificationPage, error) {
+	items, err := u.notificationRepo.GetList(ctx, data)
+	if err != nil {
+		return nil, u.errHandler.InternalTrouble(err, "failed to get notifications", "userID", data.UserID)
+	}
+	total, unread, err := u.notificationRepo.Count(ctx, data.UserID)
+	if err != nil {
+		return nil, u.errHandler.InternalTrouble(err, "failed to count notifications", "userID", data.UserID)
+	}
+	if data.OnlyUnread {
+		total = unread
+	}
+	return &dto.NotificationPage{Items: items, Total: total, Unread: unread}, nil
+}
+
+func (u *UseCase) MarkRead(ctx context.Context, userID int, ID int) error {
+	if err := u.notificationRepo.MarkRead(ctx, userID, ID); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return u.errHandler.NotFound(err, "notification not found", "userID", userID, "notificationID", ID)
+		}
+		return u.errHandler.InternalTrouble(err, "failed to mark notification as read", "userID", userID, "notificationID", ID)
+	}
+	return nil
+}
+
+func (u *UseCase) MarkAllRead(ctx context.Context, userID int) (int, error) {
+	marked, err := u.notificationRepo.MarkAllRead(ctx, userID)
+	if err != nil {
+		return 0, u.errHandler.InternalTrouble(err, "failed to mark notifications as read", "userID", userID)
+	}
+	return marked, nil
+}
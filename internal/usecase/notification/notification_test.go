@@ -0,0 +1,178 @@
+package notification_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"task-trail/internal/customerrors"
+	"task-trail/internal/repo"
+	"task-trail/internal/usecase/dto"
+	"task-trail/internal/usecase/notification"
+	"task-trail/test/mocks"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+type testDeps struct {
+	txManager        mocks.MockTxManager
+	notificationRepo mocks.MockInAppNotificationRepository
+	preferenceRepo   mocks.MockNotificationPreferenceRepository
+}
+
+func MockUseCase(ctrl *gomock.Controller) (*notification.UseCase, *testDeps) {
+	txManager := mocks.NewMockTxManager(ctrl)
+	notificationRepo := mocks.NewMockInAppNotificationRepository(ctrl)
+	preferenceRepo := mocks.NewMockNotificationPreferenceRepository(ctrl)
+	uc := notification.New(txManager, notificationRepo, preferenceRepo, customerrors.NewErrHander())
+	return uc, &testDeps{txManager: *txManager, notificationRepo: *notificationRepo, preferenceRepo: *preferenceRepo}
+}
+
+func mockTx(ctx context.Context, txManager mocks.MockTxManager) {
+	txManager.EXPECT().DoWithTx(ctx, gomock.Any()).
+		DoAndReturn(
+			func(ctx context.Context, f func(ctx context.Context) error) error {
+				return f(ctx)
+			},
+		)
+}
+
+func checkErr(t *testing.T, err error, wantErr bool, wantErrType customerrors.ErrType, wantErrMsg string) {
+	t.Helper()
+	if !wantErr {
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		return
+	}
+	var e *customerrors.Err
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+	if !errors.As(err, &e) {
+		t.Errorf("expected custom error type, got %T", err)
+		return
+	}
+	if e.Type != wantErrType {
+		t.Errorf("unexpected error type: got %d, want %d", e.Type, wantErrType)
+	}
+	if e.Msg != wantErrMsg {
+		t.Errorf("unexpected error msg: got %s, want %s", e.Msg, wantErrMsg)
+	}
+}
+
+func TestUseCaseGetList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	items := []*dto.Notification{{ID: 1, UserID: 1, Type: dto.NotificationTypeProjectInvite}}
+
+	tests := []struct {
+		name        string
+		data        *dto.NotificationList
+		uc          func(ctrl *gomock.Controller, data *dto.NotificationList) *notification.UseCase
+		want        *dto.NotificationPage
+		wantErr     bool
+		wantErrType customerrors.ErrType
+		wantErrMsg  string
+	}{
+		{
+			name: "success",
+			data: &dto.NotificationList{UserID: 1, Limit: 20},
+			uc: func(ctrl *gomock.Controller, data *dto.NotificationList) *notification.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				deps.notificationRepo.EXPECT().GetList(ctx, data).Return(items, nil)
+				deps.notificationRepo.EXPECT().Count(ctx, 1).Return(5, 2, nil)
+				return uc
+			},
+			want: &dto.NotificationPage{Items: items, Total: 5, Unread: 2},
+		},
+		{
+			name: "only unread",
+			data: &dto.NotificationList{UserID: 1, Limit: 20, OnlyUnread: true},
+			uc: func(ctrl *gomock.Controller, data *dto.NotificationList) *notification.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				deps.notificationRepo.EXPECT().GetList(ctx, data).Return(items, nil)
+				deps.notificationRepo.EXPECT().Count(ctx, 1).Return(5, 2, nil)
+				return uc
+			},
+			want: &dto.NotificationPage{Items: items, Total: 2, Unread: 2},
+		},
+		{
+			name: "failed to get notifications",
+			data: &dto.NotificationList{UserID: 1, Limit: 20},
+			uc: func(ctrl *gomock.Controller, data *dto.NotificationList) *notification.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				deps.notificationRepo.EXPECT().GetList(ctx, data).Return(nil, repo.ErrInternal)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.InternalErr,
+			wantErrMsg:  "failed to get notifications",
+		},
+		{
+			name: "failed to count notifications",
+			data: &dto.NotificationList{UserID: 1, Limit: 20},
+			uc: func(ctrl *gomock.Controller, data *dto.NotificationList) *notification.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				deps.notificationRepo.EXPECT().GetList(ctx, data).Return(items, nil)
+				deps.notificationRepo.EXPECT().Count(ctx, 1).Return(0, 0, repo.ErrInternal)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.InternalErr,
+			wantErrMsg:  "failed to count notifications",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := tt.uc(ctrl, tt.data)
+			got, err := u.GetList(ctx, tt.data)
+			checkErr(t, err, tt.wantErr, tt.wantErrType, tt.wantErrMsg)
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUseCaseMarkRead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		repoErr     error
+		wantErr     bool
+		wantErrType customerrors.ErrType
+		wantErrMsg  string
+	}{
+		{name: "success"},
+		{
+			name:        "notification not found",
+			repoErr:     repo.ErrNotFound,
+			wantErr:     true,
+			wantErrType: customerrors.NotFoundErr,
+			wantErrMsg:  "notification not found",
+		},
+		{
+			name:        "internal error",
+			repoErr:     repo.ErrInternal,
+			wantErr:     true,
+			wantErrType: customerrors.InternalErr,
+			wantErrMsg:  "failed to mark notification as read",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, deps := MockUseCase(ctrl)
+			deps.notificationRepo.EXPECT().MarkRead(ctx, 1, 2).Return(tt.repoErr)
+			err := u.MarkRead(ctx, 1, 2)
+			checkErr(t, err, tt.wantErr, tt.wantErrType, tt.wantErrMsg)
+		})
+	}
+}
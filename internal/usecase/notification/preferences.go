@@ -0,0 +1,60 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"task-trail/internal/repo"
+	"task-trail/internal/usecase/dto"
+)
+
+// GetPreferences returns preferences for every notification type and channel,
+// channels without stored preference are enabled.
+func (u *UseCase) GetPreferences(ctx context.Context, userID int) (*dto.NotificationPreferences, error) {
+	stored, err := u.preferenceRepo.Get(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, u.errHandler.NotFound(err, "user not found", "userID", userID)
+		}
+		return nil, u.errHandler.InternalTrouble(err, "failed to get notification preferences", "userID", userID)
+	}
+	type key struct {
+		t       dto.NotificationType
+		channel dto.NotificationChannel
+	}
+	enabled := make(map[key]bool, len(stored.Items))
+	for _, p := range stored.Items {
+		enabled[key{p.Type, p.Channel}] = p.Enabled
+	}
+	retVal := &dto.NotificationPreferences{
+		UserID: userID,
+		Digest: stored.Digest,
+		Items:  make([]*dto.NotificationPreference, 0, len(dto.NotificationTypes)*len(dto.NotificationChannels)),
+	}
+	for _, t := range dto.NotificationTypes {
+		for _, channel := range dto.NotificationChannels {
+			v, ok := enabled[key{t, channel}]
+			retVal.Items = append(retVal.Items, &dto.NotificationPreference{Type: t, Channel: channel, Enabled: v || !ok})
+		}
+	}
+	return retVal, nil
+}
+
+// UpdatePreferences saves given preferences and digest frequency, returns all user preferences.
+func (u *UseCase) UpdatePreferences(ctx context.Context, data *dto.NotificationPreferences) (*dto.NotificationPreferences, error) {
+	var retVal *dto.NotificationPreferences
+	err := u.txManager.DoWithTx(ctx, func(ctx context.Context) error {
+		if err := u.preferenceRepo.Update(ctx, data); err != nil {
+			if errors.Is(err, repo.ErrNotFound) {
+				return u.errHandler.NotFound(err, "user not found", "userID", data.UserID)
+			}
+			return u.errHandler.InternalTrouble(err, "failed to update notification preferences", "userID", data.UserID)
+		}
+		var err error
+		retVal, err = u.GetPreferences(ctx, data.UserID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return retVal, nil
+}
@@ -0,0 +1,167 @@
+package notification_test
+
+import (
+	"context"
+	"reflect"
+	"task-trail/internal/customerrors"
+	"task-trail/internal/repo"
+	"task-trail/internal/usecase/dto"
+	"task-trail/internal/usecase/notification"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+// allPreferences returns the full preferences matrix with the given disabled preferences.
+func allPreferences(digest dto.DigestFrequency, disabled ...*dto.NotificationPreference) *dto.NotificationPreferences {
+	retVal := &dto.NotificationPreferences{UserID: 1, Digest: digest}
+	for _, t := range dto.NotificationTypes {
+		for _, channel := range dto.NotificationChannels {
+			p := &dto.NotificationPreference{Type: t, Channel: channel, Enabled: true}
+			for _, d := range disabled {
+				if d.Type == t && d.Channel == channel {
+					p.Enabled = false
+				}
+			}
+			retVal.Items = append(retVal.Items, p)
+		}
+	}
+	return retVal
+}
+
+func TestUseCaseGetPreferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	inviteEmail := &dto.NotificationPreference{Type: dto.NotificationTypeProjectInvite, Channel: dto.NotificationChannelEmail}
+	inviteInApp := &dto.NotificationPreference{Type: dto.NotificationTypeProjectInvite, Channel: dto.NotificationChannelInApp, Enabled: true}
+
+	tests := []struct {
+		name        string
+		uc          func(ctrl *gomock.Controller) *notification.UseCase
+		want        *dto.NotificationPreferences
+		wantErr     bool
+		wantErrType customerrors.ErrType
+		wantErrMsg  string
+	}{
+		{
+			name: "defaults",
+			uc: func(ctrl *gomock.Controller) *notification.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				deps.preferenceRepo.EXPECT().Get(ctx, 1).Return(&dto.NotificationPreferences{UserID: 1, Digest: dto.DigestNone}, nil)
+				return uc
+			},
+			want: allPreferences(dto.DigestNone),
+		},
+		{
+			name: "stored preferences",
+			uc: func(ctrl *gomock.Controller) *notification.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				deps.preferenceRepo.EXPECT().Get(ctx, 1).Return(&dto.NotificationPreferences{
+					UserID: 1,
+					Digest: dto.DigestDaily,
+					Items:  []*dto.NotificationPreference{inviteEmail, inviteInApp},
+				}, nil)
+				return uc
+			},
+			want: allPreferences(dto.DigestDaily, inviteEmail),
+		},
+		{
+			name: "user not found",
+			uc: func(ctrl *gomock.Controller) *notification.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				deps.preferenceRepo.EXPECT().Get(ctx, 1).Return(nil, repo.ErrNotFound)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.NotFoundErr,
+			wantErrMsg:  "user not found",
+		},
+		{
+			name: "failed to get preferences",
+			uc: func(ctrl *gomock.Controller) *notification.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				deps.preferenceRepo.EXPECT().Get(ctx, 1).Return(nil, repo.ErrInternal)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.InternalErr,
+			wantErrMsg:  "failed to get notification preferences",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := tt.uc(ctrl)
+			got, err := u.GetPreferences(ctx, 1)
+			checkErr(t, err, tt.wantErr, tt.wantErrType, tt.wantErrMsg)
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUseCaseUpdatePreferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	inviteEmail := &dto.NotificationPreference{Type: dto.NotificationTypeProjectInvite, Channel: dto.NotificationChannelEmail}
+	data := &dto.NotificationPreferences{UserID: 1, Digest: dto.DigestWeekly, Items: []*dto.NotificationPreference{inviteEmail}}
+
+	tests := []struct {
+		name        string
+		uc          func(ctrl *gomock.Controller) *notification.UseCase
+		want        *dto.NotificationPreferences
+		wantErr     bool
+		wantErrType customerrors.ErrType
+		wantErrMsg  string
+	}{
+		{
+			name: "success",
+			uc: func(ctrl *gomock.Controller) *notification.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				mockTx(ctx, deps.txManager)
+				deps.preferenceRepo.EXPECT().Update(ctx, data).Return(nil)
+				deps.preferenceRepo.EXPECT().Get(ctx, 1).Return(data, nil)
+				return uc
+			},
+			want: allPreferences(dto.DigestWeekly, inviteEmail),
+		},
+		{
+			name: "user not found",
+			uc: func(ctrl *gomock.Controller) *notification.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				mockTx(ctx, deps.txManager)
+				deps.preferenceRepo.EXPECT().Update(ctx, data).Return(repo.ErrNotFound)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.NotFoundErr,
+			wantErrMsg:  "user not found",
+		},
+		{
+			name: "failed to update preferences",
+			uc: func(ctrl *gomock.Controller) *notification.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				mockTx(ctx, deps.txManager)
+				deps.preferenceRepo.EXPECT().Update(ctx, data).Return(repo.ErrInternal)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.InternalErr,
+			wantErrMsg:  "failed to update notification preferences",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := tt.uc(ctrl)
+			got, err := u.UpdatePreferences(ctx, data)
+			checkErr(t, err, tt.wantErr, tt.wantErrType, tt.wantErrMsg)
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
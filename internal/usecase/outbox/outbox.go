@@ -0,0 +1,155 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"task-trail/internal/customerrors"
+	"task-trail/internal/pkg/contextmanager"
+	"task-trail/internal/pkg/smtp"
+	"task-trail/internal/repo"
+	"task-trail/internal/usecase/dto"
+	"time"
+)
+
+// upper bound of the delay between delivery attempts
+const maxBackoff = time.Hour * 6
+
+// claimed messages are sent again by the next run if their results are not saved in time,
+// e.g. the worker was stopped, so it must exceed the time of sending the whole batch
+const claimTimeout = time.Minute * 30
+
+type UseCase struct {
+	outboxRepo repo.OutboxRepository
+	sender     smtp.Sender
+	errHandler customerrors.ErrorHandler
+	// number of delivery attempts before the message becomes dead
+	maxAttempts int
+	// number of messages processed in one delivery run
+	batchSize int
+	// delay before the first retry, doubled on each next one
+	backoff time.Duration
+	// number of messages sent at once
+	concurrency int
+}
+
+func New(
+	outboxRepo repo.OutboxRepository,
+	sender smtp.Sender,
+	errHandler customerrors.ErrorHandler,
+	maxAttempts int,
+	batchSize int,
+	backoff time.Duration,
+	concurrency int,
+) *UseCase {
+	return &UseCase{
+		outboxRepo:  outboxRepo,
+		sender:      sender,
+		errHandler:  errHandler,
+		maxAttempts: maxAttempts,
+		batchSize:   batchSize,
+		backoff:     backoff,
+		concurrency: max(concurrency, 1),
+	}
+}
+
+// Deliver sends pending messages from the outbox.
+// Failed messages are retried with exponential backoff and become dead after maxAttempts,
+// messages rejected permanently become dead at once.
+// Messages are claimed first, so they are sent without holding a transaction and each result is saved on its own,
+// failure to save one result doesn't prevent saving the others.
+func (u *UseCase) Deliver(ctx context.Context) (*dto.OutboxDelivery, error) {
+	messages, err := u.outboxRepo.ClaimPending(ctx, u.batchSize, time.Now().Add(claimTimeout))
+	if err != nil {
+		return nil, u.errHandler.InternalTrouble(err, "failed to get pending messages")
+	}
+	retVal := &dto.OutboxDelivery{}
+	var errs []error
+	for i, sendErr := range u.send(ctx, messages) {
+		m := messages[i]
+		if sendErr == nil {
+			if err := u.outboxRepo.MarkSent(ctx, m.ID); err != nil {
+				errs = append(errs, u.errHandler.InternalTrouble(err, "failed to mark message as sent", "eventID", m.EventID))
+				continue
+			}
+			retVal.Sent++
+			continue
+		}
+		var next *time.Time
+		if !errors.Is(sendErr, smtp.ErrPermanent) {
+			next = u.nextAttemptAt(m.Attempts + 1)
+		}
+		if err := u.outboxRepo.MarkFailed(ctx, m.ID, sendErr.Error(), next); err != nil {
+			errs = append(errs, u.errHandler.InternalTrouble(err, "failed to mark message as failed", "eventID", m.EventID))
+			continue
+		}
+		if next == nil {
+			retVal.Dead++
+		} else {
+			retVal.Retried++
+		}
+	}
+	return retVal, errors.Join(errs...)
+}
+
+func (u *UseCase) GetStatus(ctx context.Context, eventID string) (*dto.OutboxMessageStatus, error) {
+	m, err := u.outboxRepo.GetByEventID(ctx, eventID)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, u.errHandler.NotFound(err, "email not found", "eventID", eventID)
+		}
+		return nil, u.errHandler.InternalTrouble(err, "failed to get email", "eventID", eventID)
+	}
+	retVal := &dto.OutboxMessageStatus{
+		EventID:   m.EventID,
+		Status:    m.Status,
+		Attempts:  m.Attempts,
+		LastError: m.LastError,
+		CreatedAt: m.CreatedAt,
+		SentAt:    m.SentAt,
+	}
+	if m.Status == dto.OutboxPending {
+		retVal.NextAttemptAt = &m.NextAttemptAt
+	}
+	return retVal, nil
+}
+
+// send sends messages by at most concurrency at once and returns their errors in the same order.
+func (u *UseCase) send(ctx context.Context, messages []*dto.OutboxMessage) []error {
+	errs := make([]error, len(messages))
+	sem := make(chan struct{}, u.concurrency)
+	var wg sync.WaitGroup
+	for i, m := range messages {
+		msg := smtp.Message{Recipients: m.Recipients, Subject: m.Subject, Text: m.Text, HTML: m.HTML}
+		sendCtx := ctx
+		if m.RequestID != nil {
+			// sender logs id of the request which caused the email
+			sendCtx = contextmanager.WithRequestID(ctx, *m.RequestID)
+		}
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			errs[i] = u.sender.Send(sendCtx, msg, m.EventID)
+		}()
+	}
+	wg.Wait()
+	return errs
+}
+
+// nextAttemptAt returns time of the next delivery attempt, or nil if attempts are exhausted.
+func (u *UseCase) nextAttemptAt(attempts int) *time.Time {
+	if attempts >= u.maxAttempts {
+		return nil
+	}
+	delay := u.backoff
+	for i := 1; i < attempts && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	delay = min(delay, maxBackoff)
+	next := time.Now().Add(delay)
+	return &next
+}
@@ -0,0 +1,394 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"task-trail/internal/customerrors"
+	"task-trail/internal/pkg/contextmanager"
+	"task-trail/internal/pkg/smtp"
+	"task-trail/internal/repo"
+	"task-trail/internal/usecase/dto"
+	"task-trail/internal/usecase/outbox"
+	"task-trail/test/mocks"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	testMaxAttempts = 3
+	testBatchSize   = 10
+	testBackoff     = time.Minute
+	testConcurrency = 2
+)
+
+type testDeps struct {
+	outboxRepo mocks.MockOutboxRepository
+	sender     mocks.MockSmtpSender
+}
+
+func MockUseCase(ctrl *gomock.Controller) (*outbox.UseCase, *testDeps) {
+	outboxRepo := mocks.NewMockOutboxRepository(ctrl)
+	sender := mocks.NewMockSmtpSender(ctrl)
+	uc := outbox.New(outboxRepo, sender, customerrors.NewErrHander(), testMaxAttempts, testBatchSize, testBackoff, testConcurrency)
+	return uc, &testDeps{outboxRepo: *outboxRepo, sender: *sender}
+}
+
+// claimed matches the time until which messages are claimed by the delivery run.
+var claimed = gomock.Cond(func(x any) bool {
+	until, ok := x.(time.Time)
+	return ok && time.Until(until) > 10*time.Minute
+})
+
+// nextAttemptIn matches next attempt time expected after the given delay.
+func nextAttemptIn(delay time.Duration) gomock.Matcher {
+	return gomock.Cond(func(x any) bool {
+		next, ok := x.(*time.Time)
+		if !ok || next == nil {
+			return false
+		}
+		d := time.Until(*next)
+		return d > delay-time.Second && d <= delay
+	})
+}
+
+func TestUseCaseDeliver(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	newMessage := func(ID int, attempts int) *dto.OutboxMessage {
+		return &dto.OutboxMessage{
+			ID:         ID,
+			EventID:    fmt.Sprintf("event-%d", ID),
+			Recipients: []string{"test@test.test"},
+			Subject:    "subject",
+			Text:       "text",
+			HTML:       "<p>text</p>",
+			Status:     dto.OutboxPending,
+			Attempts:   attempts,
+		}
+	}
+	sendErr := fmt.Errorf("smtp unavailable")
+	rejectErr := fmt.Errorf("%w: 550 mailbox unavailable", smtp.ErrPermanent)
+
+	tests := []struct {
+		name        string
+		uc          func(ctrl *gomock.Controller) *outbox.UseCase
+		want        *dto.OutboxDelivery
+		wantErr     bool
+		wantErrType customerrors.ErrType
+		wantErrMsg  string
+	}{
+		{
+			name: "nothing to send",
+			uc: func(ctrl *gomock.Controller) *outbox.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				deps.outboxRepo.EXPECT().ClaimPending(ctx, testBatchSize, claimed).Return(nil, nil)
+				return uc
+			},
+			want: &dto.OutboxDelivery{},
+		},
+		{
+			name: "success",
+			uc: func(ctrl *gomock.Controller) *outbox.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				m := newMessage(1, 0)
+				deps.outboxRepo.EXPECT().ClaimPending(ctx, testBatchSize, claimed).Return([]*dto.OutboxMessage{m}, nil)
+				deps.sender.EXPECT().Send(
+					ctx,
+					smtp.Message{Recipients: m.Recipients, Subject: m.Subject, Text: m.Text, HTML: m.HTML},
+					m.EventID,
+				).Return(nil)
+				deps.outboxRepo.EXPECT().MarkSent(ctx, 1).Return(nil)
+				return uc
+			},
+			want: &dto.OutboxDelivery{Sent: 1},
+		},
+		{
+			name: "request id of the message is passed to the sender",
+			uc: func(ctrl *gomock.Controller) *outbox.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				m := newMessage(1, 0)
+				reqID := "req-1"
+				m.RequestID = &reqID
+				deps.outboxRepo.EXPECT().ClaimPending(ctx, testBatchSize, claimed).Return([]*dto.OutboxMessage{m}, nil)
+				deps.sender.EXPECT().Send(
+					gomock.Cond(func(x any) bool {
+						c, ok := x.(context.Context)
+						return ok && contextmanager.RequestIDFromContext(c) == reqID
+					}),
+					gomock.Any(),
+					m.EventID,
+				).Return(nil)
+				deps.outboxRepo.EXPECT().MarkSent(ctx, 1).Return(nil)
+				return uc
+			},
+			want: &dto.OutboxDelivery{Sent: 1},
+		},
+		{
+			name: "failed messages are retried with backoff or become dead",
+			uc: func(ctrl *gomock.Controller) *outbox.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				messages := []*dto.OutboxMessage{newMessage(1, 0), newMessage(2, 1), newMessage(3, 2), newMessage(4, 0)}
+				deps.outboxRepo.EXPECT().ClaimPending(ctx, testBatchSize, claimed).Return(messages, nil)
+				deps.sender.EXPECT().Send(ctx, gomock.Any(), "event-1").Return(sendErr)
+				deps.sender.EXPECT().Send(ctx, gomock.Any(), "event-2").Return(sendErr)
+				deps.sender.EXPECT().Send(ctx, gomock.Any(), "event-3").Return(sendErr)
+				deps.sender.EXPECT().Send(ctx, gomock.Any(), "event-4").Return(nil)
+				deps.outboxRepo.EXPECT().MarkFailed(ctx, 1, sendErr.Error(), nextAttemptIn(testBackoff)).Return(nil)
+				deps.outboxRepo.EXPECT().MarkFailed(ctx, 2, sendErr.Error(), nextAttemptIn(2*testBackoff)).Return(nil)
+				deps.outboxRepo.EXPECT().MarkFailed(ctx, 3, sendErr.Error(), gomock.Nil()).Return(nil)
+				deps.outboxRepo.EXPECT().MarkSent(ctx, 4).Return(nil)
+				return uc
+			},
+			want: &dto.OutboxDelivery{Sent: 1, Retried: 2, Dead: 1},
+		},
+		{
+			name: "permanently rejected message becomes dead at once",
+			uc: func(ctrl *gomock.Controller) *outbox.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				deps.outboxRepo.EXPECT().ClaimPending(ctx, testBatchSize, claimed).Return([]*dto.OutboxMessage{newMessage(1, 0)}, nil)
+				deps.sender.EXPECT().Send(ctx, gomock.Any(), "event-1").Return(rejectErr)
+				deps.outboxRepo.EXPECT().MarkFailed(ctx, 1, rejectErr.Error(), gomock.Nil()).Return(nil)
+				return uc
+			},
+			want: &dto.OutboxDelivery{Dead: 1},
+		},
+		{
+			name: "messages are sent concurrently",
+			uc: func(ctrl *gomock.Controller) *outbox.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				messages := []*dto.OutboxMessage{newMessage(1, 0), newMessage(2, 0)}
+				deps.outboxRepo.EXPECT().ClaimPending(ctx, testBatchSize, claimed).Return(messages, nil)
+				// every send waits for the other one, so sequential sending fails by timeout
+				started := make(chan struct{}, testConcurrency)
+				deps.sender.EXPECT().Send(ctx, gomock.Any(), gomock.Any()).Times(2).DoAndReturn(
+					func(context.Context, smtp.Message, string) error {
+						started <- struct{}{}
+						deadline := time.After(time.Second)
+						for len(started) < testConcurrency {
+							select {
+							case <-deadline:
+								return sendErr
+							case <-time.After(time.Millisecond):
+							}
+						}
+						return nil
+					},
+				)
+				deps.outboxRepo.EXPECT().MarkSent(ctx, 1).Return(nil)
+				deps.outboxRepo.EXPECT().MarkSent(ctx, 2).Return(nil)
+				return uc
+			},
+			want: &dto.OutboxDelivery{Sent: 2},
+		},
+		{
+			name: "failed to get pending messages",
+			uc: func(ctrl *gomock.Controller) *outbox.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				deps.outboxRepo.EXPECT().ClaimPending(ctx, testBatchSize, claimed).Return(nil, repo.ErrInternal)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.InternalErr,
+			wantErrMsg:  "failed to get pending messages",
+		},
+		{
+			name: "failed result doesn't prevent saving the others",
+			uc: func(ctrl *gomock.Controller) *outbox.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				messages := []*dto.OutboxMessage{newMessage(1, 0), newMessage(2, 0), newMessage(3, 0)}
+				deps.outboxRepo.EXPECT().ClaimPending(ctx, testBatchSize, claimed).Return(messages, nil)
+				deps.sender.EXPECT().Send(ctx, gomock.Any(), "event-1").Return(nil)
+				deps.sender.EXPECT().Send(ctx, gomock.Any(), "event-2").Return(nil)
+				deps.sender.EXPECT().Send(ctx, gomock.Any(), "event-3").Return(sendErr)
+				deps.outboxRepo.EXPECT().MarkSent(ctx, 1).Return(repo.ErrInternal)
+				deps.outboxRepo.EXPECT().MarkSent(ctx, 2).Return(nil)
+				deps.outboxRepo.EXPECT().MarkFailed(ctx, 3, sendErr.Error(), nextAttemptIn(testBackoff)).Return(nil)
+				return uc
+			},
+			want:        &dto.OutboxDelivery{Sent: 1, Retried: 1},
+			wantErr:     true,
+			wantErrType: customerrors.InternalErr,
+			wantErrMsg:  "failed to mark message as sent",
+		},
+		{
+			name: "failed to mark message as sent",
+			uc: func(ctrl *gomock.Controller) *outbox.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				deps.outboxRepo.EXPECT().ClaimPending(ctx, testBatchSize, claimed).Return([]*dto.OutboxMessage{newMessage(1, 0)}, nil)
+				deps.sender.EXPECT().Send(ctx, gomock.Any(), gomock.Any()).Return(nil)
+				deps.outboxRepo.EXPECT().MarkSent(ctx, 1).Return(repo.ErrInternal)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.InternalErr,
+			wantErrMsg:  "failed to mark message as sent",
+		},
+		{
+			name: "failed to mark message as failed",
+			uc: func(ctrl *gomock.Controller) *outbox.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				deps.outboxRepo.EXPECT().ClaimPending(ctx, testBatchSize, claimed).Return([]*dto.OutboxMessage{newMessage(1, 0)}, nil)
+				deps.sender.EXPECT().Send(ctx, gomock.Any(), gomock.Any()).Return(sendErr)
+				deps.outboxRepo.EXPECT().MarkFailed(ctx, 1, gomock.Any(), gomock.Any()).Return(repo.ErrInternal)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.InternalErr,
+			wantErrMsg:  "failed to mark message as failed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := tt.uc(ctrl)
+			got, err := u.Deliver(ctx)
+			if tt.wantErr {
+				var e *customerrors.Err
+				if err == nil {
+					t.Errorf("expected error but got nil")
+					return
+				}
+				if !errors.As(err, &e) {
+					t.Errorf("expected custom error type, got %T", err)
+					return
+				}
+				if e.Type != tt.wantErrType {
+					t.Errorf("unexpected error type: got %d, want %d", e.Type, tt.wantErrType)
+				}
+				if e.Msg != tt.wantErrMsg {
+					t.Errorf("unexpected error msg: got %s, want %s", e.Msg, tt.wantErrMsg)
+				}
+				if tt.want != nil && !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("got = %v, want %v", got, tt.want)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUseCaseGetStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	now := time.Now()
+	lastErr := "smtp unavailable"
+
+	tests := []struct {
+		name        string
+		uc          func(ctrl *gomock.Controller) *outbox.UseCase
+		want        *dto.OutboxMessageStatus
+		wantErr     bool
+		wantErrType customerrors.ErrType
+		wantErrMsg  string
+	}{
+		{
+			name: "pending message has next attempt",
+			uc: func(ctrl *gomock.Controller) *outbox.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				deps.outboxRepo.EXPECT().GetByEventID(ctx, "event-1").Return(&dto.OutboxMessage{
+					ID:            1,
+					EventID:       "event-1",
+					Subject:       "subject",
+					Status:        dto.OutboxPending,
+					Attempts:      1,
+					LastError:     &lastErr,
+					NextAttemptAt: now,
+					CreatedAt:     now,
+				}, nil)
+				return uc
+			},
+			want: &dto.OutboxMessageStatus{
+				EventID:       "event-1",
+				Status:        dto.OutboxPending,
+				Attempts:      1,
+				LastError:     &lastErr,
+				NextAttemptAt: &now,
+				CreatedAt:     now,
+			},
+		},
+		{
+			name: "sent message",
+			uc: func(ctrl *gomock.Controller) *outbox.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				deps.outboxRepo.EXPECT().GetByEventID(ctx, "event-1").Return(&dto.OutboxMessage{
+					ID:            1,
+					EventID:       "event-1",
+					Status:        dto.OutboxSent,
+					Attempts:      1,
+					NextAttemptAt: now,
+					CreatedAt:     now,
+					SentAt:        &now,
+				}, nil)
+				return uc
+			},
+			want: &dto.OutboxMessageStatus{
+				EventID:   "event-1",
+				Status:    dto.OutboxSent,
+				Attempts:  1,
+				CreatedAt: now,
+				SentAt:    &now,
+			},
+		},
+		{
+			name: "message not found",
+			uc: func(ctrl *gomock.Controller) *outbox.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				deps.outboxRepo.EXPECT().GetByEventID(ctx, "event-1").Return(nil, repo.ErrNotFound)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.NotFoundErr,
+			wantErrMsg:  "email not found",
+		},
+		{
+			name: "failed to get message",
+			uc: func(ctrl *gomock.Controller) *outbox.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				deps.outboxRepo.EXPECT().GetByEventID(ctx, "event-1").Return(nil, repo.ErrInternal)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.InternalErr,
+			wantErrMsg:  "failed to get email",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := tt.uc(ctrl)
+			got, err := u.GetStatus(ctx, "event-1")
+			if tt.wantErr {
+				var e *customerrors.Err
+				if !errors.As(err, &e) {
+					t.Errorf("expected custom error, got %v", err)
+					return
+				}
+				if e.Type != tt.wantErrType {
+					t.Errorf("unexpected error type: got %d, want %d", e.Type, tt.wantErrType)
+				}
+				if e.Msg != tt.wantErrMsg {
+					t.Errorf("unexpected error msg: got %s, want %s", e.Msg, tt.wantErrMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
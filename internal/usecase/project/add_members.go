@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"slices"
+	"task-trail/internal/pkg/pubsub"
 	"task-trail/internal/repo"
 	"task-trail/internal/usecase/dto"
 )
@@ -23,6 +24,7 @@ func (u *UseCase) AddMembers(ctx context.Context, data *dto.ProjectAddMembers) e
 		return u.errHandler.InternalTrouble(err, "failed to get project members", "projectID", data.ProjectID)
 	}
 
+	var event pubsub.Event
 	f := func(ctx context.Context) error {
 		if len(newEmails) > 0 {
 			if err := u.registerNewUsers(ctx, newEmails); err != nil {
@@ -30,10 +32,23 @@ func (u *UseCase) AddMembers(ctx context.Context, data *dto.ProjectAddMembers) e
 			}
 		}
 
-		memberIDs, err := u.getNewMembersIds(ctx, data.MemberEmails)
+		members, err := u.getNewMembers(ctx, data.MemberEmails)
 		if err != nil {
 			return err
 		}
+		memberIDs := make([]int, len(members))
+		activity := make([]*dto.ActivityCreate, len(members))
+		for i, m := range members {
+			memberIDs[i] = m.ID
+			activity[i] = &dto.ActivityCreate{
+				ProjectID:  data.ProjectID,
+				ActorID:    data.OwnerID,
+				Action:     dto.ActivityMemberAdded,
+				EntityType: dto.ActivityEntityMember,
+				EntityID:   m.ID,
+				Diff:       map[string]any{"email": m.Email},
+			}
+		}
 
 		if err := u.projectRepo.AddMembers(ctx, &dto.ProjectAddMembersDB{ProjectID: data.ProjectID, MemberIDs: memberIDs}); err != nil {
 			return u.errHandler.InternalTrouble(
@@ -47,10 +62,18 @@ func (u *UseCase) AddMembers(ctx context.Context, data *dto.ProjectAddMembers) e
 		if err := u.notificationRepo.SendInvintationInProject(ctx, &dto.NotificationProjectInvite{ProjectID: project.ID, ProjectName: project.Name, Recipients: data.MemberEmails}); err != nil {
 			return u.errHandler.InternalTrouble(err, "failed to send project invitation", "projectID", project.ID)
 		}
-		return nil
+		if err := u.record(ctx, activity...); err != nil {
+			return err
+		}
+		event, err = u.publish(ctx, pubsub.ProjectMembersAdded, project.ID, map[string][]string{"memberEmails": data.MemberEmails})
+		return err
 	}
 
-	return u.txManager.DoWithTx(ctx, f)
+	if err := u.txManager.DoWithTx(ctx, f); err != nil {
+		return err
+	}
+	u.notify(ctx, event)
+	return nil
 }
 
 func (u *UseCase) GetOwned(ctx context.Context, projectID int, ownerID int) (*dto.Project, error) {
@@ -100,7 +123,7 @@ func (u *UseCase) registerNewUsers(ctx context.Context, newMembers []string) err
 	return nil
 }
 
-func (u *UseCase) getNewMembersIds(ctx context.Context, newMembers []string) ([]int, error) {
+func (u *UseCase) getNewMembers(ctx context.Context, newMembers []string) ([]*dto.UserEmailAndID, error) {
 	users, err := u.userRepo.GetIdsByEmails(ctx, newMembers)
 	if err != nil {
 		return nil, u.errHandler.InternalTrouble(err, "failed to get new members")
@@ -108,9 +131,5 @@ func (u *UseCase) getNewMembersIds(ctx context.Context, newMembers []string) ([]
 	if len(users) != len(newMembers) {
 		return nil, u.errHandler.InternalTrouble(err, "mismatch between found user IDs and new members count")
 	}
-	ids := make([]int, len(users))
-	for i, user := range users {
-		ids[i] = user.ID
-	}
-	return ids, nil
+	return users, nil
 }
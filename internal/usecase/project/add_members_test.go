@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"task-trail/internal/customerrors"
+	"task-trail/internal/pkg/pubsub"
 	"task-trail/internal/repo"
 	"task-trail/internal/usecase/dto"
 	"task-trail/internal/usecase/project"
@@ -63,6 +64,15 @@ func TestUseCase_AddMembers(t *testing.T) {
 				)
 				deps.projectRepo.EXPECT().AddMembers(args.ctx, gomock.Any()).Return(nil)
 				deps.notificationRepo.EXPECT().SendInvintationInProject(ctx, gomock.Any()).Return(nil)
+				deps.activityRepo.EXPECT().Create(ctx, gomock.Cond(func(x any) bool {
+					a, ok := x.([]*dto.ActivityCreate)
+					return ok && len(a) == 4 && a[0].Action == dto.ActivityMemberAdded && a[0].EntityID == 2 && a[0].Diff["email"] == "test1@mail.com"
+				})).Return(nil)
+				deps.webhooks.EXPECT().Publish(ctx, gomock.Cond(func(x any) bool {
+					e, ok := x.(pubsub.Event)
+					return ok && e.Type == pubsub.ProjectMembersAdded && e.ProjectID == testProject.ID
+				})).Return(nil)
+				deps.broker.EXPECT().Publish(ctx, gomock.Any()).Return(nil)
 				return uc
 			},
 			wantErr: false,
@@ -94,6 +104,15 @@ func TestUseCase_AddMembers(t *testing.T) {
 				)
 				deps.projectRepo.EXPECT().AddMembers(args.ctx, gomock.Any()).Return(nil)
 				deps.notificationRepo.EXPECT().SendInvintationInProject(ctx, gomock.Any()).Return(nil)
+				deps.activityRepo.EXPECT().Create(ctx, gomock.Cond(func(x any) bool {
+					a, ok := x.([]*dto.ActivityCreate)
+					return ok && len(a) == 4 && a[0].Action == dto.ActivityMemberAdded && a[0].EntityID == 2 && a[0].Diff["email"] == "test1@mail.com"
+				})).Return(nil)
+				deps.webhooks.EXPECT().Publish(ctx, gomock.Cond(func(x any) bool {
+					e, ok := x.(pubsub.Event)
+					return ok && e.Type == pubsub.ProjectMembersAdded && e.ProjectID == testProject.ID
+				})).Return(nil)
+				deps.broker.EXPECT().Publish(ctx, gomock.Any()).Return(nil)
 				return uc
 			},
 			wantErr: false,
@@ -266,6 +285,32 @@ func TestUseCase_AddMembers(t *testing.T) {
 			wantErrType: customerrors.InternalErr,
 			wantErrMsg:  "failed to send project invitation",
 		},
+		{
+			name: "failed to record project activity",
+			args: testArgs,
+			uc: func(ctrl *gomock.Controller, args args) *project.UseCase {
+
+				uc, deps := mockUseCase(ctrl)
+				mockTx(args.ctx, deps.txManager)
+				deps.projectRepo.EXPECT().GetOwned(args.ctx, args.data.ProjectID, args.data.OwnerID).Return(testProject, nil)
+				deps.userRepo.EXPECT().GetIdsByEmails(args.ctx, args.data.MemberEmails).Return(
+					[]*dto.UserEmailAndID{
+						{ID: 2, Email: "test1@mail.com"},
+						{ID: 3, Email: "test2@mail.com"},
+						{ID: 4, Email: "test3@mail.com"},
+						{ID: 5, Email: "test4@mail.com"},
+					},
+					nil,
+				).Times(2)
+				deps.projectRepo.EXPECT().AddMembers(args.ctx, gomock.Any()).Return(nil)
+				deps.notificationRepo.EXPECT().SendInvintationInProject(ctx, gomock.Any()).Return(nil)
+				deps.activityRepo.EXPECT().Create(ctx, gomock.Any()).Return(repo.ErrInternal)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.InternalErr,
+			wantErrMsg:  "failed to record project activity",
+		},
 
 		// failed to send invitation
 	}
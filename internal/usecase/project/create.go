@@ -3,12 +3,14 @@ package project
 import (
 	"context"
 	"errors"
+	"task-trail/internal/pkg/pubsub"
 	"task-trail/internal/repo"
 	"task-trail/internal/usecase/dto"
 )
 
 func (u *UseCase) Create(ctx context.Context, data *dto.ProjectCreate) (int, error) {
 	var id int
+	var event pubsub.Event
 	var err error
 	f := func(ctx context.Context) error {
 		id, err = u.projectRepo.Create(ctx, data)
@@ -18,10 +20,23 @@ func (u *UseCase) Create(ctx context.Context, data *dto.ProjectCreate) (int, err
 			}
 			return u.errHandler.InternalTrouble(err, "failed to create project", "ownerID", data.OwnerID)
 		}
-		return nil
+		err = u.record(ctx, &dto.ActivityCreate{
+			ProjectID:  id,
+			ActorID:    data.OwnerID,
+			Action:     dto.ActivityProjectCreated,
+			EntityType: dto.ActivityEntityProject,
+			EntityID:   id,
+			Diff:       map[string]any{"name": data.Name, "description": data.Description},
+		})
+		if err != nil {
+			return err
+		}
+		event, err = u.publish(ctx, pubsub.ProjectCreated, id, map[string]string{"name": data.Name, "description": data.Description})
+		return err
 	}
 	if err := u.txManager.DoWithTx(ctx, f); err != nil {
 		return 0, err
 	}
+	u.notify(ctx, event)
 	return id, nil
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"reflect"
 	"task-trail/internal/customerrors"
+	"task-trail/internal/pkg/pubsub"
 	"task-trail/internal/repo"
 	"task-trail/internal/usecase/dto"
 	"task-trail/internal/usecase/project"
@@ -45,11 +46,70 @@ func TestUseCase_Create(t *testing.T) {
 				uc, deps := mockUseCase(ctrl)
 				mockTx(args.ctx, deps.txManager)
 				deps.projectRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(1, nil)
+				deps.activityRepo.EXPECT().Create(gomock.Any(), gomock.Cond(func(x any) bool {
+					a, ok := x.([]*dto.ActivityCreate)
+					return ok && len(a) == 1 && a[0].Action == dto.ActivityProjectCreated && a[0].EntityID == 1 && a[0].ActorID == 1
+				})).Return(nil)
+				deps.webhooks.EXPECT().Publish(args.ctx, gomock.Cond(func(x any) bool {
+					e, ok := x.(pubsub.Event)
+					return ok && e.Type == pubsub.ProjectCreated && e.ProjectID == 1
+				})).Return(nil)
+				deps.broker.EXPECT().Publish(args.ctx, gomock.Any()).Return(nil)
 				return uc
 			},
 			want:    1,
 			wantErr: false,
 		},
+		{
+			name: "failed real-time publish is logged",
+			args: testArgs,
+			uc: func(ctrl *gomock.Controller, args args) *project.UseCase {
+
+				uc, deps := mockUseCase(ctrl)
+				mockTx(args.ctx, deps.txManager)
+				deps.projectRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(1, nil)
+				deps.activityRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+				deps.webhooks.EXPECT().Publish(args.ctx, gomock.Any()).Return(nil)
+				deps.broker.EXPECT().Publish(args.ctx, gomock.Any()).Return(repo.ErrInternal)
+				deps.logger.EXPECT().ErrorContext(args.ctx, "failed to publish project event", gomock.Any())
+				return uc
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name: "failed to create webhook deliveries",
+			args: testArgs,
+			uc: func(ctrl *gomock.Controller, args args) *project.UseCase {
+
+				uc, deps := mockUseCase(ctrl)
+				mockTx(args.ctx, deps.txManager)
+				deps.projectRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(1, nil)
+				deps.activityRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+				deps.webhooks.EXPECT().Publish(args.ctx, gomock.Any()).Return(
+					customerrors.NewErrHander().InternalTrouble(repo.ErrInternal, "failed to create webhook delivery"),
+				)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.InternalErr,
+			wantErrMsg:  "failed to create webhook delivery",
+		},
+		{
+			name: "failed to record project activity",
+			args: testArgs,
+			uc: func(ctrl *gomock.Controller, args args) *project.UseCase {
+
+				uc, deps := mockUseCase(ctrl)
+				mockTx(args.ctx, deps.txManager)
+				deps.projectRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(1, nil)
+				deps.activityRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(repo.ErrInternal)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.InternalErr,
+			wantErrMsg:  "failed to record project activity",
+		},
 		{
 			name: "owner not found",
 			args: testArgs,
@@ -2,10 +2,15 @@ package project
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"task-trail/internal/customerrors"
+	"task-trail/internal/pkg/contextmanager"
+	"task-trail/internal/pkg/logger"
+	"task-trail/internal/pkg/pubsub"
 	"task-trail/internal/repo"
 	"task-trail/internal/usecase"
+	"task-trail/internal/usecase/dto"
 )
 
 type UseCase struct {
@@ -14,7 +19,13 @@ type UseCase struct {
 	projectRepo      repo.ProjectRepository
 	userRepo         repo.UserRepository
 	notificationRepo repo.NotificationRepository
-	errHandler       customerrors.ErrorHandler
+	activityRepo     repo.ActivityRepository
+	// webhooks saves deliveries of project events in the transaction of the change
+	webhooks pubsub.Publisher
+	// broker streams project events to real-time subscribers after the change is saved
+	broker     pubsub.Publisher
+	errHandler customerrors.ErrorHandler
+	logger     logger.Logger
 }
 
 func New(
@@ -23,7 +34,11 @@ func New(
 	projectRepo repo.ProjectRepository,
 	userRepo repo.UserRepository,
 	notificationRepo repo.NotificationRepository,
+	activityRepo repo.ActivityRepository,
+	webhooks pubsub.Publisher,
+	broker pubsub.Publisher,
 	errHandler customerrors.ErrorHandler,
+	logger logger.Logger,
 ) *UseCase {
 	return &UseCase{
 
@@ -32,7 +47,11 @@ func New(
 		projectRepo:      projectRepo,
 		userRepo:         userRepo,
 		notificationRepo: notificationRepo,
+		activityRepo:     activityRepo,
+		webhooks:         webhooks,
+		broker:           broker,
 		errHandler:       errHandler,
+		logger:           logger,
 	}
 }
 
@@ -55,3 +74,38 @@ func (u *UseCase) CheckMembership(ctx context.Context, projectID int, memberID i
 	}
 	return nil
 }
+
+// publish creates webhook deliveries of the project change and returns the event for real-time subscribers.
+// It is called inside the transaction of the change, so deliveries are saved only along with the change.
+func (u *UseCase) publish(ctx context.Context, eventType string, projectID int, data any) (pubsub.Event, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return pubsub.Event{}, u.errHandler.InternalTrouble(err, "failed to encode project event", "projectID", projectID)
+	}
+	event := pubsub.Event{Type: eventType, ProjectID: projectID, Data: payload}
+	if err := u.webhooks.Publish(ctx, event); err != nil {
+		return pubsub.Event{}, err
+	}
+	return event, nil
+}
+
+// notify streams the saved change to real-time subscribers, the change is already committed,
+// so the failure is only logged.
+func (u *UseCase) notify(ctx context.Context, event pubsub.Event) {
+	if err := u.broker.Publish(ctx, event); err != nil {
+		u.logger.ErrorContext(ctx, "failed to publish project event", "projectID", event.ProjectID, "type", event.Type, "error", err.Error())
+	}
+}
+
+// record appends entries to the project activity log with id of the current request.
+// It is called inside the transaction of the change, so the log is consistent with the data.
+func (u *UseCase) record(ctx context.Context, entries ...*dto.ActivityCreate) error {
+	requestID := contextmanager.RequestIDFromContext(ctx)
+	for _, e := range entries {
+		e.RequestID = requestID
+	}
+	if err := u.activityRepo.Create(ctx, entries); err != nil {
+		return u.errHandler.InternalTrouble(err, "failed to record project activity", "projectID", entries[0].ProjectID)
+	}
+	return nil
+}
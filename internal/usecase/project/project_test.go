@@ -14,6 +14,10 @@ type testDeps struct {
 	userRepo         mocks.MockUserRepository
 	projectRepo      mocks.MockProjectRepository
 	notificationRepo mocks.MockNotificationRepository
+	activityRepo     mocks.MockActivityRepository
+	webhooks         mocks.MockPublisher
+	broker           mocks.MockPublisher
+	logger           mocks.MockLogger
 	txManager        mocks.MockTxManager
 	errHandler       customerrors.ErrorHandler
 }
@@ -25,13 +29,21 @@ func mockUseCase(ctrl *gomock.Controller) (*project.UseCase, *testDeps) {
 	errHandler := customerrors.NewErrHander()
 	mockAuhtUC := mocks.NewMockAuthentication(ctrl)
 	mockNotificationRepo := mocks.NewMockNotificationRepository(ctrl)
-	uc := project.New(txManager, mockAuhtUC, projectRepo, userRepo, mockNotificationRepo, errHandler)
+	activityRepo := mocks.NewMockActivityRepository(ctrl)
+	webhooks := mocks.NewMockPublisher(ctrl)
+	broker := mocks.NewMockPublisher(ctrl)
+	logger := mocks.NewMockLogger(ctrl)
+	uc := project.New(txManager, mockAuhtUC, projectRepo, userRepo, mockNotificationRepo, activityRepo, webhooks, broker, errHandler, logger)
 	deps := &testDeps{
 		authUC:           *mockAuhtUC,
 		txManager:        *txManager,
 		projectRepo:      *projectRepo,
 		userRepo:         *userRepo,
 		notificationRepo: *mockNotificationRepo,
+		activityRepo:     *activityRepo,
+		webhooks:         *webhooks,
+		broker:           *broker,
+		logger:           *logger,
 		errHandler:       errHandler,
 	}
 	return uc, deps
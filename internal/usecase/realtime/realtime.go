@@ -0,0 +1,61 @@
+package realtime
+
+import (
+	"context"
+	"errors"
+	"task-trail/internal/customerrors"
+	"task-trail/internal/pkg/logger"
+	"task-trail/internal/pkg/pubsub"
+	"task-trail/internal/repo"
+)
+
+type UseCase struct {
+	projectRepo repo.ProjectRepository
+	broker      pubsub.Broker
+	errHandler  customerrors.ErrorHandler
+	logger      logger.Logger
+}
+
+func New(projectRepo repo.ProjectRepository, broker pubsub.Broker, errHandler customerrors.ErrorHandler, logger logger.Logger) *UseCase {
+	return &UseCase{projectRepo: projectRepo, broker: broker, errHandler: errHandler, logger: logger}
+}
+
+// Subscribe streams events of the given projects until ctx is done, the user must be a member of every project.
+// If no projects are given, the user receives events of all projects they are a member of.
+// Membership is checked for every event, so projects joined after subscribing are included
+// and events of projects the user has been removed from are not delivered.
+func (u *UseCase) Subscribe(ctx context.Context, userID int, projectIDs []int) (<-chan pubsub.Event, error) {
+	for _, ID := range projectIDs {
+		if err := u.projectRepo.IsMember(ctx, ID, userID); err != nil {
+			if errors.Is(err, repo.ErrNotFound) {
+				return nil, u.errHandler.NotFound(err, "project not found", "projectID", ID, "memberID", userID)
+			}
+			return nil, u.errHandler.InternalTrouble(err, "failed to verify user membership", "projectID", ID, "memberID", userID)
+		}
+	}
+	events := u.broker.Subscribe(ctx, projectIDs)
+	retVal := make(chan pubsub.Event)
+	go func() {
+		defer close(retVal)
+		for e := range events {
+			if !u.isMember(ctx, e.ProjectID, userID) {
+				continue
+			}
+			select {
+			case retVal <- e:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return retVal, nil
+}
+
+// isMember reports whether the event can be delivered to the user, the event is skipped if membership can't be verified.
+func (u *UseCase) isMember(ctx context.Context, projectID int, userID int) bool {
+	err := u.projectRepo.IsMember(ctx, projectID, userID)
+	if err != nil && !errors.Is(err, repo.ErrNotFound) && ctx.Err() == nil {
+		u.logger.WarnContext(ctx, "failed to verify membership of event recipient", "error", err, "projectID", projectID, "userID", userID)
+	}
+	return err == nil
+}
@@ -0,0 +1,164 @@
+package realtime_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"task-trail/internal/customerrors"
+	"task-trail/internal/pkg/pubsub"
+	"task-trail/internal/repo"
+	"task-trail/internal/usecase/realtime"
+	"task-trail/test/mocks"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+type testDeps struct {
+	projectRepo mocks.MockProjectRepository
+	broker      mocks.MockBroker
+	logger      mocks.MockLogger
+}
+
+func MockUseCase(ctrl *gomock.Controller) (*realtime.UseCase, *testDeps) {
+	projectRepo := mocks.NewMockProjectRepository(ctrl)
+	broker := mocks.NewMockBroker(ctrl)
+	logger := mocks.NewMockLogger(ctrl)
+	uc := realtime.New(projectRepo, broker, customerrors.NewErrHander(), logger)
+	return uc, &testDeps{projectRepo: *projectRepo, broker: *broker, logger: *logger}
+}
+
+// newEvents returns closed channel with events of the given projects
+func newEvents(projectIDs ...int) <-chan pubsub.Event {
+	events := make(chan pubsub.Event, len(projectIDs))
+	for _, ID := range projectIDs {
+		events <- pubsub.Event{Type: pubsub.ProjectMembersAdded, ProjectID: ID}
+	}
+	close(events)
+	return events
+}
+
+func TestUseCaseSubscribe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		projectIDs  []int
+		uc          func(ctrl *gomock.Controller) *realtime.UseCase
+		want        []int
+		wantErr     bool
+		wantErrType customerrors.ErrType
+		wantErrMsg  string
+	}{
+		{
+			name:       "success",
+			projectIDs: []int{1, 2},
+			uc: func(ctrl *gomock.Controller) *realtime.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				deps.projectRepo.EXPECT().IsMember(ctx, 1, 1).Return(nil).Times(2)
+				deps.projectRepo.EXPECT().IsMember(ctx, 2, 1).Return(nil).Times(2)
+				deps.broker.EXPECT().Subscribe(ctx, []int{1, 2}).Return(newEvents(1, 2))
+				return uc
+			},
+			want: []int{1, 2},
+		},
+		{
+			name: "all user projects",
+			uc: func(ctrl *gomock.Controller) *realtime.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				deps.broker.EXPECT().Subscribe(ctx, nil).Return(newEvents(3, 4))
+				deps.projectRepo.EXPECT().IsMember(ctx, 3, 1).Return(nil)
+				deps.projectRepo.EXPECT().IsMember(ctx, 4, 1).Return(repo.ErrNotFound)
+				return uc
+			},
+			want: []int{3},
+		},
+		{
+			name:       "events are skipped after the user is removed from the project",
+			projectIDs: []int{1},
+			uc: func(ctrl *gomock.Controller) *realtime.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				deps.projectRepo.EXPECT().IsMember(ctx, 1, 1).Return(nil)
+				deps.broker.EXPECT().Subscribe(ctx, []int{1}).Return(newEvents(1, 1))
+				deps.projectRepo.EXPECT().IsMember(ctx, 1, 1).Return(nil)
+				deps.projectRepo.EXPECT().IsMember(ctx, 1, 1).Return(repo.ErrNotFound)
+				return uc
+			},
+			want: []int{1},
+		},
+		{
+			name: "event is skipped if membership can't be verified",
+			uc: func(ctrl *gomock.Controller) *realtime.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				deps.broker.EXPECT().Subscribe(ctx, nil).Return(newEvents(1, 2))
+				deps.projectRepo.EXPECT().IsMember(ctx, 1, 1).Return(repo.ErrInternal)
+				deps.logger.EXPECT().WarnContext(ctx, "failed to verify membership of event recipient", gomock.Any())
+				deps.projectRepo.EXPECT().IsMember(ctx, 2, 1).Return(nil)
+				return uc
+			},
+			want: []int{2},
+		},
+		{
+			name:       "user is not a project member",
+			projectIDs: []int{1, 2},
+			uc: func(ctrl *gomock.Controller) *realtime.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				deps.projectRepo.EXPECT().IsMember(ctx, 1, 1).Return(nil)
+				deps.projectRepo.EXPECT().IsMember(ctx, 2, 1).Return(repo.ErrNotFound)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.NotFoundErr,
+			wantErrMsg:  "project not found",
+		},
+		{
+			name:       "failed to verify membership",
+			projectIDs: []int{1},
+			uc: func(ctrl *gomock.Controller) *realtime.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				deps.projectRepo.EXPECT().IsMember(ctx, 1, 1).Return(repo.ErrInternal)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.InternalErr,
+			wantErrMsg:  "failed to verify user membership",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := tt.uc(ctrl)
+			got, err := u.Subscribe(ctx, 1, tt.projectIDs)
+			if tt.wantErr {
+				var e *customerrors.Err
+				if err == nil {
+					t.Errorf("expected error but got nil")
+					return
+				}
+				if !errors.As(err, &e) {
+					t.Errorf("expected custom error type, got %T", err)
+					return
+				}
+				if e.Type != tt.wantErrType {
+					t.Errorf("unexpected error type: got %d, want %d", e.Type, tt.wantErrType)
+				}
+				if e.Msg != tt.wantErrMsg {
+					t.Errorf("unexpected error msg: got %s, want %s", e.Msg, tt.wantErrMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			var projectIDs []int
+			for e := range got {
+				projectIDs = append(projectIDs, e.ProjectID)
+			}
+			if !reflect.DeepEqual(projectIDs, tt.want) {
+				t.Errorf("got events of projects %v, want %v", projectIDs, tt.want)
+			}
+		})
+	}
+}
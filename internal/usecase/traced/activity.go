@@ -0,0 +1,22 @@
+package traced
+
+import (
+	"context"
+	"task-trail/internal/usecase"
+	"task-trail/internal/usecase/dto"
+)
+
+type activity struct {
+	uc usecase.Activity
+}
+
+func NewActivity(uc usecase.Activity) usecase.Activity {
+	return &activity{uc: uc}
+}
+
+func (a *activity) GetList(ctx context.Context, data *dto.ActivityList) (*dto.ActivityPage, error) {
+	ctx, span := tracer.Start(ctx, "Activity.GetList")
+	res, err := a.uc.GetList(ctx, data)
+	end(span, err)
+	return res, err
+}
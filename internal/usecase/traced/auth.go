@@ -0,0 +1,113 @@
+package traced
+
+import (
+	"context"
+	"task-trail/internal/usecase"
+	"task-trail/internal/usecase/dto"
+)
+
+type authentication struct {
+	uc usecase.Authentication
+}
+
+func NewAuthentication(uc usecase.Authentication) usecase.Authentication {
+	return &authentication{uc: uc}
+}
+
+func (a *authentication) Login(ctx context.Context, data *dto.Credentials) (*dto.LoginRes, error) {
+	ctx, span := tracer.Start(ctx, "Authentication.Login")
+	res, err := a.uc.Login(ctx, data)
+	end(span, err)
+	return res, err
+}
+
+func (a *authentication) Register(ctx context.Context, data *dto.Credentials) error {
+	ctx, span := tracer.Start(ctx, "Authentication.Register")
+	err := a.uc.Register(ctx, data)
+	end(span, err)
+	return err
+}
+
+func (a *authentication) AutoRegister(ctx context.Context, email string) error {
+	ctx, span := tracer.Start(ctx, "Authentication.AutoRegister")
+	err := a.uc.AutoRegister(ctx, email)
+	end(span, err)
+	return err
+}
+
+func (a *authentication) Activate(ctx context.Context, data *dto.Activation) error {
+	ctx, span := tracer.Start(ctx, "Authentication.Activate")
+	err := a.uc.Activate(ctx, data)
+	end(span, err)
+	return err
+}
+
+func (a *authentication) Logout(ctx context.Context, refreshToken string) error {
+	ctx, span := tracer.Start(ctx, "Authentication.Logout")
+	err := a.uc.Logout(ctx, refreshToken)
+	end(span, err)
+	return err
+}
+
+func (a *authentication) Refresh(ctx context.Context, refreshToken string) (*dto.RefreshRes, error) {
+	ctx, span := tracer.Start(ctx, "Authentication.Refresh")
+	res, err := a.uc.Refresh(ctx, refreshToken)
+	end(span, err)
+	return res, err
+}
+
+func (a *authentication) Verify(ctx context.Context, tokenID string) error {
+	ctx, span := tracer.Start(ctx, "Authentication.Verify")
+	err := a.uc.Verify(ctx, tokenID)
+	end(span, err)
+	return err
+}
+
+func (a *authentication) ResendVerificationEmail(ctx context.Context, email string) error {
+	ctx, span := tracer.Start(ctx, "Authentication.ResendVerificationEmail")
+	err := a.uc.ResendVerificationEmail(ctx, email)
+	end(span, err)
+	return err
+}
+
+func (a *authentication) SendPasswordResetEmail(ctx context.Context, email string) error {
+	ctx, span := tracer.Start(ctx, "Authentication.SendPasswordResetEmail")
+	err := a.uc.SendPasswordResetEmail(ctx, email)
+	end(span, err)
+	return err
+}
+
+func (a *authentication) ResetPassword(ctx context.Context, data *dto.PasswordReset) error {
+	ctx, span := tracer.Start(ctx, "Authentication.ResetPassword")
+	err := a.uc.ResetPassword(ctx, data)
+	end(span, err)
+	return err
+}
+
+func (a *authentication) ChangePassword(ctx context.Context, data *dto.PasswordChange) error {
+	ctx, span := tracer.Start(ctx, "Authentication.ChangePassword")
+	err := a.uc.ChangePassword(ctx, data)
+	end(span, err)
+	return err
+}
+
+func (a *authentication) RequestEmailChange(ctx context.Context, data *dto.EmailChange) error {
+	ctx, span := tracer.Start(ctx, "Authentication.RequestEmailChange")
+	err := a.uc.RequestEmailChange(ctx, data)
+	end(span, err)
+	return err
+}
+
+func (a *authentication) ConfirmEmailChange(ctx context.Context, tokenID string) error {
+	ctx, span := tracer.Start(ctx, "Authentication.ConfirmEmailChange")
+	err := a.uc.ConfirmEmailChange(ctx, tokenID)
+	end(span, err)
+	return err
+}
+
+func (a *authentication) CancelEmailChange(ctx context.Context, tokenID string) error {
+	ctx, span := tracer.Start(ctx, "Authentication.CancelEmailChange")
+	err := a.uc.CancelEmailChange(ctx, tokenID)
+	end(span, err)
+	return err
+}
@@ -0,0 +1,22 @@
+package traced
+
+import (
+	"context"
+	"task-trail/internal/usecase"
+	"task-trail/internal/usecase/dto"
+)
+
+type digest struct {
+	uc usecase.Digest
+}
+
+func NewDigest(uc usecase.Digest) usecase.Digest {
+	return &digest{uc: uc}
+}
+
+func (d *digest) Send(ctx context.Context, frequency dto.DigestFrequency) (*dto.DigestDelivery, error) {
+	ctx, span := tracer.Start(ctx, "Digest.Send")
+	res, err := d.uc.Send(ctx, frequency)
+	end(span, err)
+	return res, err
+}
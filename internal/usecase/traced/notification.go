@@ -0,0 +1,50 @@
+package traced
+
+import (
+	"context"
+	"task-trail/internal/usecase"
+	"task-trail/internal/usecase/dto"
+)
+
+type notification struct {
+	uc usecase.Notification
+}
+
+func NewNotification(uc usecase.Notification) usecase.Notification {
+	return &notification{uc: uc}
+}
+
+func (n *notification) GetList(ctx context.Context, data *dto.NotificationList) (*dto.NotificationPage, error) {
+	ctx, span := tracer.Start(ctx, "Notification.GetList")
+	res, err := n.uc.GetList(ctx, data)
+	end(span, err)
+	return res, err
+}
+
+func (n *notification) MarkRead(ctx context.Context, userID int, ID int) error {
+	ctx, span := tracer.Start(ctx, "Notification.MarkRead")
+	err := n.uc.MarkRead(ctx, userID, ID)
+	end(span, err)
+	return err
+}
+
+func (n *notification) MarkAllRead(ctx context.Context, userID int) (int, error) {
+	ctx, span := tracer.Start(ctx, "Notification.MarkAllRead")
+	res, err := n.uc.MarkAllRead(ctx, userID)
+	end(span, err)
+	return res, err
+}
+
+func (n *notification) GetPreferences(ctx context.Context, userID int) (*dto.NotificationPreferences, error) {
+	ctx, span := tracer.Start(ctx, "Notification.GetPreferences")
+	res, err := n.uc.GetPreferences(ctx, userID)
+	end(span, err)
+	return res, err
+}
+
+func (n *notification) UpdatePreferences(ctx context.Context, data *dto.NotificationPreferences) (*dto.NotificationPreferences, error) {
+	ctx, span := tracer.Start(ctx, "Notification.UpdatePreferences")
+	res, err := n.uc.UpdatePreferences(ctx, data)
+	end(span, err)
+	return res, err
+}
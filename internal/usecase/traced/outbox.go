@@ -0,0 +1,29 @@
+package traced
+
+import (
+	"context"
+	"task-trail/internal/usecase"
+	"task-trail/internal/usecase/dto"
+)
+
+type outbox struct {
+	uc usecase.Outbox
+}
+
+func NewOutbox(uc usecase.Outbox) usecase.Outbox {
+	return &outbox{uc: uc}
+}
+
+func (o *outbox) Deliver(ctx context.Context) (*dto.OutboxDelivery, error) {
+	ctx, span := tracer.Start(ctx, "Outbox.Deliver")
+	res, err := o.uc.Deliver(ctx)
+	end(span, err)
+	return res, err
+}
+
+func (o *outbox) GetStatus(ctx context.Context, eventID string) (*dto.OutboxMessageStatus, error) {
+	ctx, span := tracer.Start(ctx, "Outbox.GetStatus")
+	res, err := o.uc.GetStatus(ctx, eventID)
+	end(span, err)
+	return res, err
+}
@@ -0,0 +1,50 @@
+package traced
+
+import (
+	"context"
+	"task-trail/internal/usecase"
+	"task-trail/internal/usecase/dto"
+)
+
+type project struct {
+	uc usecase.Project
+}
+
+func NewProject(uc usecase.Project) usecase.Project {
+	return &project{uc: uc}
+}
+
+func (p *project) Create(ctx context.Context, data *dto.ProjectCreate) (int, error) {
+	ctx, span := tracer.Start(ctx, "Project.Create")
+	res, err := p.uc.Create(ctx, data)
+	end(span, err)
+	return res, err
+}
+
+func (p *project) GetList(ctx context.Context, data *dto.ProjectList) ([]*dto.ProjectRes, error) {
+	ctx, span := tracer.Start(ctx, "Project.GetList")
+	res, err := p.uc.GetList(ctx, data)
+	end(span, err)
+	return res, err
+}
+
+func (p *project) GetByID(ctx context.Context, projectID int, memberID int) (*dto.ProjectRes, error) {
+	ctx, span := tracer.Start(ctx, "Project.GetByID")
+	res, err := p.uc.GetByID(ctx, projectID, memberID)
+	end(span, err)
+	return res, err
+}
+
+func (p *project) AddMembers(ctx context.Context, data *dto.ProjectAddMembers) error {
+	ctx, span := tracer.Start(ctx, "Project.AddMembers")
+	err := p.uc.AddMembers(ctx, data)
+	end(span, err)
+	return err
+}
+
+func (p *project) GetCandidates(ctx context.Context, ownerID int, projectID int) ([]*dto.UserSimple, error) {
+	ctx, span := tracer.Start(ctx, "Project.GetCandidates")
+	res, err := p.uc.GetCandidates(ctx, ownerID, projectID)
+	end(span, err)
+	return res, err
+}
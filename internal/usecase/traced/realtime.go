@@ -0,0 +1,22 @@
+package traced
+
+import (
+	"context"
+	"task-trail/internal/pkg/pubsub"
+	"task-trail/internal/usecase"
+)
+
+type realtime struct {
+	uc usecase.Realtime
+}
+
+func NewRealtime(uc usecase.Realtime) usecase.Realtime {
+	return &realtime{uc: uc}
+}
+
+func (r *realtime) Subscribe(ctx context.Context, userID int, projectIDs []int) (<-chan pubsub.Event, error) {
+	ctx, span := tracer.Start(ctx, "Realtime.Subscribe")
+	res, err := r.uc.Subscribe(ctx, userID, projectIDs)
+	end(span, err)
+	return res, err
+}
@@ -0,0 +1,25 @@
+// Package traced decorates use cases with spans, so every call is a child of the request or task span
+// and the parent of transaction and query spans.
+package traced
+
+import (
+	"errors"
+	"task-trail/internal/customerrors"
+	"task-trail/internal/pkg/tracing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("task-trail/internal/usecase")
+
+// end ends the span of the use case, only internal errors mark it as failed,
+// the other ones are expected outcomes such as validation errors or missing entities.
+func end(span trace.Span, err error) {
+	var e *customerrors.Err
+	if errors.As(err, &e) && e.Type != customerrors.InternalErr {
+		span.End()
+		return
+	}
+	tracing.End(span, err)
+}
@@ -0,0 +1,65 @@
+package traced
+
+import (
+	"context"
+	"task-trail/internal/usecase"
+	"task-trail/internal/usecase/dto"
+	"time"
+)
+
+type user struct {
+	uc usecase.User
+}
+
+func NewUser(uc usecase.User) usecase.User {
+	return &user{uc: uc}
+}
+
+func (u *user) UpdateAvatar(ctx context.Context, data *dto.FileUpload) (*dto.UserAvatar, error) {
+	ctx, span := tracer.Start(ctx, "User.UpdateAvatar")
+	res, err := u.uc.UpdateAvatar(ctx, data)
+	end(span, err)
+	return res, err
+}
+
+func (u *user) UpdateByID(ctx context.Context, data *dto.UserUpdate) (*dto.CurrentUser, error) {
+	ctx, span := tracer.Start(ctx, "User.UpdateByID")
+	res, err := u.uc.UpdateByID(ctx, data)
+	end(span, err)
+	return res, err
+}
+
+func (u *user) GetCurrentByID(ctx context.Context, ID int) (*dto.CurrentUser, error) {
+	ctx, span := tracer.Start(ctx, "User.GetCurrentByID")
+	res, err := u.uc.GetCurrentByID(ctx, ID)
+	end(span, err)
+	return res, err
+}
+
+func (u *user) Export(ctx context.Context, ID int) (*dto.UserExport, error) {
+	ctx, span := tracer.Start(ctx, "User.Export")
+	res, err := u.uc.Export(ctx, ID)
+	end(span, err)
+	return res, err
+}
+
+func (u *user) RequestDeletion(ctx context.Context, data *dto.AccountDeletion) (*time.Time, error) {
+	ctx, span := tracer.Start(ctx, "User.RequestDeletion")
+	res, err := u.uc.RequestDeletion(ctx, data)
+	end(span, err)
+	return res, err
+}
+
+func (u *user) CancelDeletion(ctx context.Context, ID int) error {
+	ctx, span := tracer.Start(ctx, "User.CancelDeletion")
+	err := u.uc.CancelDeletion(ctx, ID)
+	end(span, err)
+	return err
+}
+
+func (u *user) DeleteScheduled(ctx context.Context) (int, error) {
+	ctx, span := tracer.Start(ctx, "User.DeleteScheduled")
+	res, err := u.uc.DeleteScheduled(ctx)
+	end(span, err)
+	return res, err
+}
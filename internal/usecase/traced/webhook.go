@@ -0,0 +1,65 @@
+package traced
+
+import (
+	"context"
+	"task-trail/internal/pkg/pubsub"
+	"task-trail/internal/usecase"
+	"task-trail/internal/usecase/dto"
+)
+
+type webhook struct {
+	uc usecase.Webhook
+}
+
+func NewWebhook(uc usecase.Webhook) usecase.Webhook {
+	return &webhook{uc: uc}
+}
+
+func (w *webhook) Publish(ctx context.Context, event pubsub.Event) error {
+	ctx, span := tracer.Start(ctx, "Webhook.Publish")
+	err := w.uc.Publish(ctx, event)
+	end(span, err)
+	return err
+}
+
+func (w *webhook) Create(ctx context.Context, data *dto.WebhookCreate) (*dto.Webhook, error) {
+	ctx, span := tracer.Start(ctx, "Webhook.Create")
+	res, err := w.uc.Create(ctx, data)
+	end(span, err)
+	return res, err
+}
+
+func (w *webhook) GetList(ctx context.Context, projectID int, ownerID int) ([]*dto.Webhook, error) {
+	ctx, span := tracer.Start(ctx, "Webhook.GetList")
+	res, err := w.uc.GetList(ctx, projectID, ownerID)
+	end(span, err)
+	return res, err
+}
+
+func (w *webhook) Delete(ctx context.Context, projectID int, ownerID int, ID int) error {
+	ctx, span := tracer.Start(ctx, "Webhook.Delete")
+	err := w.uc.Delete(ctx, projectID, ownerID, ID)
+	end(span, err)
+	return err
+}
+
+func (w *webhook) GetDeliveries(ctx context.Context, data *dto.WebhookDeliveryList) ([]*dto.WebhookDelivery, error) {
+	ctx, span := tracer.Start(ctx, "Webhook.GetDeliveries")
+	res, err := w.uc.GetDeliveries(ctx, data)
+	end(span, err)
+	return res, err
+}
+
+func (w *webhook) SendTest(ctx context.Context, projectID int, ownerID int, ID int) (*dto.WebhookDelivery, error) {
+	ctx, span := tracer.Start(ctx, "Webhook.SendTest")
+	res, err := w.uc.SendTest(ctx, projectID, ownerID, ID)
+	end(span, err)
+	return res, err
+}
+
+func (w *webhook) Deliver(ctx context.Context) (*dto.WebhookDeliveryStats, error) {
+	ctx, span := tracer.Start(ctx, "Webhook.Deliver")
+	res, err := w.uc.Deliver(ctx)
+	end(span, err)
+	return res, err
+}
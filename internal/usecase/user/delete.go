@@ -0,0 +1,100 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"task-trail/internal/repo"
+	"task-trail/internal/usecase/dto"
+	"time"
+)
+
+// RequestDeletion schedules account deletion after the grace period.
+// Returns the date when the account will be deleted.
+func (u *UseCase) RequestDeletion(ctx context.Context, data *dto.AccountDeletion) (*time.Time, error) {
+	user, err := u.getByID(ctx, data.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user.DeletionRequestedAt != nil {
+		return nil, u.errHandler.BadRequest(nil, "account deletion already requested", "userID", data.UserID)
+	}
+	if err := u.pwdService.ComparePassword(data.Password, user.PasswordHash); err != nil {
+		return nil, u.errHandler.BadRequest(err, "incorrect password", "userID", data.UserID)
+	}
+	now := time.Now()
+	if err := u.setDeletionRequestedAt(ctx, data.UserID, &now); err != nil {
+		return nil, err
+	}
+	scheduledAt := u.deletionDate(now)
+	return &scheduledAt, nil
+}
+
+// CancelDeletion cancels scheduled account deletion while the grace period is not over.
+func (u *UseCase) CancelDeletion(ctx context.Context, ID int) error {
+	user, err := u.getByID(ctx, ID)
+	if err != nil {
+		return err
+	}
+	if user.DeletionRequestedAt == nil {
+		return u.errHandler.BadRequest(nil, "account deletion is not requested", "userID", ID)
+	}
+	return u.setDeletionRequestedAt(ctx, ID, nil)
+}
+
+// DeleteScheduled deletes all accounts with expired grace period.
+// Owned projects are transferred to other members or deleted, tokens are purged
+// and personal data is anonymized. Failed account is kept for the next run and doesn't stop deletion of the others.
+// Returns the number of deleted accounts along with the errors of failed ones.
+func (u *UseCase) DeleteScheduled(ctx context.Context) (int, error) {
+	ids, err := u.userRepo.GetScheduledForDeletion(ctx, u.deletionGraceDays)
+	if err != nil {
+		return 0, u.errHandler.InternalTrouble(err, "failed to get users scheduled for deletion")
+	}
+	deleted := 0
+	var errs []error
+	for _, id := range ids {
+		if err := u.txManager.DoWithTx(ctx, func(ctx context.Context) error { return u.delete(ctx, id) }); err != nil {
+			u.logger.ErrorContext(ctx, "failed to delete user", "error", err, "userID", id)
+			errs = append(errs, err)
+			continue
+		}
+		deleted++
+	}
+	return deleted, errors.Join(errs...)
+}
+
+func (u *UseCase) delete(ctx context.Context, ID int) error {
+	if _, _, err := u.projectRepo.TransferOwnership(ctx, ID); err != nil {
+		return u.errHandler.InternalTrouble(err, "failed to transfer owned projects", "userID", ID)
+	}
+	if err := u.projectRepo.RemoveMemberFromAll(ctx, ID); err != nil {
+		return u.errHandler.InternalTrouble(err, "failed to remove user from projects", "userID", ID)
+	}
+	if _, err := u.rtRepo.DeleteAllUsersTokens(ctx, ID); err != nil {
+		return u.errHandler.InternalTrouble(err, "failed to delete refresh tokens", "userID", ID)
+	}
+	if _, err := u.etRepo.DeleteAllUsersTokens(ctx, ID); err != nil {
+		return u.errHandler.InternalTrouble(err, "failed to delete email tokens", "userID", ID)
+	}
+	if _, err := u.fileRepo.SoftDeleteByOwner(ctx, ID); err != nil {
+		return u.errHandler.InternalTrouble(err, "failed to delete user files", "userID", ID)
+	}
+	if err := u.userRepo.Anonymize(ctx, ID); err != nil {
+		return u.errHandler.InternalTrouble(err, "failed to anonymize user", "userID", ID)
+	}
+	return nil
+}
+
+func (u *UseCase) setDeletionRequestedAt(ctx context.Context, ID int, requestedAt *time.Time) error {
+	if err := u.userRepo.SetDeletionRequestedAt(ctx, ID, requestedAt); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return u.errHandler.BadRequest(err, "user not found", "userID", ID)
+		}
+		return u.errHandler.InternalTrouble(err, "failed to update user", "userID", ID)
+	}
+	return nil
+}
+
+func (u *UseCase) deletionDate(requestedAt time.Time) time.Time {
+	return requestedAt.AddDate(0, 0, u.deletionGraceDays)
+}
@@ -0,0 +1,283 @@
+package user_test
+
+import (
+	"context"
+	"errors"
+	"task-trail/internal/customerrors"
+	"task-trail/internal/repo"
+	"task-trail/internal/usecase/dto"
+	"task-trail/internal/usecase/user"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+// around matches time close to the given one.
+func around(want time.Time) gomock.Matcher {
+	return gomock.Cond(func(x any) bool {
+		got, ok := x.(*time.Time)
+		return ok && got != nil && got.Sub(want).Abs() < time.Second
+	})
+}
+
+func TestUseCaseRequestDeletion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	data := &dto.AccountDeletion{UserID: 1, Password: "password"}
+	requestedAt := time.Now().Add(-time.Hour)
+	tests := []struct {
+		name        string
+		uc          func(ctrl *gomock.Controller) *user.UseCase
+		wantErr     bool
+		wantErrType customerrors.ErrType
+		wantErrMsg  string
+	}{
+		{
+			name: "deletion scheduled after grace period",
+			uc: func(ctrl *gomock.Controller) *user.UseCase {
+				uc, deps := mockUseCase(ctrl)
+				deps.userRepo.EXPECT().GetByID(ctx, 1).Return(&dto.User{ID: 1, PasswordHash: "hash"}, nil)
+				deps.pwdService.EXPECT().ComparePassword("password", "hash").Return(nil)
+				deps.userRepo.EXPECT().SetDeletionRequestedAt(ctx, 1, around(time.Now())).Return(nil)
+				return uc
+			},
+		},
+		{
+			name: "deletion already requested",
+			uc: func(ctrl *gomock.Controller) *user.UseCase {
+				uc, deps := mockUseCase(ctrl)
+				deps.userRepo.EXPECT().GetByID(ctx, 1).Return(&dto.User{ID: 1, DeletionRequestedAt: &requestedAt}, nil)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.ValidationErr,
+			wantErrMsg:  "account deletion already requested",
+		},
+		{
+			name: "incorrect password",
+			uc: func(ctrl *gomock.Controller) *user.UseCase {
+				uc, deps := mockUseCase(ctrl)
+				deps.userRepo.EXPECT().GetByID(ctx, 1).Return(&dto.User{ID: 1, PasswordHash: "hash"}, nil)
+				deps.pwdService.EXPECT().ComparePassword("password", "hash").Return(errors.New("mismatch"))
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.ValidationErr,
+			wantErrMsg:  "incorrect password",
+		},
+		{
+			name: "user not found",
+			uc: func(ctrl *gomock.Controller) *user.UseCase {
+				uc, deps := mockUseCase(ctrl)
+				deps.userRepo.EXPECT().GetByID(ctx, 1).Return(nil, repo.ErrNotFound)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.ValidationErr,
+			wantErrMsg:  "user not found",
+		},
+		{
+			name: "failed to update user",
+			uc: func(ctrl *gomock.Controller) *user.UseCase {
+				uc, deps := mockUseCase(ctrl)
+				deps.userRepo.EXPECT().GetByID(ctx, 1).Return(&dto.User{ID: 1, PasswordHash: "hash"}, nil)
+				deps.pwdService.EXPECT().ComparePassword(gomock.Any(), gomock.Any()).Return(nil)
+				deps.userRepo.EXPECT().SetDeletionRequestedAt(ctx, 1, gomock.Any()).Return(repo.ErrInternal)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.InternalErr,
+			wantErrMsg:  "failed to update user",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := tt.uc(ctrl)
+			got, err := u.RequestDeletion(ctx, data)
+			checkErr(t, err, tt.wantErr, tt.wantErrType, tt.wantErrMsg)
+			if tt.wantErr {
+				if got != nil {
+					t.Errorf("got = %v, want nil", got)
+				}
+				return
+			}
+			want := time.Now().AddDate(0, 0, testGraceDays)
+			if got == nil || got.Sub(want).Abs() > time.Second {
+				t.Errorf("got = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestUseCaseCancelDeletion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	requestedAt := time.Now().Add(-time.Hour)
+	tests := []struct {
+		name        string
+		uc          func(ctrl *gomock.Controller) *user.UseCase
+		wantErr     bool
+		wantErrType customerrors.ErrType
+		wantErrMsg  string
+	}{
+		{
+			name: "success",
+			uc: func(ctrl *gomock.Controller) *user.UseCase {
+				uc, deps := mockUseCase(ctrl)
+				deps.userRepo.EXPECT().GetByID(ctx, 1).Return(&dto.User{ID: 1, DeletionRequestedAt: &requestedAt}, nil)
+				deps.userRepo.EXPECT().SetDeletionRequestedAt(ctx, 1, gomock.Nil()).Return(nil)
+				return uc
+			},
+		},
+		{
+			name: "deletion is not requested",
+			uc: func(ctrl *gomock.Controller) *user.UseCase {
+				uc, deps := mockUseCase(ctrl)
+				deps.userRepo.EXPECT().GetByID(ctx, 1).Return(&dto.User{ID: 1}, nil)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.ValidationErr,
+			wantErrMsg:  "account deletion is not requested",
+		},
+		{
+			name: "failed to get user",
+			uc: func(ctrl *gomock.Controller) *user.UseCase {
+				uc, deps := mockUseCase(ctrl)
+				deps.userRepo.EXPECT().GetByID(ctx, 1).Return(nil, repo.ErrInternal)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.InternalErr,
+			wantErrMsg:  "failed to get user",
+		},
+		{
+			name: "user deleted meanwhile",
+			uc: func(ctrl *gomock.Controller) *user.UseCase {
+				uc, deps := mockUseCase(ctrl)
+				deps.userRepo.EXPECT().GetByID(ctx, 1).Return(&dto.User{ID: 1, DeletionRequestedAt: &requestedAt}, nil)
+				deps.userRepo.EXPECT().SetDeletionRequestedAt(ctx, 1, gomock.Nil()).Return(repo.ErrNotFound)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.ValidationErr,
+			wantErrMsg:  "user not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := tt.uc(ctrl)
+			err := u.CancelDeletion(ctx, 1)
+			checkErr(t, err, tt.wantErr, tt.wantErrType, tt.wantErrMsg)
+		})
+	}
+}
+
+func TestUseCaseDeleteScheduled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	// expectDelete expects the whole deletion of the user in order, projects are handed over before anything is removed
+	expectDelete := func(deps *testDeps, ID int) {
+		mockTx(ctx, deps.txManager)
+		gomock.InOrder(
+			deps.projectRepo.EXPECT().TransferOwnership(ctx, ID).Return(1, 1, nil),
+			deps.projectRepo.EXPECT().RemoveMemberFromAll(ctx, ID).Return(nil),
+			deps.rtRepo.EXPECT().DeleteAllUsersTokens(ctx, ID).Return(2, nil),
+			deps.etRepo.EXPECT().DeleteAllUsersTokens(ctx, ID).Return(1, nil),
+			deps.fileRepo.EXPECT().SoftDeleteByOwner(ctx, ID).Return(3, nil),
+			deps.userRepo.EXPECT().Anonymize(ctx, ID).Return(nil),
+		)
+	}
+	tests := []struct {
+		name        string
+		uc          func(ctrl *gomock.Controller) *user.UseCase
+		want        int
+		wantErr     bool
+		wantErrType customerrors.ErrType
+		wantErrMsg  string
+	}{
+		{
+			name: "accounts with expired grace period are deleted",
+			uc: func(ctrl *gomock.Controller) *user.UseCase {
+				uc, deps := mockUseCase(ctrl)
+				deps.userRepo.EXPECT().GetScheduledForDeletion(ctx, testGraceDays).Return([]int{1, 2}, nil)
+				expectDelete(deps, 1)
+				expectDelete(deps, 2)
+				return uc
+			},
+			want: 2,
+		},
+		{
+			name: "nothing scheduled",
+			uc: func(ctrl *gomock.Controller) *user.UseCase {
+				uc, deps := mockUseCase(ctrl)
+				deps.userRepo.EXPECT().GetScheduledForDeletion(ctx, testGraceDays).Return(nil, nil)
+				return uc
+			},
+		},
+		{
+			name: "failed to get scheduled users",
+			uc: func(ctrl *gomock.Controller) *user.UseCase {
+				uc, deps := mockUseCase(ctrl)
+				deps.userRepo.EXPECT().GetScheduledForDeletion(ctx, testGraceDays).Return(nil, repo.ErrInternal)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.InternalErr,
+			wantErrMsg:  "failed to get users scheduled for deletion",
+		},
+		{
+			name: "failed user doesn't stop deletion of the others",
+			uc: func(ctrl *gomock.Controller) *user.UseCase {
+				uc, deps := mockUseCase(ctrl)
+				deps.userRepo.EXPECT().GetScheduledForDeletion(ctx, testGraceDays).Return([]int{1, 2, 3}, nil)
+				expectDelete(deps, 1)
+				mockTx(ctx, deps.txManager)
+				deps.projectRepo.EXPECT().TransferOwnership(ctx, 2).Return(0, 0, repo.ErrInternal)
+				deps.logger.EXPECT().ErrorContext(ctx, "failed to delete user", "error", gomock.Any(), "userID", 2)
+				expectDelete(deps, 3)
+				return uc
+			},
+			want:        2,
+			wantErr:     true,
+			wantErrType: customerrors.InternalErr,
+			wantErrMsg:  "failed to transfer owned projects",
+		},
+		{
+			name: "failed to anonymize user",
+			uc: func(ctrl *gomock.Controller) *user.UseCase {
+				uc, deps := mockUseCase(ctrl)
+				deps.userRepo.EXPECT().GetScheduledForDeletion(ctx, testGraceDays).Return([]int{1}, nil)
+				mockTx(ctx, deps.txManager)
+				deps.projectRepo.EXPECT().TransferOwnership(ctx, 1).Return(0, 0, nil)
+				deps.projectRepo.EXPECT().RemoveMemberFromAll(ctx, 1).Return(nil)
+				deps.rtRepo.EXPECT().DeleteAllUsersTokens(ctx, 1).Return(0, nil)
+				deps.etRepo.EXPECT().DeleteAllUsersTokens(ctx, 1).Return(0, nil)
+				deps.fileRepo.EXPECT().SoftDeleteByOwner(ctx, 1).Return(0, nil)
+				deps.userRepo.EXPECT().Anonymize(ctx, 1).Return(repo.ErrInternal)
+				deps.logger.EXPECT().ErrorContext(ctx, "failed to delete user", "error", gomock.Any(), "userID", 1)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.InternalErr,
+			wantErrMsg:  "failed to anonymize user",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := tt.uc(ctrl)
+			got, err := u.DeleteScheduled(ctx)
+			checkErr(t, err, tt.wantErr, tt.wantErrType, tt.wantErrMsg)
+			if got != tt.want {
+				t.Errorf("got = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
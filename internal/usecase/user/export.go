@@ -0,0 +1,41 @@
+package user
+
+import (
+	"context"
+	"task-trail/internal/usecase/dto"
+	"time"
+)
+
+// Export collects all personal data related to the user: profile, projects, authored tasks and files metadata.
+func (u *UseCase) Export(ctx context.Context, ID int) (*dto.UserExport, error) {
+	user, err := u.getByID(ctx, ID)
+	if err != nil {
+		return nil, err
+	}
+	current := u.toCurrentUser(user)
+	retVal := &dto.UserExport{
+		Profile: &dto.UserExportProfile{
+			ID:         user.ID,
+			Email:      user.Email,
+			Username:   user.Username,
+			AvatarURL:  current.AvatarURL,
+			VerifiedAt: user.VerifiedAt,
+			CreatedAt:  user.CreatedAt,
+		},
+		CreatedAt: time.Now(),
+	}
+
+	retVal.Projects, err = u.projectRepo.GetList(ctx, &dto.ProjectList{MemberID: ID})
+	if err != nil {
+		return nil, u.errHandler.InternalTrouble(err, "failed to get user projects", "userID", ID)
+	}
+	retVal.Tasks, err = u.taskRepo.GetByAuthor(ctx, ID)
+	if err != nil {
+		return nil, u.errHandler.InternalTrouble(err, "failed to get user tasks", "userID", ID)
+	}
+	retVal.Files, err = u.fileRepo.GetByOwner(ctx, ID)
+	if err != nil {
+		return nil, u.errHandler.InternalTrouble(err, "failed to get user files", "userID", ID)
+	}
+	return retVal, nil
+}
@@ -0,0 +1,138 @@
+package user_test
+
+import (
+	"context"
+	"reflect"
+	"task-trail/internal/customerrors"
+	"task-trail/internal/repo"
+	"task-trail/internal/usecase/dto"
+	"task-trail/internal/usecase/user"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestUseCaseExport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	username := "john"
+	avatarID := "avatar-id"
+	avatarURL := "https://cdn.test/avatar-id"
+	verifiedAt := time.Now().Add(-time.Hour)
+	u := &dto.User{
+		ID:           1,
+		Email:        "john@test.test",
+		Username:     &username,
+		AvatarID:     &avatarID,
+		PasswordHash: "hash",
+		VerifiedAt:   &verifiedAt,
+		CreatedAt:    verifiedAt.Add(-time.Hour),
+	}
+	projects := []*dto.ProjectRes{{ID: 1, Name: "Alpha"}}
+	tasks := []*dto.Task{{ID: 1, ProjectID: 1, Name: "Task", AuthorID: 1}}
+	files := []*dto.File{{ID: "file-id", OriginalName: "doc.pdf", OwnerID: 1}}
+	tests := []struct {
+		name        string
+		uc          func(ctrl *gomock.Controller) *user.UseCase
+		want        *dto.UserExport
+		wantErr     bool
+		wantErrType customerrors.ErrType
+		wantErrMsg  string
+	}{
+		{
+			name: "profile, projects, tasks and files are exported",
+			uc: func(ctrl *gomock.Controller) *user.UseCase {
+				uc, deps := mockUseCase(ctrl)
+				deps.userRepo.EXPECT().GetByID(ctx, 1).Return(u, nil)
+				deps.storage.EXPECT().GetPath(avatarID).Return(avatarURL)
+				deps.projectRepo.EXPECT().GetList(ctx, &dto.ProjectList{MemberID: 1}).Return(projects, nil)
+				deps.taskRepo.EXPECT().GetByAuthor(ctx, 1).Return(tasks, nil)
+				deps.fileRepo.EXPECT().GetByOwner(ctx, 1).Return(files, nil)
+				return uc
+			},
+			want: &dto.UserExport{
+				Profile: &dto.UserExportProfile{
+					ID:         1,
+					Email:      u.Email,
+					Username:   &username,
+					AvatarURL:  &avatarURL,
+					VerifiedAt: &verifiedAt,
+					CreatedAt:  u.CreatedAt,
+				},
+				Projects: projects,
+				Tasks:    tasks,
+				Files:    files,
+			},
+		},
+		{
+			name: "user not found",
+			uc: func(ctrl *gomock.Controller) *user.UseCase {
+				uc, deps := mockUseCase(ctrl)
+				deps.userRepo.EXPECT().GetByID(ctx, 1).Return(nil, repo.ErrNotFound)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.ValidationErr,
+			wantErrMsg:  "user not found",
+		},
+		{
+			name: "failed to get user projects",
+			uc: func(ctrl *gomock.Controller) *user.UseCase {
+				uc, deps := mockUseCase(ctrl)
+				deps.userRepo.EXPECT().GetByID(ctx, 1).Return(&dto.User{ID: 1}, nil)
+				deps.projectRepo.EXPECT().GetList(ctx, gomock.Any()).Return(nil, repo.ErrInternal)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.InternalErr,
+			wantErrMsg:  "failed to get user projects",
+		},
+		{
+			name: "failed to get user tasks",
+			uc: func(ctrl *gomock.Controller) *user.UseCase {
+				uc, deps := mockUseCase(ctrl)
+				deps.userRepo.EXPECT().GetByID(ctx, 1).Return(&dto.User{ID: 1}, nil)
+				deps.projectRepo.EXPECT().GetList(ctx, gomock.Any()).Return(nil, nil)
+				deps.taskRepo.EXPECT().GetByAuthor(ctx, 1).Return(nil, repo.ErrInternal)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.InternalErr,
+			wantErrMsg:  "failed to get user tasks",
+		},
+		{
+			name: "failed to get user files",
+			uc: func(ctrl *gomock.Controller) *user.UseCase {
+				uc, deps := mockUseCase(ctrl)
+				deps.userRepo.EXPECT().GetByID(ctx, 1).Return(&dto.User{ID: 1}, nil)
+				deps.projectRepo.EXPECT().GetList(ctx, gomock.Any()).Return(nil, nil)
+				deps.taskRepo.EXPECT().GetByAuthor(ctx, 1).Return(nil, nil)
+				deps.fileRepo.EXPECT().GetByOwner(ctx, 1).Return(nil, repo.ErrInternal)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.InternalErr,
+			wantErrMsg:  "failed to get user files",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := tt.uc(ctrl)
+			got, err := uc.Export(ctx, 1)
+			checkErr(t, err, tt.wantErr, tt.wantErrType, tt.wantErrMsg)
+			if tt.wantErr {
+				return
+			}
+			if got == nil || time.Since(got.CreatedAt) > time.Second {
+				t.Fatalf("unexpected export creation time: %v", got)
+			}
+			got.CreatedAt = time.Time{}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"task-trail/internal/customerrors"
+	"task-trail/internal/pkg/logger"
 	"task-trail/internal/pkg/password"
 	"task-trail/internal/pkg/storage"
 	"task-trail/internal/pkg/uuid"
@@ -21,30 +22,52 @@ var avatarAllowedMimeTypes = map[string]bool{
 type UseCase struct {
 	txManager   repo.TxManager
 	userRepo    repo.UserRepository
+	projectRepo repo.ProjectRepository
+	taskRepo    repo.TaskRepository
+	fileRepo    repo.FileRepository
+	rtRepo      repo.RefreshTokenRepository
+	etRepo      repo.EmailTokenRepository
 	fileUseCase *file.UseCase
 	storage     storage.Service
 	pwdService  password.Service
 	errHandler  customerrors.ErrorHandler
 	uuidGen     uuid.Generator
+	logger      logger.Logger
+	// number of days between deletion request and account anonymization
+	deletionGraceDays int
 }
 
 func New(
 	txManager repo.TxManager,
 	repo repo.UserRepository,
+	projectRepo repo.ProjectRepository,
+	taskRepo repo.TaskRepository,
+	fileRepo repo.FileRepository,
+	rtRepo repo.RefreshTokenRepository,
+	etRepo repo.EmailTokenRepository,
 	fileUseCase *file.UseCase,
 	storage storage.Service,
 	pwdService password.Service,
 	errHandler customerrors.ErrorHandler,
 	uuidGen uuid.Generator,
+	logger logger.Logger,
+	deletionGraceDays int,
 ) *UseCase {
 	return &UseCase{
-		txManager:   txManager,
-		userRepo:    repo,
-		fileUseCase: fileUseCase,
-		storage:     storage,
-		pwdService:  pwdService,
-		errHandler:  errHandler,
-		uuidGen:     uuidGen,
+		txManager:         txManager,
+		userRepo:          repo,
+		projectRepo:       projectRepo,
+		taskRepo:          taskRepo,
+		fileRepo:          fileRepo,
+		rtRepo:            rtRepo,
+		etRepo:            etRepo,
+		fileUseCase:       fileUseCase,
+		storage:           storage,
+		pwdService:        pwdService,
+		errHandler:        errHandler,
+		uuidGen:           uuidGen,
+		logger:            logger,
+		deletionGraceDays: deletionGraceDays,
 	}
 }
 
@@ -91,6 +114,15 @@ func (u *UseCase) UpdateByID(ctx context.Context, data *dto.UserUpdate) (*dto.Cu
 	return u.GetCurrentByID(ctx, data.ID)
 }
 func (u *UseCase) GetCurrentByID(ctx context.Context, ID int) (*dto.CurrentUser, error) {
+	user, err := u.getByID(ctx, ID)
+	if err != nil {
+		return nil, err
+	}
+	return u.toCurrentUser(user), nil
+
+}
+
+func (u *UseCase) getByID(ctx context.Context, ID int) (*dto.User, error) {
 	user, err := u.userRepo.GetByID(ctx, ID)
 	if err != nil {
 		if errors.Is(err, repo.ErrNotFound) {
@@ -98,8 +130,7 @@ func (u *UseCase) GetCurrentByID(ctx context.Context, ID int) (*dto.CurrentUser,
 		}
 		return nil, u.errHandler.InternalTrouble(err, "failed to get user", "userID", ID)
 	}
-	return u.toCurrentUser(user), nil
-
+	return user, nil
 }
 
 func (u *UseCase) toCurrentUser(data *dto.User) *dto.CurrentUser {
@@ -107,10 +138,15 @@ func (u *UseCase) toCurrentUser(data *dto.User) *dto.CurrentUser {
 		ID:       data.ID,
 		Username: data.Username,
 		Email:    data.Email,
+		Language: data.Language,
 	}
 	if data.AvatarID != nil {
 		avatarURL := u.storage.GetPath(*data.AvatarID)
 		retVal.AvatarURL = &avatarURL
 	}
+	if data.DeletionRequestedAt != nil {
+		scheduledAt := u.deletionDate(*data.DeletionRequestedAt)
+		retVal.DeletionScheduledAt = &scheduledAt
+	}
 	return retVal
 }
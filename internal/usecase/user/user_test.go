@@ -0,0 +1,104 @@
+package user_test
+
+import (
+	"context"
+	"errors"
+	"task-trail/internal/customerrors"
+	"task-trail/internal/usecase/user"
+	"task-trail/test/mocks"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+const testGraceDays = 30
+
+type testDeps struct {
+	txManager   mocks.MockTxManager
+	userRepo    mocks.MockUserRepository
+	projectRepo mocks.MockProjectRepository
+	taskRepo    mocks.MockTaskRepository
+	fileRepo    mocks.MockFileRepository
+	rtRepo      mocks.MockRefreshTokenRepository
+	etRepo      mocks.MockEmailTokenRepository
+	storage     mocks.MockStorage
+	pwdService  mocks.MockPasswordService
+	logger      mocks.MockLogger
+}
+
+func mockUseCase(ctrl *gomock.Controller) (*user.UseCase, *testDeps) {
+	txManager := mocks.NewMockTxManager(ctrl)
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	projectRepo := mocks.NewMockProjectRepository(ctrl)
+	taskRepo := mocks.NewMockTaskRepository(ctrl)
+	fileRepo := mocks.NewMockFileRepository(ctrl)
+	rtRepo := mocks.NewMockRefreshTokenRepository(ctrl)
+	etRepo := mocks.NewMockEmailTokenRepository(ctrl)
+	storage := mocks.NewMockStorage(ctrl)
+	pwdService := mocks.NewMockPasswordService(ctrl)
+	uuidGen := mocks.NewMockGenerator(ctrl)
+	logger := mocks.NewMockLogger(ctrl)
+	uc := user.New(
+		txManager,
+		userRepo,
+		projectRepo,
+		taskRepo,
+		fileRepo,
+		rtRepo,
+		etRepo,
+		nil,
+		storage,
+		pwdService,
+		customerrors.NewErrHander(),
+		uuidGen,
+		logger,
+		testGraceDays,
+	)
+	deps := &testDeps{
+		txManager:   *txManager,
+		userRepo:    *userRepo,
+		projectRepo: *projectRepo,
+		taskRepo:    *taskRepo,
+		fileRepo:    *fileRepo,
+		rtRepo:      *rtRepo,
+		etRepo:      *etRepo,
+		storage:     *storage,
+		pwdService:  *pwdService,
+		logger:      *logger,
+	}
+	return uc, deps
+}
+
+func mockTx(ctx context.Context, txManager mocks.MockTxManager) {
+	txManager.EXPECT().DoWithTx(ctx, gomock.Any()).
+		DoAndReturn(
+			func(ctx context.Context, f func(ctx context.Context) error) error {
+				return f(ctx)
+			},
+		)
+}
+
+func checkErr(t *testing.T, err error, wantErr bool, wantErrType customerrors.ErrType, wantErrMsg string) {
+	t.Helper()
+	if !wantErr {
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		return
+	}
+	var e *customerrors.Err
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+	if !errors.As(err, &e) {
+		t.Errorf("expected custom error type, got %T", err)
+		return
+	}
+	if e.Type != wantErrType {
+		t.Errorf("unexpected error type: got %d, want %d", e.Type, wantErrType)
+	}
+	if e.Msg != wantErrMsg {
+		t.Errorf("unexpected error msg: got %s, want %s", e.Msg, wantErrMsg)
+	}
+}
@@ -0,0 +1,172 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"task-trail/internal/pkg/pubsub"
+	"task-trail/internal/pkg/webhook"
+	"task-trail/internal/usecase/dto"
+	"time"
+)
+
+// upper bound of the delay between delivery attempts
+const maxBackoff = time.Hour * 6
+
+// maximum length of the saved delivery error
+const maxErrorLen = 1000
+
+// claimed deliveries are sent again by the next run if their attempts are not saved in time,
+// e.g. the worker was stopped, so it must exceed the time of sending the whole batch
+const claimTimeout = time.Minute * 30
+
+// eventBody is the JSON body of the webhook request.
+type eventBody struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	ProjectID int             `json:"projectId"`
+	CreatedAt time.Time       `json:"createdAt"`
+	Data      json.RawMessage `json:"data,omitempty"`
+}
+
+// Publish creates deliveries of the event for all subscribed project webhooks,
+// deliveries are sent by the background worker.
+func (u *UseCase) Publish(ctx context.Context, event pubsub.Event) error {
+	webhooks, err := u.webhookRepo.GetSubscribed(ctx, event.ProjectID, event.Type)
+	if err != nil {
+		return u.errHandler.InternalTrouble(err, "failed to get subscribed webhooks", "projectID", event.ProjectID)
+	}
+	for _, w := range webhooks {
+		if _, err := u.createDelivery(ctx, w.ID, event, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SendTest sends the test event to the webhook immediately and returns the delivery result.
+// Failed test delivery is retried as any other one.
+func (u *UseCase) SendTest(ctx context.Context, projectID int, ownerID int, ID int) (*dto.WebhookDelivery, error) {
+	w, err := u.getWebhook(ctx, projectID, ownerID, ID)
+	if err != nil {
+		return nil, err
+	}
+	data, _ := json.Marshal(map[string]string{"message": "test event"})
+	// delivery is saved as claimed, so the worker doesn't send it while the request is in flight,
+	// and the request is sent without holding a transaction
+	claimed := time.Now().Add(claimTimeout)
+	retVal, err := u.createDelivery(ctx, w.ID, pubsub.Event{Type: pubsub.WebhookTest, ProjectID: projectID, Data: data}, &claimed)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := u.attempt(ctx, retVal); err != nil {
+		return nil, err
+	}
+	return retVal, nil
+}
+
+// Deliver sends pending deliveries.
+// Failed deliveries are retried with exponential backoff and fail after maxAttempts.
+// Deliveries are claimed first, so requests are sent without holding a transaction.
+func (u *UseCase) Deliver(ctx context.Context) (*dto.WebhookDeliveryStats, error) {
+	deliveries, err := u.webhookRepo.ClaimPendingDeliveries(ctx, u.batchSize, time.Now().Add(claimTimeout))
+	if err != nil {
+		return nil, u.errHandler.InternalTrouble(err, "failed to get pending webhook deliveries")
+	}
+	retVal := &dto.WebhookDeliveryStats{}
+	for _, d := range deliveries {
+		status, err := u.attempt(ctx, d)
+		if err != nil {
+			return nil, err
+		}
+		switch status {
+		case dto.WebhookDeliveryDelivered:
+			retVal.Delivered++
+		case dto.WebhookDeliveryPending:
+			retVal.Retried++
+		case dto.WebhookDeliveryFailed:
+			retVal.Failed++
+		}
+	}
+	return retVal, nil
+}
+
+func (u *UseCase) createDelivery(ctx context.Context, webhookID int, event pubsub.Event, nextAttemptAt *time.Time) (*dto.WebhookDelivery, error) {
+	eventID := u.uuidGenerator.Generate()
+	body, err := json.Marshal(eventBody{
+		ID:        eventID,
+		Type:      event.Type,
+		ProjectID: event.ProjectID,
+		CreatedAt: time.Now().UTC(),
+		Data:      event.Data,
+	})
+	if err != nil {
+		return nil, u.errHandler.InternalTrouble(err, "failed to encode webhook event", "webhookID", webhookID)
+	}
+	retVal, err := u.webhookRepo.CreateDelivery(ctx, &dto.WebhookDeliveryCreate{
+		WebhookID:     webhookID,
+		EventID:       eventID,
+		EventType:     event.Type,
+		Payload:       string(body),
+		NextAttemptAt: nextAttemptAt,
+	})
+	if err != nil {
+		return nil, u.errHandler.InternalTrouble(err, "failed to create webhook delivery", "webhookID", webhookID)
+	}
+	return retVal, nil
+}
+
+// attempt sends the delivery, saves the result and updates the delivery with it.
+func (u *UseCase) attempt(ctx context.Context, d *dto.WebhookDelivery) (dto.WebhookDeliveryStatus, error) {
+	code, sendErr := u.sender.Send(ctx, &webhook.Request{
+		URL:       d.URL,
+		Secret:    d.Secret,
+		EventID:   d.EventID,
+		EventType: d.EventType,
+		Body:      []byte(d.Payload),
+	})
+	a := &dto.WebhookAttempt{DeliveryID: d.ID, Status: dto.WebhookDeliveryDelivered}
+	if code != 0 {
+		a.ResponseCode = &code
+	}
+	if sendErr != nil {
+		msg := sendErr.Error()
+		if len(msg) > maxErrorLen {
+			msg = msg[:maxErrorLen]
+		}
+		a.LastError = &msg
+		a.NextAttemptAt = u.nextAttemptAt(d.Attempts + 1)
+		a.Status = dto.WebhookDeliveryFailed
+		if a.NextAttemptAt != nil {
+			a.Status = dto.WebhookDeliveryPending
+		}
+	}
+	if err := u.webhookRepo.SaveAttempt(ctx, a); err != nil {
+		return "", u.errHandler.InternalTrouble(err, "failed to save webhook delivery attempt", "eventID", d.EventID)
+	}
+	d.Attempts++
+	d.Status = a.Status
+	d.ResponseCode = a.ResponseCode
+	d.LastError = a.LastError
+	if a.NextAttemptAt != nil {
+		d.NextAttemptAt = *a.NextAttemptAt
+	}
+	if a.Status == dto.WebhookDeliveryDelivered {
+		now := time.Now()
+		d.DeliveredAt = &now
+	}
+	return a.Status, nil
+}
+
+// nextAttemptAt returns time of the next delivery attempt, or nil if attempts are exhausted.
+func (u *UseCase) nextAttemptAt(attempts int) *time.Time {
+	if attempts >= u.maxAttempts {
+		return nil
+	}
+	delay := u.backoff
+	for i := 1; i < attempts && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	delay = min(delay, maxBackoff)
+	next := time.Now().Add(delay)
+	return &next
+}
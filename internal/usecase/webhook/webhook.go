@@ -0,0 +1,123 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"task-trail/internal/customerrors"
+	"task-trail/internal/pkg/uuid"
+	"task-trail/internal/pkg/webhook"
+	"task-trail/internal/repo"
+	"task-trail/internal/usecase/dto"
+	"time"
+)
+
+type UseCase struct {
+	webhookRepo   repo.WebhookRepository
+	projectRepo   repo.ProjectRepository
+	sender        webhook.Sender
+	uuidGenerator uuid.Generator
+	errHandler    customerrors.ErrorHandler
+	// number of delivery attempts before the delivery fails
+	maxAttempts int
+	// number of deliveries processed in one worker run
+	batchSize int
+	// delay before the first retry, doubled on each next one
+	backoff time.Duration
+}
+
+func New(
+	webhookRepo repo.WebhookRepository,
+	projectRepo repo.ProjectRepository,
+	sender webhook.Sender,
+	uuidGenerator uuid.Generator,
+	errHandler customerrors.ErrorHandler,
+	maxAttempts int,
+	batchSize int,
+	backoff time.Duration,
+) *UseCase {
+	return &UseCase{
+		webhookRepo:   webhookRepo,
+		projectRepo:   projectRepo,
+		sender:        sender,
+		uuidGenerator: uuidGenerator,
+		errHandler:    errHandler,
+		maxAttempts:   maxAttempts,
+		batchSize:     batchSize,
+		backoff:       backoff,
+	}
+}
+
+// Create adds the webhook to the project owned by the user, the secret is generated if not given.
+func (u *UseCase) Create(ctx context.Context, data *dto.WebhookCreate) (*dto.Webhook, error) {
+	if err := u.checkOwner(ctx, data.ProjectID, data.OwnerID); err != nil {
+		return nil, err
+	}
+	if data.Secret == "" {
+		data.Secret = rand.Text()
+	}
+	retVal, err := u.webhookRepo.Create(ctx, data)
+	if err != nil {
+		return nil, u.errHandler.InternalTrouble(err, "failed to create webhook", "projectID", data.ProjectID)
+	}
+	return retVal, nil
+}
+
+func (u *UseCase) GetList(ctx context.Context, projectID int, ownerID int) ([]*dto.Webhook, error) {
+	if err := u.checkOwner(ctx, projectID, ownerID); err != nil {
+		return nil, err
+	}
+	retVal, err := u.webhookRepo.GetList(ctx, projectID)
+	if err != nil {
+		return nil, u.errHandler.InternalTrouble(err, "failed to get webhooks", "projectID", projectID)
+	}
+	return retVal, nil
+}
+
+func (u *UseCase) Delete(ctx context.Context, projectID int, ownerID int, ID int) error {
+	if err := u.checkOwner(ctx, projectID, ownerID); err != nil {
+		return err
+	}
+	if err := u.webhookRepo.Delete(ctx, projectID, ID); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return u.errHandler.NotFound(err, "webhook not found", "projectID", projectID, "webhookID", ID)
+		}
+		return u.errHandler.InternalTrouble(err, "failed to delete webhook", "projectID", projectID, "webhookID", ID)
+	}
+	return nil
+}
+
+func (u *UseCase) GetDeliveries(ctx context.Context, data *dto.WebhookDeliveryList) ([]*dto.WebhookDelivery, error) {
+	if _, err := u.getWebhook(ctx, data.ProjectID, data.OwnerID, data.WebhookID); err != nil {
+		return nil, err
+	}
+	retVal, err := u.webhookRepo.GetDeliveries(ctx, data)
+	if err != nil {
+		return nil, u.errHandler.InternalTrouble(err, "failed to get webhook deliveries", "webhookID", data.WebhookID)
+	}
+	return retVal, nil
+}
+
+func (u *UseCase) checkOwner(ctx context.Context, projectID int, ownerID int) error {
+	if _, err := u.projectRepo.GetOwned(ctx, projectID, ownerID); err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return u.errHandler.NotFound(err, "project not found", "projectID", projectID, "ownerID", ownerID)
+		}
+		return u.errHandler.InternalTrouble(err, "failed to get project", "projectID", projectID, "ownerID", ownerID)
+	}
+	return nil
+}
+
+func (u *UseCase) getWebhook(ctx context.Context, projectID int, ownerID int, ID int) (*dto.Webhook, error) {
+	if err := u.checkOwner(ctx, projectID, ownerID); err != nil {
+		return nil, err
+	}
+	retVal, err := u.webhookRepo.GetByID(ctx, projectID, ID)
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return nil, u.errHandler.NotFound(err, "webhook not found", "projectID", projectID, "webhookID", ID)
+		}
+		return nil, u.errHandler.InternalTrouble(err, "failed to get webhook", "projectID", projectID, "webhookID", ID)
+	}
+	return retVal, nil
+}
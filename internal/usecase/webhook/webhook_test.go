@@ -0,0 +1,362 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"task-trail/internal/customerrors"
+	"task-trail/internal/pkg/pubsub"
+	"task-trail/internal/pkg/webhook"
+	"task-trail/internal/pkg/webhook/httpsender"
+	"task-trail/internal/repo"
+	"task-trail/internal/usecase/dto"
+	webhookuc "task-trail/internal/usecase/webhook"
+	"task-trail/test/mocks"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	testMaxAttempts = 3
+	testBatchSize   = 10
+	testBackoff     = time.Minute
+	testSecret      = "test-secret-0123456789"
+	testEventID     = "8f1c9f36-5c1e-4a43-9a4d-1b0f6c3f4f11"
+)
+
+type testDeps struct {
+	webhookRepo   mocks.MockWebhookRepository
+	projectRepo   mocks.MockProjectRepository
+	uuidGenerator mocks.MockGenerator
+}
+
+// MockUseCase creates use case sending real HTTP requests.
+func MockUseCase(ctrl *gomock.Controller) (*webhookuc.UseCase, *testDeps) {
+	webhookRepo := mocks.NewMockWebhookRepository(ctrl)
+	projectRepo := mocks.NewMockProjectRepository(ctrl)
+	uuidGenerator := mocks.NewMockGenerator(ctrl)
+	uc := webhookuc.New(
+		webhookRepo,
+		projectRepo,
+		// test receivers listen on loopback
+		httpsender.New(time.Second, httpsender.AllowPrivateNetworks(true)),
+		uuidGenerator,
+		customerrors.NewErrHander(),
+		testMaxAttempts,
+		testBatchSize,
+		testBackoff,
+	)
+	return uc, &testDeps{
+		webhookRepo:   *webhookRepo,
+		projectRepo:   *projectRepo,
+		uuidGenerator: *uuidGenerator,
+	}
+}
+
+// received is the request captured by the stub receiver.
+type received struct {
+	header http.Header
+	body   []byte
+}
+
+// newStub starts local receiver responding with the given status codes one by one.
+func newStub(t *testing.T, codes ...int) (*httptest.Server, <-chan received) {
+	requests := make(chan received, len(codes))
+	i := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- received{header: r.Header, body: body}
+		w.WriteHeader(codes[i])
+		i++
+	}))
+	t.Cleanup(srv.Close)
+	return srv, requests
+}
+
+func checkErr(t *testing.T, err error, wantErr bool, wantErrType customerrors.ErrType, wantErrMsg string) {
+	t.Helper()
+	if !wantErr {
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		return
+	}
+	var e *customerrors.Err
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+	if !errors.As(err, &e) {
+		t.Errorf("expected custom error type, got %T", err)
+		return
+	}
+	if e.Type != wantErrType {
+		t.Errorf("unexpected error type: got %d, want %d", e.Type, wantErrType)
+	}
+	if e.Msg != wantErrMsg {
+		t.Errorf("unexpected error msg: got %s, want %s", e.Msg, wantErrMsg)
+	}
+}
+
+func TestUseCaseSendTest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	srv, requests := newStub(t, http.StatusNoContent)
+	hook := &dto.Webhook{ID: 2, ProjectID: 1, URL: srv.URL, Secret: testSecret}
+
+	uc, deps := MockUseCase(ctrl)
+	deps.projectRepo.EXPECT().GetOwned(ctx, 1, 1).Return(&dto.Project{ID: 1}, nil)
+	deps.webhookRepo.EXPECT().GetByID(ctx, 1, 2).Return(hook, nil)
+	deps.uuidGenerator.EXPECT().Generate().Return(testEventID)
+	deps.webhookRepo.EXPECT().CreateDelivery(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, data *dto.WebhookDeliveryCreate) (*dto.WebhookDelivery, error) {
+			// the worker must not pick the delivery while the test request is sent
+			if data.NextAttemptAt == nil || time.Until(*data.NextAttemptAt) < 10*time.Minute {
+				t.Errorf("delivery is not claimed: %v", data.NextAttemptAt)
+			}
+			return &dto.WebhookDelivery{
+				ID:        5,
+				WebhookID: data.WebhookID,
+				EventID:   data.EventID,
+				EventType: data.EventType,
+				Payload:   data.Payload,
+				Status:    dto.WebhookDeliveryPending,
+				URL:       hook.URL,
+				Secret:    hook.Secret,
+			}, nil
+		},
+	)
+	code := http.StatusNoContent
+	deps.webhookRepo.EXPECT().SaveAttempt(ctx, &dto.WebhookAttempt{
+		DeliveryID:   5,
+		Status:       dto.WebhookDeliveryDelivered,
+		ResponseCode: &code,
+	}).Return(nil)
+
+	got, err := uc.SendTest(ctx, 1, 1, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Status != dto.WebhookDeliveryDelivered || got.Attempts != 1 || *got.ResponseCode != code {
+		t.Errorf("unexpected delivery: %+v", got)
+	}
+
+	r := <-requests
+	if sign := r.header.Get(webhook.HeaderSignature); sign != webhook.Sign(testSecret, r.body) {
+		t.Errorf("invalid signature %s", sign)
+	}
+	if r.header.Get(webhook.HeaderEvent) != pubsub.WebhookTest {
+		t.Errorf("unexpected event header %s", r.header.Get(webhook.HeaderEvent))
+	}
+	if r.header.Get(webhook.HeaderDelivery) != testEventID {
+		t.Errorf("unexpected delivery header %s", r.header.Get(webhook.HeaderDelivery))
+	}
+	var body map[string]any
+	if err := json.Unmarshal(r.body, &body); err != nil {
+		t.Fatalf("invalid body: %v", err)
+	}
+	if body["id"] != testEventID || body["type"] != pubsub.WebhookTest || body["projectId"] != float64(1) {
+		t.Errorf("unexpected body: %s", r.body)
+	}
+}
+
+func TestUseCaseDeliver(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	newDelivery := func(ID int, attempts int, url string) *dto.WebhookDelivery {
+		return &dto.WebhookDelivery{
+			ID:        ID,
+			EventID:   testEventID,
+			EventType: pubsub.ProjectMembersAdded,
+			Payload:   `{"type":"project.members_added"}`,
+			Status:    dto.WebhookDeliveryPending,
+			Attempts:  attempts,
+			URL:       url,
+			Secret:    testSecret,
+		}
+	}
+	// claimedUntil matches the time claimed deliveries are postponed to
+	claimedUntil := gomock.Cond(func(x any) bool {
+		until, ok := x.(time.Time)
+		return ok && time.Until(until) > 10*time.Minute
+	})
+	// nextAttemptIn matches the attempt retried after the given delay.
+	nextAttemptIn := func(ID int, delay time.Duration) gomock.Matcher {
+		return gomock.Cond(func(x any) bool {
+			a, ok := x.(*dto.WebhookAttempt)
+			if !ok || a.DeliveryID != ID || a.Status != dto.WebhookDeliveryPending || a.NextAttemptAt == nil {
+				return false
+			}
+			d := time.Until(*a.NextAttemptAt)
+			return d > delay-time.Second && d <= delay && *a.ResponseCode == http.StatusInternalServerError
+		})
+	}
+
+	tests := []struct {
+		name        string
+		uc          func(t *testing.T, ctrl *gomock.Controller) *webhookuc.UseCase
+		want        *dto.WebhookDeliveryStats
+		wantErr     bool
+		wantErrType customerrors.ErrType
+		wantErrMsg  string
+	}{
+		{
+			name: "delivered, retried with backoff and failed",
+			uc: func(t *testing.T, ctrl *gomock.Controller) *webhookuc.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				srv, _ := newStub(t, http.StatusOK, http.StatusInternalServerError, http.StatusInternalServerError)
+				deps.webhookRepo.EXPECT().ClaimPendingDeliveries(ctx, testBatchSize, claimedUntil).Return([]*dto.WebhookDelivery{
+					newDelivery(1, 0, srv.URL),
+					newDelivery(2, 1, srv.URL),
+					newDelivery(3, 2, srv.URL),
+				}, nil)
+				code := http.StatusOK
+				deps.webhookRepo.EXPECT().SaveAttempt(ctx, &dto.WebhookAttempt{
+					DeliveryID:   1,
+					Status:       dto.WebhookDeliveryDelivered,
+					ResponseCode: &code,
+				}).Return(nil)
+				deps.webhookRepo.EXPECT().SaveAttempt(ctx, nextAttemptIn(2, 2*testBackoff)).Return(nil)
+				deps.webhookRepo.EXPECT().SaveAttempt(ctx, gomock.Cond(func(x any) bool {
+					a, ok := x.(*dto.WebhookAttempt)
+					return ok && a.DeliveryID == 3 && a.Status == dto.WebhookDeliveryFailed && a.NextAttemptAt == nil
+				})).Return(nil)
+				return uc
+			},
+			want: &dto.WebhookDeliveryStats{Delivered: 1, Retried: 1, Failed: 1},
+		},
+		{
+			name: "receiver is unavailable",
+			uc: func(t *testing.T, ctrl *gomock.Controller) *webhookuc.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				srv, _ := newStub(t)
+				srv.Close()
+				deps.webhookRepo.EXPECT().ClaimPendingDeliveries(ctx, testBatchSize, claimedUntil).Return([]*dto.WebhookDelivery{newDelivery(1, 0, srv.URL)}, nil)
+				deps.webhookRepo.EXPECT().SaveAttempt(ctx, gomock.Cond(func(x any) bool {
+					a, ok := x.(*dto.WebhookAttempt)
+					return ok && a.Status == dto.WebhookDeliveryPending && a.ResponseCode == nil && a.LastError != nil
+				})).Return(nil)
+				return uc
+			},
+			want: &dto.WebhookDeliveryStats{Retried: 1},
+		},
+		{
+			name: "failed to get pending deliveries",
+			uc: func(t *testing.T, ctrl *gomock.Controller) *webhookuc.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				deps.webhookRepo.EXPECT().ClaimPendingDeliveries(ctx, testBatchSize, claimedUntil).Return(nil, repo.ErrInternal)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.InternalErr,
+			wantErrMsg:  "failed to get pending webhook deliveries",
+		},
+		{
+			name: "failed to save attempt",
+			uc: func(t *testing.T, ctrl *gomock.Controller) *webhookuc.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				srv, _ := newStub(t, http.StatusOK)
+				deps.webhookRepo.EXPECT().ClaimPendingDeliveries(ctx, testBatchSize, claimedUntil).Return([]*dto.WebhookDelivery{newDelivery(1, 0, srv.URL)}, nil)
+				deps.webhookRepo.EXPECT().SaveAttempt(ctx, gomock.Any()).Return(repo.ErrInternal)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.InternalErr,
+			wantErrMsg:  "failed to save webhook delivery attempt",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := tt.uc(t, ctrl)
+			got, err := u.Deliver(ctx)
+			checkErr(t, err, tt.wantErr, tt.wantErrType, tt.wantErrMsg)
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUseCasePublish(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	event := pubsub.Event{Type: pubsub.ProjectMembersAdded, ProjectID: 1, Data: json.RawMessage(`{"memberEmails":["a@test.test"]}`)}
+
+	tests := []struct {
+		name        string
+		uc          func(ctrl *gomock.Controller) *webhookuc.UseCase
+		wantErr     bool
+		wantErrType customerrors.ErrType
+		wantErrMsg  string
+	}{
+		{
+			name: "delivery created for every subscribed webhook",
+			uc: func(ctrl *gomock.Controller) *webhookuc.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				deps.webhookRepo.EXPECT().GetSubscribed(ctx, 1, pubsub.ProjectMembersAdded).Return([]*dto.Webhook{{ID: 1}, {ID: 2}}, nil)
+				deps.uuidGenerator.EXPECT().Generate().Return(testEventID).Times(2)
+				deps.webhookRepo.EXPECT().CreateDelivery(ctx, gomock.Cond(func(x any) bool {
+					d, ok := x.(*dto.WebhookDeliveryCreate)
+					var body map[string]any
+					return ok && d.EventType == pubsub.ProjectMembersAdded &&
+						json.Unmarshal([]byte(d.Payload), &body) == nil && body["data"] != nil
+				})).Return(&dto.WebhookDelivery{}, nil).Times(2)
+				return uc
+			},
+		},
+		{
+			name: "failed to get subscribed webhooks",
+			uc: func(ctrl *gomock.Controller) *webhookuc.UseCase {
+				uc, deps := MockUseCase(ctrl)
+				deps.webhookRepo.EXPECT().GetSubscribed(ctx, 1, pubsub.ProjectMembersAdded).Return(nil, repo.ErrInternal)
+				return uc
+			},
+			wantErr:     true,
+			wantErrType: customerrors.InternalErr,
+			wantErrMsg:  "failed to get subscribed webhooks",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := tt.uc(ctrl)
+			err := u.Publish(ctx, event)
+			checkErr(t, err, tt.wantErr, tt.wantErrType, tt.wantErrMsg)
+		})
+	}
+}
+
+func TestUseCaseCreate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	t.Run("secret is generated", func(t *testing.T) {
+		uc, deps := MockUseCase(ctrl)
+		deps.projectRepo.EXPECT().GetOwned(ctx, 1, 1).Return(&dto.Project{ID: 1}, nil)
+		deps.webhookRepo.EXPECT().Create(ctx, gomock.Cond(func(x any) bool {
+			d, ok := x.(*dto.WebhookCreate)
+			return ok && len(d.Secret) >= 16
+		})).Return(&dto.Webhook{ID: 1}, nil)
+		_, err := uc.Create(ctx, &dto.WebhookCreate{ProjectID: 1, OwnerID: 1, URL: "https://test.test"})
+		checkErr(t, err, false, 0, "")
+	})
+	t.Run("project not found", func(t *testing.T) {
+		uc, deps := MockUseCase(ctrl)
+		deps.projectRepo.EXPECT().GetOwned(ctx, 1, 1).Return(nil, repo.ErrNotFound)
+		_, err := uc.Create(ctx, &dto.WebhookCreate{ProjectID: 1, OwnerID: 1, URL: "https://test.test"})
+		checkErr(t, err, true, customerrors.NotFoundErr, "project not found")
+	})
+}
@@ -0,0 +1,12 @@
+DELETE FROM email_tokens
+WHERE purpose IN ('email_change', 'email_change_cancel');
+
+ALTER TABLE email_tokens
+    DROP CONSTRAINT email_token_purpose_check;
+
+ALTER TABLE email_tokens
+    ADD CONSTRAINT email_token_purpose_check
+    CHECK (purpose IN ('verify', 'reset'));
+
+ALTER TABLE email_tokens
+    DROP COLUMN payload;
@@ -0,0 +1,9 @@
+ALTER TABLE email_tokens
+    ADD payload VARCHAR NULL;
+
+ALTER TABLE email_tokens
+    DROP CONSTRAINT email_token_purpose_check;
+
+ALTER TABLE email_tokens
+    ADD CONSTRAINT email_token_purpose_check
+    CHECK (purpose IN ('verify', 'reset', 'email_change', 'email_change_cancel'));
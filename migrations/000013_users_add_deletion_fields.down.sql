@@ -0,0 +1,3 @@
+ALTER TABLE users
+DROP COLUMN IF EXISTS deletion_requested_at,
+DROP COLUMN IF EXISTS deleted_at;
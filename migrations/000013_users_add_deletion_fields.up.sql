@@ -0,0 +1,3 @@
+ALTER TABLE users
+ADD deletion_requested_at TIMESTAMP WITH TIME ZONE NULL,
+ADD deleted_at TIMESTAMP WITH TIME ZONE NULL;
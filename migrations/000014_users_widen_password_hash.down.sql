@@ -0,0 +1,13 @@
+-- argon2id hashes do not fit into VARCHAR(60), so the migration can only be reverted
+-- while every stored hash is still a bcrypt one; otherwise the users have to reset
+-- their passwords (or the hashes have to be cleared) before going down
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM users WHERE length(password_hash) > 60) THEN
+        RAISE EXCEPTION 'cannot shrink users.password_hash: hashes longer than 60 characters exist';
+    END IF;
+END
+$$;
+
+ALTER TABLE users
+ALTER COLUMN password_hash TYPE VARCHAR(60);
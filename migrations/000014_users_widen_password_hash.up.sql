@@ -0,0 +1,2 @@
+ALTER TABLE users
+ALTER COLUMN password_hash TYPE VARCHAR(255);
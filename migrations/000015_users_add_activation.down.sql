@@ -0,0 +1,11 @@
+DELETE FROM email_tokens WHERE purpose = 'activation';
+
+ALTER TABLE email_tokens
+    DROP CONSTRAINT email_token_purpose_check;
+
+ALTER TABLE email_tokens
+    ADD CONSTRAINT email_token_purpose_check
+    CHECK (purpose IN ('verify', 'reset', 'email_change', 'email_change_cancel'));
+
+ALTER TABLE users
+DROP COLUMN IF EXISTS is_activated;
@@ -0,0 +1,12 @@
+ALTER TABLE users
+    ADD is_activated BOOLEAN NOT NULL DEFAULT TRUE;
+
+-- auto-registered users never set their password
+UPDATE users SET is_activated = FALSE WHERE password_hash = ' ';
+
+ALTER TABLE email_tokens
+    DROP CONSTRAINT email_token_purpose_check;
+
+ALTER TABLE email_tokens
+    ADD CONSTRAINT email_token_purpose_check
+    CHECK (purpose IN ('verify', 'reset', 'email_change', 'email_change_cancel', 'activation'));
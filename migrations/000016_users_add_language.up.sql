@@ -0,0 +1,2 @@
+ALTER TABLE users
+    ADD language VARCHAR(8);
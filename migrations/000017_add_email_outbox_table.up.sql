@@ -0,0 +1,17 @@
+CREATE TABLE email_outbox (
+    id INTEGER PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    event_id VARCHAR(36) UNIQUE NOT NULL,
+    recipients VARCHAR(254)[] NOT NULL,
+    subject VARCHAR NOT NULL,
+    text_body VARCHAR NOT NULL,
+    html_body VARCHAR NOT NULL DEFAULT '',
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error VARCHAR,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    sent_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT email_outbox_status_check CHECK (status IN ('pending', 'sent', 'dead'))
+);
+
+CREATE INDEX email_outbox_pending_idx ON email_outbox (next_attempt_at) WHERE status = 'pending';
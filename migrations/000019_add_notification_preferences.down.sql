@@ -0,0 +1,4 @@
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_notification_digest_check;
+ALTER TABLE users DROP COLUMN IF EXISTS notification_digest;
+
+DROP TABLE IF EXISTS notification_preferences;
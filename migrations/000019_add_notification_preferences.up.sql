@@ -0,0 +1,13 @@
+CREATE TABLE notification_preferences (
+    user_id INTEGER NOT NULL,
+    type VARCHAR(32) NOT NULL,
+    channel VARCHAR(16) NOT NULL,
+    enabled BOOLEAN NOT NULL,
+    PRIMARY KEY (user_id, type, channel),
+    CONSTRAINT fk_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT notification_preference_type_check CHECK (type IN ('project_invite')),
+    CONSTRAINT notification_preference_channel_check CHECK (channel IN ('email', 'in_app'))
+);
+
+ALTER TABLE users ADD COLUMN notification_digest VARCHAR(16) NOT NULL DEFAULT 'none';
+ALTER TABLE users ADD CONSTRAINT users_notification_digest_check CHECK (notification_digest IN ('none', 'daily', 'weekly'));
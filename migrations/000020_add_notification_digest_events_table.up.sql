@@ -0,0 +1,15 @@
+CREATE TABLE notification_digest_events (
+    id INTEGER PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    user_id INTEGER NOT NULL,
+    type VARCHAR(32) NOT NULL,
+    project_id INTEGER,
+    task_id INTEGER,
+    payload JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    -- events are not claimed by other runs until this time
+    claimed_until TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT fk_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT notification_digest_event_type_check CHECK (type IN ('project_invite'))
+);
+
+CREATE INDEX notification_digest_events_user_idx ON notification_digest_events (user_id, created_at);
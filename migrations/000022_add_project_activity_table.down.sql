@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS project_activity;
+DROP FUNCTION IF EXISTS project_activity_forbid_update();
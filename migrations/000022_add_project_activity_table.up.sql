@@ -0,0 +1,29 @@
+CREATE TABLE project_activity (
+    id INTEGER PRIMARY KEY GENERATED ALWAYS AS IDENTITY,
+    project_id INTEGER NOT NULL,
+    -- actor is kept empty when the user account is removed
+    actor_id INTEGER,
+    action VARCHAR(64) NOT NULL,
+    entity_type VARCHAR(32) NOT NULL,
+    entity_id INTEGER NOT NULL,
+    diff JSONB NOT NULL DEFAULT '{}',
+    request_id VARCHAR(64),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_project FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE,
+    CONSTRAINT fk_actor FOREIGN KEY (actor_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX project_activity_project_idx ON project_activity (project_id, created_at DESC);
+CREATE INDEX project_activity_actor_idx ON project_activity (project_id, actor_id);
+CREATE INDEX project_activity_entity_idx ON project_activity (project_id, entity_type, entity_id);
+
+-- activity log is append-only, entries can be removed only together with the project
+CREATE FUNCTION project_activity_forbid_update() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'project activity is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER project_activity_append_only
+    BEFORE UPDATE ON project_activity
+    FOR EACH ROW EXECUTE FUNCTION project_activity_forbid_update();
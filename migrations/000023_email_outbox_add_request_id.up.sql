@@ -0,0 +1,2 @@
+ALTER TABLE email_outbox
+    ADD request_id VARCHAR(64);
@@ -13,6 +13,7 @@ import (
 	context "context"
 	reflect "reflect"
 	dto "task-trail/internal/usecase/dto"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -79,6 +80,20 @@ func (m *MockUserRepository) EXPECT() *MockUserRepositoryMockRecorder {
 	return m.recorder
 }
 
+// Anonymize mocks base method.
+func (m *MockUserRepository) Anonymize(ctx context.Context, ID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Anonymize", ctx, ID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Anonymize indicates an expected call of Anonymize.
+func (mr *MockUserRepositoryMockRecorder) Anonymize(ctx, ID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Anonymize", reflect.TypeOf((*MockUserRepository)(nil).Anonymize), ctx, ID)
+}
+
 // Create mocks base method.
 func (m *MockUserRepository) Create(ctx context.Context, arg1 *dto.UserCreate) (int, error) {
 	m.ctrl.T.Helper()
@@ -154,6 +169,50 @@ func (mr *MockUserRepositoryMockRecorder) GetIdsByEmails(ctx, emails any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdsByEmails", reflect.TypeOf((*MockUserRepository)(nil).GetIdsByEmails), ctx, emails)
 }
 
+// GetLanguagesByEmails mocks base method.
+func (m *MockUserRepository) GetLanguagesByEmails(ctx context.Context, emails []string) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLanguagesByEmails", ctx, emails)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLanguagesByEmails indicates an expected call of GetLanguagesByEmails.
+func (mr *MockUserRepositoryMockRecorder) GetLanguagesByEmails(ctx, emails any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLanguagesByEmails", reflect.TypeOf((*MockUserRepository)(nil).GetLanguagesByEmails), ctx, emails)
+}
+
+// GetScheduledForDeletion mocks base method.
+func (m *MockUserRepository) GetScheduledForDeletion(ctx context.Context, olderThan int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScheduledForDeletion", ctx, olderThan)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScheduledForDeletion indicates an expected call of GetScheduledForDeletion.
+func (mr *MockUserRepositoryMockRecorder) GetScheduledForDeletion(ctx, olderThan any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduledForDeletion", reflect.TypeOf((*MockUserRepository)(nil).GetScheduledForDeletion), ctx, olderThan)
+}
+
+// SetDeletionRequestedAt mocks base method.
+func (m *MockUserRepository) SetDeletionRequestedAt(ctx context.Context, ID int, requestedAt *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDeletionRequestedAt", ctx, ID, requestedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDeletionRequestedAt indicates an expected call of SetDeletionRequestedAt.
+func (mr *MockUserRepositoryMockRecorder) SetDeletionRequestedAt(ctx, ID, requestedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDeletionRequestedAt", reflect.TypeOf((*MockUserRepository)(nil).SetDeletionRequestedAt), ctx, ID, requestedAt)
+}
+
 // Update mocks base method.
 func (m *MockUserRepository) Update(ctx context.Context, arg1 *dto.UserUpdate) error {
 	m.ctrl.T.Helper()
@@ -258,6 +317,21 @@ func (mr *MockRefreshTokenRepositoryMockRecorder) Create(ctx, data any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRefreshTokenRepository)(nil).Create), ctx, data)
 }
 
+// DeleteAllUsersTokens mocks base method.
+func (m *MockRefreshTokenRepository) DeleteAllUsersTokens(ctx context.Context, userID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAllUsersTokens", ctx, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAllUsersTokens indicates an expected call of DeleteAllUsersTokens.
+func (mr *MockRefreshTokenRepositoryMockRecorder) DeleteAllUsersTokens(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAllUsersTokens", reflect.TypeOf((*MockRefreshTokenRepository)(nil).DeleteAllUsersTokens), ctx, userID)
+}
+
 // DeleteRevokedAndOldTokens mocks base method.
 func (m *MockRefreshTokenRepository) DeleteRevokedAndOldTokens(ctx context.Context, olderThan int) (int, error) {
 	m.ctrl.T.Helper()
@@ -355,6 +429,21 @@ func (mr *MockEmailTokenRepositoryMockRecorder) Create(ctx, data any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockEmailTokenRepository)(nil).Create), ctx, data)
 }
 
+// DeleteAllUsersTokens mocks base method.
+func (m *MockEmailTokenRepository) DeleteAllUsersTokens(ctx context.Context, userID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAllUsersTokens", ctx, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAllUsersTokens indicates an expected call of DeleteAllUsersTokens.
+func (mr *MockEmailTokenRepositoryMockRecorder) DeleteAllUsersTokens(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAllUsersTokens", reflect.TypeOf((*MockEmailTokenRepository)(nil).DeleteAllUsersTokens), ctx, userID)
+}
+
 // DeleteUsedAndOldTokens mocks base method.
 func (m *MockEmailTokenRepository) DeleteUsedAndOldTokens(ctx context.Context, olderThan int) (int, error) {
 	m.ctrl.T.Helper()
@@ -424,17 +513,45 @@ func (m *MockNotificationRepository) EXPECT() *MockNotificationRepositoryMockRec
 }
 
 // SendAutoRegisterEmail mocks base method.
-func (m *MockNotificationRepository) SendAutoRegisterEmail(ctx context.Context, email string) error {
+func (m *MockNotificationRepository) SendAutoRegisterEmail(ctx context.Context, email, token string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SendAutoRegisterEmail", ctx, email)
+	ret := m.ctrl.Call(m, "SendAutoRegisterEmail", ctx, email, token)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // SendAutoRegisterEmail indicates an expected call of SendAutoRegisterEmail.
-func (mr *MockNotificationRepositoryMockRecorder) SendAutoRegisterEmail(ctx, email any) *gomock.Call {
+func (mr *MockNotificationRepositoryMockRecorder) SendAutoRegisterEmail(ctx, email, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendAutoRegisterEmail", reflect.TypeOf((*MockNotificationRepository)(nil).SendAutoRegisterEmail), ctx, email, token)
+}
+
+// SendEmailChangeEmail mocks base method.
+func (m *MockNotificationRepository) SendEmailChangeEmail(ctx context.Context, email, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendEmailChangeEmail", ctx, email, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendEmailChangeEmail indicates an expected call of SendEmailChangeEmail.
+func (mr *MockNotificationRepositoryMockRecorder) SendEmailChangeEmail(ctx, email, token any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendAutoRegisterEmail", reflect.TypeOf((*MockNotificationRepository)(nil).SendAutoRegisterEmail), ctx, email)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendEmailChangeEmail", reflect.TypeOf((*MockNotificationRepository)(nil).SendEmailChangeEmail), ctx, email, token)
+}
+
+// SendEmailChangeNotice mocks base method.
+func (m *MockNotificationRepository) SendEmailChangeNotice(ctx context.Context, data *dto.NotificationEmailChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendEmailChangeNotice", ctx, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendEmailChangeNotice indicates an expected call of SendEmailChangeNotice.
+func (mr *MockNotificationRepositoryMockRecorder) SendEmailChangeNotice(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendEmailChangeNotice", reflect.TypeOf((*MockNotificationRepository)(nil).SendEmailChangeNotice), ctx, data)
 }
 
 // SendInvintationInProject mocks base method.
@@ -479,6 +596,590 @@ func (mr *MockNotificationRepositoryMockRecorder) SendVerificationEmail(ctx, ema
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendVerificationEmail", reflect.TypeOf((*MockNotificationRepository)(nil).SendVerificationEmail), ctx, email, token)
 }
 
+// MockInAppNotificationRepository is a mock of InAppNotificationRepository interface.
+type MockInAppNotificationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockInAppNotificationRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockInAppNotificationRepositoryMockRecorder is the mock recorder for MockInAppNotificationRepository.
+type MockInAppNotificationRepositoryMockRecorder struct {
+	mock *MockInAppNotificationRepository
+}
+
+// NewMockInAppNotificationRepository creates a new mock instance.
+func NewMockInAppNotificationRepository(ctrl *gomock.Controller) *MockInAppNotificationRepository {
+	mock := &MockInAppNotificationRepository{ctrl: ctrl}
+	mock.recorder = &MockInAppNotificationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInAppNotificationRepository) EXPECT() *MockInAppNotificationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockInAppNotificationRepository) Count(ctx context.Context, userID int) (int, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockInAppNotificationRepositoryMockRecorder) Count(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockInAppNotificationRepository)(nil).Count), ctx, userID)
+}
+
+// Create mocks base method.
+func (m *MockInAppNotificationRepository) Create(ctx context.Context, data []*dto.NotificationCreate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockInAppNotificationRepositoryMockRecorder) Create(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockInAppNotificationRepository)(nil).Create), ctx, data)
+}
+
+// GetList mocks base method.
+func (m *MockInAppNotificationRepository) GetList(ctx context.Context, data *dto.NotificationList) ([]*dto.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetList", ctx, data)
+	ret0, _ := ret[0].([]*dto.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetList indicates an expected call of GetList.
+func (mr *MockInAppNotificationRepositoryMockRecorder) GetList(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockInAppNotificationRepository)(nil).GetList), ctx, data)
+}
+
+// MarkAllRead mocks base method.
+func (m *MockInAppNotificationRepository) MarkAllRead(ctx context.Context, userID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAllRead", ctx, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkAllRead indicates an expected call of MarkAllRead.
+func (mr *MockInAppNotificationRepositoryMockRecorder) MarkAllRead(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllRead", reflect.TypeOf((*MockInAppNotificationRepository)(nil).MarkAllRead), ctx, userID)
+}
+
+// MarkRead mocks base method.
+func (m *MockInAppNotificationRepository) MarkRead(ctx context.Context, userID, ID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRead", ctx, userID, ID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkRead indicates an expected call of MarkRead.
+func (mr *MockInAppNotificationRepositoryMockRecorder) MarkRead(ctx, userID, ID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRead", reflect.TypeOf((*MockInAppNotificationRepository)(nil).MarkRead), ctx, userID, ID)
+}
+
+// MockActivityRepository is a mock of ActivityRepository interface.
+type MockActivityRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockActivityRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockActivityRepositoryMockRecorder is the mock recorder for MockActivityRepository.
+type MockActivityRepositoryMockRecorder struct {
+	mock *MockActivityRepository
+}
+
+// NewMockActivityRepository creates a new mock instance.
+func NewMockActivityRepository(ctrl *gomock.Controller) *MockActivityRepository {
+	mock := &MockActivityRepository{ctrl: ctrl}
+	mock.recorder = &MockActivityRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockActivityRepository) EXPECT() *MockActivityRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockActivityRepository) Count(ctx context.Context, data *dto.ActivityList) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, data)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockActivityRepositoryMockRecorder) Count(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockActivityRepository)(nil).Count), ctx, data)
+}
+
+// Create mocks base method.
+func (m *MockActivityRepository) Create(ctx context.Context, data []*dto.ActivityCreate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockActivityRepositoryMockRecorder) Create(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockActivityRepository)(nil).Create), ctx, data)
+}
+
+// GetList mocks base method.
+func (m *MockActivityRepository) GetList(ctx context.Context, data *dto.ActivityList) ([]*dto.Activity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetList", ctx, data)
+	ret0, _ := ret[0].([]*dto.Activity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetList indicates an expected call of GetList.
+func (mr *MockActivityRepositoryMockRecorder) GetList(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockActivityRepository)(nil).GetList), ctx, data)
+}
+
+// MockNotificationPreferenceRepository is a mock of NotificationPreferenceRepository interface.
+type MockNotificationPreferenceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationPreferenceRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockNotificationPreferenceRepositoryMockRecorder is the mock recorder for MockNotificationPreferenceRepository.
+type MockNotificationPreferenceRepositoryMockRecorder struct {
+	mock *MockNotificationPreferenceRepository
+}
+
+// NewMockNotificationPreferenceRepository creates a new mock instance.
+func NewMockNotificationPreferenceRepository(ctrl *gomock.Controller) *MockNotificationPreferenceRepository {
+	mock := &MockNotificationPreferenceRepository{ctrl: ctrl}
+	mock.recorder = &MockNotificationPreferenceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationPreferenceRepository) EXPECT() *MockNotificationPreferenceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockNotificationPreferenceRepository) Get(ctx context.Context, userID int) (*dto.NotificationPreferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, userID)
+	ret0, _ := ret[0].(*dto.NotificationPreferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockNotificationPreferenceRepositoryMockRecorder) Get(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockNotificationPreferenceRepository)(nil).Get), ctx, userID)
+}
+
+// GetDigests mocks base method.
+func (m *MockNotificationPreferenceRepository) GetDigests(ctx context.Context, userIDs []int) (map[int]dto.DigestFrequency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDigests", ctx, userIDs)
+	ret0, _ := ret[0].(map[int]dto.DigestFrequency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDigests indicates an expected call of GetDigests.
+func (mr *MockNotificationPreferenceRepositoryMockRecorder) GetDigests(ctx, userIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDigests", reflect.TypeOf((*MockNotificationPreferenceRepository)(nil).GetDigests), ctx, userIDs)
+}
+
+// GetEnabled mocks base method.
+func (m *MockNotificationPreferenceRepository) GetEnabled(ctx context.Context, userIDs []int, t dto.NotificationType, channel dto.NotificationChannel) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnabled", ctx, userIDs, t, channel)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEnabled indicates an expected call of GetEnabled.
+func (mr *MockNotificationPreferenceRepositoryMockRecorder) GetEnabled(ctx, userIDs, t, channel any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnabled", reflect.TypeOf((*MockNotificationPreferenceRepository)(nil).GetEnabled), ctx, userIDs, t, channel)
+}
+
+// Update mocks base method.
+func (m *MockNotificationPreferenceRepository) Update(ctx context.Context, data *dto.NotificationPreferences) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockNotificationPreferenceRepositoryMockRecorder) Update(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockNotificationPreferenceRepository)(nil).Update), ctx, data)
+}
+
+// MockNotificationDigestRepository is a mock of NotificationDigestRepository interface.
+type MockNotificationDigestRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationDigestRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockNotificationDigestRepositoryMockRecorder is the mock recorder for MockNotificationDigestRepository.
+type MockNotificationDigestRepositoryMockRecorder struct {
+	mock *MockNotificationDigestRepository
+}
+
+// NewMockNotificationDigestRepository creates a new mock instance.
+func NewMockNotificationDigestRepository(ctrl *gomock.Controller) *MockNotificationDigestRepository {
+	mock := &MockNotificationDigestRepository{ctrl: ctrl}
+	mock.recorder = &MockNotificationDigestRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationDigestRepository) EXPECT() *MockNotificationDigestRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimPending mocks base method.
+func (m *MockNotificationDigestRepository) ClaimPending(ctx context.Context, frequencies []dto.DigestFrequency, until time.Time) ([]*dto.DigestEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimPending", ctx, frequencies, until)
+	ret0, _ := ret[0].([]*dto.DigestEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimPending indicates an expected call of ClaimPending.
+func (mr *MockNotificationDigestRepositoryMockRecorder) ClaimPending(ctx, frequencies, until any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimPending", reflect.TypeOf((*MockNotificationDigestRepository)(nil).ClaimPending), ctx, frequencies, until)
+}
+
+// Create mocks base method.
+func (m *MockNotificationDigestRepository) Create(ctx context.Context, data []*dto.NotificationCreate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockNotificationDigestRepositoryMockRecorder) Create(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockNotificationDigestRepository)(nil).Create), ctx, data)
+}
+
+// Delete mocks base method.
+func (m *MockNotificationDigestRepository) Delete(ctx context.Context, IDs []int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, IDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockNotificationDigestRepositoryMockRecorder) Delete(ctx, IDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockNotificationDigestRepository)(nil).Delete), ctx, IDs)
+}
+
+// MockWebhookRepository is a mock of WebhookRepository interface.
+type MockWebhookRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookRepositoryMockRecorder is the mock recorder for MockWebhookRepository.
+type MockWebhookRepositoryMockRecorder struct {
+	mock *MockWebhookRepository
+}
+
+// NewMockWebhookRepository creates a new mock instance.
+func NewMockWebhookRepository(ctrl *gomock.Controller) *MockWebhookRepository {
+	mock := &MockWebhookRepository{ctrl: ctrl}
+	mock.recorder = &MockWebhookRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookRepository) EXPECT() *MockWebhookRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimPendingDeliveries mocks base method.
+func (m *MockWebhookRepository) ClaimPendingDeliveries(ctx context.Context, limit int, until time.Time) ([]*dto.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimPendingDeliveries", ctx, limit, until)
+	ret0, _ := ret[0].([]*dto.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimPendingDeliveries indicates an expected call of ClaimPendingDeliveries.
+func (mr *MockWebhookRepositoryMockRecorder) ClaimPendingDeliveries(ctx, limit, until any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimPendingDeliveries", reflect.TypeOf((*MockWebhookRepository)(nil).ClaimPendingDeliveries), ctx, limit, until)
+}
+
+// Create mocks base method.
+func (m *MockWebhookRepository) Create(ctx context.Context, data *dto.WebhookCreate) (*dto.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, data)
+	ret0, _ := ret[0].(*dto.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWebhookRepositoryMockRecorder) Create(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWebhookRepository)(nil).Create), ctx, data)
+}
+
+// CreateDelivery mocks base method.
+func (m *MockWebhookRepository) CreateDelivery(ctx context.Context, data *dto.WebhookDeliveryCreate) (*dto.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDelivery", ctx, data)
+	ret0, _ := ret[0].(*dto.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDelivery indicates an expected call of CreateDelivery.
+func (mr *MockWebhookRepositoryMockRecorder) CreateDelivery(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDelivery", reflect.TypeOf((*MockWebhookRepository)(nil).CreateDelivery), ctx, data)
+}
+
+// Delete mocks base method.
+func (m *MockWebhookRepository) Delete(ctx context.Context, projectID, ID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, projectID, ID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWebhookRepositoryMockRecorder) Delete(ctx, projectID, ID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWebhookRepository)(nil).Delete), ctx, projectID, ID)
+}
+
+// GetByID mocks base method.
+func (m *MockWebhookRepository) GetByID(ctx context.Context, projectID, ID int) (*dto.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, projectID, ID)
+	ret0, _ := ret[0].(*dto.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockWebhookRepositoryMockRecorder) GetByID(ctx, projectID, ID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockWebhookRepository)(nil).GetByID), ctx, projectID, ID)
+}
+
+// GetDeliveries mocks base method.
+func (m *MockWebhookRepository) GetDeliveries(ctx context.Context, data *dto.WebhookDeliveryList) ([]*dto.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveries", ctx, data)
+	ret0, _ := ret[0].([]*dto.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveries indicates an expected call of GetDeliveries.
+func (mr *MockWebhookRepositoryMockRecorder) GetDeliveries(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveries", reflect.TypeOf((*MockWebhookRepository)(nil).GetDeliveries), ctx, data)
+}
+
+// GetList mocks base method.
+func (m *MockWebhookRepository) GetList(ctx context.Context, projectID int) ([]*dto.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetList", ctx, projectID)
+	ret0, _ := ret[0].([]*dto.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetList indicates an expected call of GetList.
+func (mr *MockWebhookRepositoryMockRecorder) GetList(ctx, projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockWebhookRepository)(nil).GetList), ctx, projectID)
+}
+
+// GetSubscribed mocks base method.
+func (m *MockWebhookRepository) GetSubscribed(ctx context.Context, projectID int, eventType string) ([]*dto.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscribed", ctx, projectID, eventType)
+	ret0, _ := ret[0].([]*dto.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscribed indicates an expected call of GetSubscribed.
+func (mr *MockWebhookRepositoryMockRecorder) GetSubscribed(ctx, projectID, eventType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscribed", reflect.TypeOf((*MockWebhookRepository)(nil).GetSubscribed), ctx, projectID, eventType)
+}
+
+// SaveAttempt mocks base method.
+func (m *MockWebhookRepository) SaveAttempt(ctx context.Context, data *dto.WebhookAttempt) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAttempt", ctx, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveAttempt indicates an expected call of SaveAttempt.
+func (mr *MockWebhookRepositoryMockRecorder) SaveAttempt(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAttempt", reflect.TypeOf((*MockWebhookRepository)(nil).SaveAttempt), ctx, data)
+}
+
+// MockOutboxRepository is a mock of OutboxRepository interface.
+type MockOutboxRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockOutboxRepositoryMockRecorder is the mock recorder for MockOutboxRepository.
+type MockOutboxRepositoryMockRecorder struct {
+	mock *MockOutboxRepository
+}
+
+// NewMockOutboxRepository creates a new mock instance.
+func NewMockOutboxRepository(ctrl *gomock.Controller) *MockOutboxRepository {
+	mock := &MockOutboxRepository{ctrl: ctrl}
+	mock.recorder = &MockOutboxRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxRepository) EXPECT() *MockOutboxRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimPending mocks base method.
+func (m *MockOutboxRepository) ClaimPending(ctx context.Context, limit int, until time.Time) ([]*dto.OutboxMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimPending", ctx, limit, until)
+	ret0, _ := ret[0].([]*dto.OutboxMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimPending indicates an expected call of ClaimPending.
+func (mr *MockOutboxRepositoryMockRecorder) ClaimPending(ctx, limit, until any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimPending", reflect.TypeOf((*MockOutboxRepository)(nil).ClaimPending), ctx, limit, until)
+}
+
+// Create mocks base method.
+func (m *MockOutboxRepository) Create(ctx context.Context, data *dto.OutboxMessageCreate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOutboxRepositoryMockRecorder) Create(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOutboxRepository)(nil).Create), ctx, data)
+}
+
+// DeleteSentAndOld mocks base method.
+func (m *MockOutboxRepository) DeleteSentAndOld(ctx context.Context, olderThan int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSentAndOld", ctx, olderThan)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSentAndOld indicates an expected call of DeleteSentAndOld.
+func (mr *MockOutboxRepositoryMockRecorder) DeleteSentAndOld(ctx, olderThan any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSentAndOld", reflect.TypeOf((*MockOutboxRepository)(nil).DeleteSentAndOld), ctx, olderThan)
+}
+
+// GetByEventID mocks base method.
+func (m *MockOutboxRepository) GetByEventID(ctx context.Context, eventID string) (*dto.OutboxMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByEventID", ctx, eventID)
+	ret0, _ := ret[0].(*dto.OutboxMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByEventID indicates an expected call of GetByEventID.
+func (mr *MockOutboxRepositoryMockRecorder) GetByEventID(ctx, eventID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEventID", reflect.TypeOf((*MockOutboxRepository)(nil).GetByEventID), ctx, eventID)
+}
+
+// MarkFailed mocks base method.
+func (m *MockOutboxRepository) MarkFailed(ctx context.Context, ID int, lastError string, nextAttemptAt *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkFailed", ctx, ID, lastError, nextAttemptAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkFailed indicates an expected call of MarkFailed.
+func (mr *MockOutboxRepositoryMockRecorder) MarkFailed(ctx, ID, lastError, nextAttemptAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkFailed", reflect.TypeOf((*MockOutboxRepository)(nil).MarkFailed), ctx, ID, lastError, nextAttemptAt)
+}
+
+// MarkSent mocks base method.
+func (m *MockOutboxRepository) MarkSent(ctx context.Context, ID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkSent", ctx, ID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkSent indicates an expected call of MarkSent.
+func (mr *MockOutboxRepositoryMockRecorder) MarkSent(ctx, ID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkSent", reflect.TypeOf((*MockOutboxRepository)(nil).MarkSent), ctx, ID)
+}
+
 // MockFileRepository is a mock of FileRepository interface.
 type MockFileRepository struct {
 	ctrl     *gomock.Controller
@@ -517,6 +1218,75 @@ func (mr *MockFileRepositoryMockRecorder) Create(ctx, file any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockFileRepository)(nil).Create), ctx, file)
 }
 
+// GetByOwner mocks base method.
+func (m *MockFileRepository) GetByOwner(ctx context.Context, ownerID int) ([]*dto.File, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByOwner", ctx, ownerID)
+	ret0, _ := ret[0].([]*dto.File)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByOwner indicates an expected call of GetByOwner.
+func (mr *MockFileRepositoryMockRecorder) GetByOwner(ctx, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOwner", reflect.TypeOf((*MockFileRepository)(nil).GetByOwner), ctx, ownerID)
+}
+
+// SoftDeleteByOwner mocks base method.
+func (m *MockFileRepository) SoftDeleteByOwner(ctx context.Context, ownerID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SoftDeleteByOwner", ctx, ownerID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SoftDeleteByOwner indicates an expected call of SoftDeleteByOwner.
+func (mr *MockFileRepositoryMockRecorder) SoftDeleteByOwner(ctx, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftDeleteByOwner", reflect.TypeOf((*MockFileRepository)(nil).SoftDeleteByOwner), ctx, ownerID)
+}
+
+// MockTaskRepository is a mock of TaskRepository interface.
+type MockTaskRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockTaskRepositoryMockRecorder is the mock recorder for MockTaskRepository.
+type MockTaskRepositoryMockRecorder struct {
+	mock *MockTaskRepository
+}
+
+// NewMockTaskRepository creates a new mock instance.
+func NewMockTaskRepository(ctrl *gomock.Controller) *MockTaskRepository {
+	mock := &MockTaskRepository{ctrl: ctrl}
+	mock.recorder = &MockTaskRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskRepository) EXPECT() *MockTaskRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetByAuthor mocks base method.
+func (m *MockTaskRepository) GetByAuthor(ctx context.Context, authorID int) ([]*dto.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByAuthor", ctx, authorID)
+	ret0, _ := ret[0].([]*dto.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByAuthor indicates an expected call of GetByAuthor.
+func (mr *MockTaskRepositoryMockRecorder) GetByAuthor(ctx, authorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByAuthor", reflect.TypeOf((*MockTaskRepository)(nil).GetByAuthor), ctx, authorID)
+}
+
 // MockProjectRepository is a mock of ProjectRepository interface.
 type MockProjectRepository struct {
 	ctrl     *gomock.Controller
@@ -643,3 +1413,33 @@ func (mr *MockProjectRepositoryMockRecorder) IsMember(ctx, projectID, memberID a
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsMember", reflect.TypeOf((*MockProjectRepository)(nil).IsMember), ctx, projectID, memberID)
 }
+
+// RemoveMemberFromAll mocks base method.
+func (m *MockProjectRepository) RemoveMemberFromAll(ctx context.Context, memberID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMemberFromAll", ctx, memberID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMemberFromAll indicates an expected call of RemoveMemberFromAll.
+func (mr *MockProjectRepositoryMockRecorder) RemoveMemberFromAll(ctx, memberID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMemberFromAll", reflect.TypeOf((*MockProjectRepository)(nil).RemoveMemberFromAll), ctx, memberID)
+}
+
+// TransferOwnership mocks base method.
+func (m *MockProjectRepository) TransferOwnership(ctx context.Context, ownerID int) (int, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferOwnership", ctx, ownerID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// TransferOwnership indicates an expected call of TransferOwnership.
+func (mr *MockProjectRepositoryMockRecorder) TransferOwnership(ctx, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferOwnership", reflect.TypeOf((*MockProjectRepository)(nil).TransferOwnership), ctx, ownerID)
+}
@@ -0,0 +1,106 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/pkg/smtp/contracts.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/pkg/smtp/contracts.go -destination=test/mocks/mock_smtp.go -package=mocks -mock_names=Sender=MockSmtpSender
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	smtp "task-trail/internal/pkg/smtp"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSmtpSender is a mock of Sender interface.
+type MockSmtpSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockSmtpSenderMockRecorder
+	isgomock struct{}
+}
+
+// MockSmtpSenderMockRecorder is the mock recorder for MockSmtpSender.
+type MockSmtpSenderMockRecorder struct {
+	mock *MockSmtpSender
+}
+
+// NewMockSmtpSender creates a new mock instance.
+func NewMockSmtpSender(ctrl *gomock.Controller) *MockSmtpSender {
+	mock := &MockSmtpSender{ctrl: ctrl}
+	mock.recorder = &MockSmtpSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSmtpSender) EXPECT() *MockSmtpSenderMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockSmtpSender) Send(ctx context.Context, msg smtp.Message, eventID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, msg, eventID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockSmtpSenderMockRecorder) Send(ctx, msg, eventID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockSmtpSender)(nil).Send), ctx, msg, eventID)
+}
+
+// MockMailbox is a mock of Mailbox interface.
+type MockMailbox struct {
+	ctrl     *gomock.Controller
+	recorder *MockMailboxMockRecorder
+	isgomock struct{}
+}
+
+// MockMailboxMockRecorder is the mock recorder for MockMailbox.
+type MockMailboxMockRecorder struct {
+	mock *MockMailbox
+}
+
+// NewMockMailbox creates a new mock instance.
+func NewMockMailbox(ctrl *gomock.Controller) *MockMailbox {
+	mock := &MockMailbox{ctrl: ctrl}
+	mock.recorder = &MockMailboxMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMailbox) EXPECT() *MockMailboxMockRecorder {
+	return m.recorder
+}
+
+// Clear mocks base method.
+func (m *MockMailbox) Clear() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Clear")
+}
+
+// Clear indicates an expected call of Clear.
+func (mr *MockMailboxMockRecorder) Clear() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clear", reflect.TypeOf((*MockMailbox)(nil).Clear))
+}
+
+// Messages mocks base method.
+func (m *MockMailbox) Messages() []smtp.SentMessage {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Messages")
+	ret0, _ := ret[0].([]smtp.SentMessage)
+	return ret0
+}
+
+// Messages indicates an expected call of Messages.
+func (mr *MockMailboxMockRecorder) Messages() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Messages", reflect.TypeOf((*MockMailbox)(nil).Messages))
+}
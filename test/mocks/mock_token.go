@@ -12,6 +12,7 @@ package mocks
 import (
 	reflect "reflect"
 	dto "task-trail/internal/usecase/dto"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -71,12 +72,13 @@ func (mr *MockTokenServiceMockRecorder) GenRefreshToken(userID any) *gomock.Call
 }
 
 // VerifyAccessToken mocks base method.
-func (m *MockTokenService) VerifyAccessToken(token string) (int, error) {
+func (m *MockTokenService) VerifyAccessToken(token string) (int, time.Time, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "VerifyAccessToken", token)
 	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(time.Time)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // VerifyAccessToken indicates an expected call of VerifyAccessToken.
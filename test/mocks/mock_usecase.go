@@ -12,7 +12,9 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	pubsub "task-trail/internal/pkg/pubsub"
 	dto "task-trail/internal/usecase/dto"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -41,6 +43,20 @@ func (m *MockAuthentication) EXPECT() *MockAuthenticationMockRecorder {
 	return m.recorder
 }
 
+// Activate mocks base method.
+func (m *MockAuthentication) Activate(ctx context.Context, data *dto.Activation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Activate", ctx, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Activate indicates an expected call of Activate.
+func (mr *MockAuthenticationMockRecorder) Activate(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Activate", reflect.TypeOf((*MockAuthentication)(nil).Activate), ctx, data)
+}
+
 // AutoRegister mocks base method.
 func (m *MockAuthentication) AutoRegister(ctx context.Context, email string) error {
 	m.ctrl.T.Helper()
@@ -55,6 +71,20 @@ func (mr *MockAuthenticationMockRecorder) AutoRegister(ctx, email any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AutoRegister", reflect.TypeOf((*MockAuthentication)(nil).AutoRegister), ctx, email)
 }
 
+// CancelEmailChange mocks base method.
+func (m *MockAuthentication) CancelEmailChange(ctx context.Context, tokenID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelEmailChange", ctx, tokenID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelEmailChange indicates an expected call of CancelEmailChange.
+func (mr *MockAuthenticationMockRecorder) CancelEmailChange(ctx, tokenID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelEmailChange", reflect.TypeOf((*MockAuthentication)(nil).CancelEmailChange), ctx, tokenID)
+}
+
 // ChangePassword mocks base method.
 func (m *MockAuthentication) ChangePassword(ctx context.Context, data *dto.PasswordChange) error {
 	m.ctrl.T.Helper()
@@ -69,6 +99,20 @@ func (mr *MockAuthenticationMockRecorder) ChangePassword(ctx, data any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockAuthentication)(nil).ChangePassword), ctx, data)
 }
 
+// ConfirmEmailChange mocks base method.
+func (m *MockAuthentication) ConfirmEmailChange(ctx context.Context, tokenID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmEmailChange", ctx, tokenID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmEmailChange indicates an expected call of ConfirmEmailChange.
+func (mr *MockAuthenticationMockRecorder) ConfirmEmailChange(ctx, tokenID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmEmailChange", reflect.TypeOf((*MockAuthentication)(nil).ConfirmEmailChange), ctx, tokenID)
+}
+
 // Login mocks base method.
 func (m *MockAuthentication) Login(ctx context.Context, data *dto.Credentials) (*dto.LoginRes, error) {
 	m.ctrl.T.Helper()
@@ -127,6 +171,20 @@ func (mr *MockAuthenticationMockRecorder) Register(ctx, data any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockAuthentication)(nil).Register), ctx, data)
 }
 
+// RequestEmailChange mocks base method.
+func (m *MockAuthentication) RequestEmailChange(ctx context.Context, data *dto.EmailChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestEmailChange", ctx, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestEmailChange indicates an expected call of RequestEmailChange.
+func (mr *MockAuthenticationMockRecorder) RequestEmailChange(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestEmailChange", reflect.TypeOf((*MockAuthentication)(nil).RequestEmailChange), ctx, data)
+}
+
 // ResendVerificationEmail mocks base method.
 func (m *MockAuthentication) ResendVerificationEmail(ctx context.Context, email string) error {
 	m.ctrl.T.Helper()
@@ -207,6 +265,50 @@ func (m *MockUser) EXPECT() *MockUserMockRecorder {
 	return m.recorder
 }
 
+// CancelDeletion mocks base method.
+func (m *MockUser) CancelDeletion(ctx context.Context, ID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelDeletion", ctx, ID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelDeletion indicates an expected call of CancelDeletion.
+func (mr *MockUserMockRecorder) CancelDeletion(ctx, ID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelDeletion", reflect.TypeOf((*MockUser)(nil).CancelDeletion), ctx, ID)
+}
+
+// DeleteScheduled mocks base method.
+func (m *MockUser) DeleteScheduled(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteScheduled", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteScheduled indicates an expected call of DeleteScheduled.
+func (mr *MockUserMockRecorder) DeleteScheduled(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteScheduled", reflect.TypeOf((*MockUser)(nil).DeleteScheduled), ctx)
+}
+
+// Export mocks base method.
+func (m *MockUser) Export(ctx context.Context, ID int) (*dto.UserExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, ID)
+	ret0, _ := ret[0].(*dto.UserExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockUserMockRecorder) Export(ctx, ID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockUser)(nil).Export), ctx, ID)
+}
+
 // GetCurrentByID mocks base method.
 func (m *MockUser) GetCurrentByID(ctx context.Context, ID int) (*dto.CurrentUser, error) {
 	m.ctrl.T.Helper()
@@ -222,6 +324,21 @@ func (mr *MockUserMockRecorder) GetCurrentByID(ctx, ID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentByID", reflect.TypeOf((*MockUser)(nil).GetCurrentByID), ctx, ID)
 }
 
+// RequestDeletion mocks base method.
+func (m *MockUser) RequestDeletion(ctx context.Context, data *dto.AccountDeletion) (*time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestDeletion", ctx, data)
+	ret0, _ := ret[0].(*time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestDeletion indicates an expected call of RequestDeletion.
+func (mr *MockUserMockRecorder) RequestDeletion(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestDeletion", reflect.TypeOf((*MockUser)(nil).RequestDeletion), ctx, data)
+}
+
 // UpdateAvatar mocks base method.
 func (m *MockUser) UpdateAvatar(ctx context.Context, data *dto.FileUpload) (*dto.UserAvatar, error) {
 	m.ctrl.T.Helper()
@@ -388,3 +505,399 @@ func (mr *MockProjectMockRecorder) GetList(ctx, data any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockProject)(nil).GetList), ctx, data)
 }
+
+// MockOutbox is a mock of Outbox interface.
+type MockOutbox struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxMockRecorder
+	isgomock struct{}
+}
+
+// MockOutboxMockRecorder is the mock recorder for MockOutbox.
+type MockOutboxMockRecorder struct {
+	mock *MockOutbox
+}
+
+// NewMockOutbox creates a new mock instance.
+func NewMockOutbox(ctrl *gomock.Controller) *MockOutbox {
+	mock := &MockOutbox{ctrl: ctrl}
+	mock.recorder = &MockOutboxMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutbox) EXPECT() *MockOutboxMockRecorder {
+	return m.recorder
+}
+
+// Deliver mocks base method.
+func (m *MockOutbox) Deliver(ctx context.Context) (*dto.OutboxDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deliver", ctx)
+	ret0, _ := ret[0].(*dto.OutboxDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Deliver indicates an expected call of Deliver.
+func (mr *MockOutboxMockRecorder) Deliver(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deliver", reflect.TypeOf((*MockOutbox)(nil).Deliver), ctx)
+}
+
+// GetStatus mocks base method.
+func (m *MockOutbox) GetStatus(ctx context.Context, eventID string) (*dto.OutboxMessageStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatus", ctx, eventID)
+	ret0, _ := ret[0].(*dto.OutboxMessageStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatus indicates an expected call of GetStatus.
+func (mr *MockOutboxMockRecorder) GetStatus(ctx, eventID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatus", reflect.TypeOf((*MockOutbox)(nil).GetStatus), ctx, eventID)
+}
+
+// MockDigest is a mock of Digest interface.
+type MockDigest struct {
+	ctrl     *gomock.Controller
+	recorder *MockDigestMockRecorder
+	isgomock struct{}
+}
+
+// MockDigestMockRecorder is the mock recorder for MockDigest.
+type MockDigestMockRecorder struct {
+	mock *MockDigest
+}
+
+// NewMockDigest creates a new mock instance.
+func NewMockDigest(ctrl *gomock.Controller) *MockDigest {
+	mock := &MockDigest{ctrl: ctrl}
+	mock.recorder = &MockDigestMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDigest) EXPECT() *MockDigestMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockDigest) Send(ctx context.Context, frequency dto.DigestFrequency) (*dto.DigestDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, frequency)
+	ret0, _ := ret[0].(*dto.DigestDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockDigestMockRecorder) Send(ctx, frequency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockDigest)(nil).Send), ctx, frequency)
+}
+
+// MockNotification is a mock of Notification interface.
+type MockNotification struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationMockRecorder
+	isgomock struct{}
+}
+
+// MockNotificationMockRecorder is the mock recorder for MockNotification.
+type MockNotificationMockRecorder struct {
+	mock *MockNotification
+}
+
+// NewMockNotification creates a new mock instance.
+func NewMockNotification(ctrl *gomock.Controller) *MockNotification {
+	mock := &MockNotification{ctrl: ctrl}
+	mock.recorder = &MockNotificationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotification) EXPECT() *MockNotificationMockRecorder {
+	return m.recorder
+}
+
+// GetList mocks base method.
+func (m *MockNotification) GetList(ctx context.Context, data *dto.NotificationList) (*dto.NotificationPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetList", ctx, data)
+	ret0, _ := ret[0].(*dto.NotificationPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetList indicates an expected call of GetList.
+func (mr *MockNotificationMockRecorder) GetList(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockNotification)(nil).GetList), ctx, data)
+}
+
+// GetPreferences mocks base method.
+func (m *MockNotification) GetPreferences(ctx context.Context, userID int) (*dto.NotificationPreferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreferences", ctx, userID)
+	ret0, _ := ret[0].(*dto.NotificationPreferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreferences indicates an expected call of GetPreferences.
+func (mr *MockNotificationMockRecorder) GetPreferences(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreferences", reflect.TypeOf((*MockNotification)(nil).GetPreferences), ctx, userID)
+}
+
+// MarkAllRead mocks base method.
+func (m *MockNotification) MarkAllRead(ctx context.Context, userID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAllRead", ctx, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkAllRead indicates an expected call of MarkAllRead.
+func (mr *MockNotificationMockRecorder) MarkAllRead(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllRead", reflect.TypeOf((*MockNotification)(nil).MarkAllRead), ctx, userID)
+}
+
+// MarkRead mocks base method.
+func (m *MockNotification) MarkRead(ctx context.Context, userID, ID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRead", ctx, userID, ID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkRead indicates an expected call of MarkRead.
+func (mr *MockNotificationMockRecorder) MarkRead(ctx, userID, ID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRead", reflect.TypeOf((*MockNotification)(nil).MarkRead), ctx, userID, ID)
+}
+
+// UpdatePreferences mocks base method.
+func (m *MockNotification) UpdatePreferences(ctx context.Context, data *dto.NotificationPreferences) (*dto.NotificationPreferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePreferences", ctx, data)
+	ret0, _ := ret[0].(*dto.NotificationPreferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePreferences indicates an expected call of UpdatePreferences.
+func (mr *MockNotificationMockRecorder) UpdatePreferences(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePreferences", reflect.TypeOf((*MockNotification)(nil).UpdatePreferences), ctx, data)
+}
+
+// MockRealtime is a mock of Realtime interface.
+type MockRealtime struct {
+	ctrl     *gomock.Controller
+	recorder *MockRealtimeMockRecorder
+	isgomock struct{}
+}
+
+// MockRealtimeMockRecorder is the mock recorder for MockRealtime.
+type MockRealtimeMockRecorder struct {
+	mock *MockRealtime
+}
+
+// NewMockRealtime creates a new mock instance.
+func NewMockRealtime(ctrl *gomock.Controller) *MockRealtime {
+	mock := &MockRealtime{ctrl: ctrl}
+	mock.recorder = &MockRealtimeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRealtime) EXPECT() *MockRealtimeMockRecorder {
+	return m.recorder
+}
+
+// Subscribe mocks base method.
+func (m *MockRealtime) Subscribe(ctx context.Context, userID int, projectIDs []int) (<-chan pubsub.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", ctx, userID, projectIDs)
+	ret0, _ := ret[0].(<-chan pubsub.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockRealtimeMockRecorder) Subscribe(ctx, userID, projectIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockRealtime)(nil).Subscribe), ctx, userID, projectIDs)
+}
+
+// MockActivity is a mock of Activity interface.
+type MockActivity struct {
+	ctrl     *gomock.Controller
+	recorder *MockActivityMockRecorder
+	isgomock struct{}
+}
+
+// MockActivityMockRecorder is the mock recorder for MockActivity.
+type MockActivityMockRecorder struct {
+	mock *MockActivity
+}
+
+// NewMockActivity creates a new mock instance.
+func NewMockActivity(ctrl *gomock.Controller) *MockActivity {
+	mock := &MockActivity{ctrl: ctrl}
+	mock.recorder = &MockActivityMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockActivity) EXPECT() *MockActivityMockRecorder {
+	return m.recorder
+}
+
+// GetList mocks base method.
+func (m *MockActivity) GetList(ctx context.Context, data *dto.ActivityList) (*dto.ActivityPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetList", ctx, data)
+	ret0, _ := ret[0].(*dto.ActivityPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetList indicates an expected call of GetList.
+func (mr *MockActivityMockRecorder) GetList(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockActivity)(nil).GetList), ctx, data)
+}
+
+// MockWebhook is a mock of Webhook interface.
+type MockWebhook struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookMockRecorder is the mock recorder for MockWebhook.
+type MockWebhookMockRecorder struct {
+	mock *MockWebhook
+}
+
+// NewMockWebhook creates a new mock instance.
+func NewMockWebhook(ctrl *gomock.Controller) *MockWebhook {
+	mock := &MockWebhook{ctrl: ctrl}
+	mock.recorder = &MockWebhookMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhook) EXPECT() *MockWebhookMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockWebhook) Create(ctx context.Context, data *dto.WebhookCreate) (*dto.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, data)
+	ret0, _ := ret[0].(*dto.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWebhookMockRecorder) Create(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWebhook)(nil).Create), ctx, data)
+}
+
+// Delete mocks base method.
+func (m *MockWebhook) Delete(ctx context.Context, projectID, ownerID, ID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, projectID, ownerID, ID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWebhookMockRecorder) Delete(ctx, projectID, ownerID, ID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWebhook)(nil).Delete), ctx, projectID, ownerID, ID)
+}
+
+// Deliver mocks base method.
+func (m *MockWebhook) Deliver(ctx context.Context) (*dto.WebhookDeliveryStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deliver", ctx)
+	ret0, _ := ret[0].(*dto.WebhookDeliveryStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Deliver indicates an expected call of Deliver.
+func (mr *MockWebhookMockRecorder) Deliver(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deliver", reflect.TypeOf((*MockWebhook)(nil).Deliver), ctx)
+}
+
+// GetDeliveries mocks base method.
+func (m *MockWebhook) GetDeliveries(ctx context.Context, data *dto.WebhookDeliveryList) ([]*dto.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveries", ctx, data)
+	ret0, _ := ret[0].([]*dto.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveries indicates an expected call of GetDeliveries.
+func (mr *MockWebhookMockRecorder) GetDeliveries(ctx, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveries", reflect.TypeOf((*MockWebhook)(nil).GetDeliveries), ctx, data)
+}
+
+// GetList mocks base method.
+func (m *MockWebhook) GetList(ctx context.Context, projectID, ownerID int) ([]*dto.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetList", ctx, projectID, ownerID)
+	ret0, _ := ret[0].([]*dto.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetList indicates an expected call of GetList.
+func (mr *MockWebhookMockRecorder) GetList(ctx, projectID, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockWebhook)(nil).GetList), ctx, projectID, ownerID)
+}
+
+// Publish mocks base method.
+func (m *MockWebhook) Publish(ctx context.Context, event pubsub.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockWebhookMockRecorder) Publish(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockWebhook)(nil).Publish), ctx, event)
+}
+
+// SendTest mocks base method.
+func (m *MockWebhook) SendTest(ctx context.Context, projectID, ownerID, ID int) (*dto.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendTest", ctx, projectID, ownerID, ID)
+	ret0, _ := ret[0].(*dto.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendTest indicates an expected call of SendTest.
+func (mr *MockWebhookMockRecorder) SendTest(ctx, projectID, ownerID, ID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendTest", reflect.TypeOf((*MockWebhook)(nil).SendTest), ctx, projectID, ownerID, ID)
+}